@@ -76,6 +76,7 @@ import "C"
 import (
 	"flag"
 	"math"
+	"os"
 	"strings"
 	"sync"
 	"unsafe"
@@ -90,6 +91,7 @@ import (
 	"github.com/lf-edge/edge-home-orchestration-go/internal/controller/securemgr/verifier"
 	"github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr"
 	"github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr/executor/nativeexecutor"
+	"github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr/executor/systemdexecutor"
 	"github.com/lf-edge/edge-home-orchestration-go/internal/controller/storagemgr"
 	"github.com/lf-edge/edge-home-orchestration-go/internal/db/bolt/wrapper"
 	"github.com/lf-edge/edge-home-orchestration-go/internal/orchestrationapi"
@@ -173,7 +175,18 @@ func OrchestrationInit(secure C.int, mnedc C.int) C.int {
 	builder.SetVerifierConf(verifier.GetInstance())
 	builder.SetScoring(scoringmgr.GetInstance())
 	builder.SetService(servicemgr.GetInstance())
-	builder.SetExecutor(nativeexecutor.GetInstance())
+	if os.Getenv("NATIVE_EXECUTOR") == systemdexecutor.Name {
+		log.Println(logPrefix, "Orchestration init with systemd executor")
+		seImpl, err := systemdexecutor.NewSEImpl()
+		if err != nil {
+			log.Fatalf("%s Failed to connect to systemd: %s", logPrefix, err.Error())
+			return -1
+		}
+		systemdexecutor.GetInstance().SetSEImpl(seImpl)
+		builder.SetExecutor(systemdexecutor.GetInstance())
+	} else {
+		builder.SetExecutor(nativeexecutor.GetInstance())
+	}
 	builder.SetClient(restIns)
 	orcheEngine = builder.Build()
 	if orcheEngine == nil {
@@ -21,7 +21,11 @@ package main
 import (
 	"errors"
 	"os"
+	"strconv"
 	"strings"
+	"time"
+
+	units "github.com/docker/go-units"
 
 	"github.com/lf-edge/edge-home-orchestration-go/internal/common/fscreator"
 	"github.com/lf-edge/edge-home-orchestration-go/internal/common/logmgr"
@@ -35,7 +39,10 @@ import (
 	"github.com/lf-edge/edge-home-orchestration-go/internal/controller/securemgr/verifier"
 	"github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr"
 	executor "github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr/executor/containerexecutor"
+	"github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr/executor/wasmexecutor"
+	"github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr/servicelog"
 	"github.com/lf-edge/edge-home-orchestration-go/internal/controller/storagemgr"
+	"github.com/lf-edge/edge-home-orchestration-go/internal/db/bolt/execution"
 	"github.com/lf-edge/edge-home-orchestration-go/internal/db/bolt/wrapper"
 	"github.com/lf-edge/edge-home-orchestration-go/internal/orchestrationapi"
 	"github.com/lf-edge/edge-home-orchestration-go/internal/restinterface/cipher/dummy"
@@ -51,19 +58,21 @@ const logPrefix = "[interface]"
 
 // Handle Platform Dependencies
 const (
-	platform      = "docker"
-	executionType = "container"
+	platform             = "docker"
+	defaultExecutionType = "container"
 
 	edgeDir = "/var/edge-orchestration"
 
 	logPath             = edgeDir + "/log"
+	serviceLogPath      = logPath + "/services"
 	configPath          = edgeDir + "/apps"
 	dbPath              = edgeDir + "/data/db"
 	certificateFilePath = edgeDir + "/certs"
 
-	cipherKeyFilePath = edgeDir + "/user/orchestration_userID.txt"
-	deviceIDFilePath  = edgeDir + "/device/orchestration_deviceID.txt"
-	mnedcServerConfig = edgeDir + "/mnedc/client-config.yaml"
+	cipherKeyFilePath    = edgeDir + "/user/orchestration_userID.txt"
+	registryAuthFilePath = edgeDir + "/user/registry_auth.json"
+	deviceIDFilePath     = edgeDir + "/device/orchestration_deviceID.txt"
+	mnedcServerConfig    = edgeDir + "/mnedc/client-config.yaml"
 )
 
 var (
@@ -85,6 +94,7 @@ func orchestrationInit() error {
 	log.Println(">>> commitID  : ", commitID)
 	log.Println(">>> version   : ", version)
 	wrapper.SetBoltDBPath(dbPath)
+	servicelog.SetLogDir(serviceLogPath)
 
 	if err := fscreator.CreateFileSystem(edgeDir); err != nil {
 		log.Panicf("%s Failed to create edge-orchestration file system\n", logPrefix)
@@ -94,6 +104,46 @@ func orchestrationInit() error {
 	secure := os.Getenv("SECURE")
 	mnedc := os.Getenv("MNEDC")
 	ui := os.Getenv("WEBUI")
+	containerRuntime := os.Getenv("CONTAINER_RUNTIME")
+	containerEndpoint := os.Getenv("CONTAINER_RUNTIME_ENDPOINT")
+	containerReconcile := os.Getenv("CONTAINER_RECONCILE")
+	containerMaxCPUShares := os.Getenv("CONTAINER_MAX_CPU_SHARES")
+	containerMaxMemory := os.Getenv("CONTAINER_MAX_MEMORY")
+	historyMaxRecords := os.Getenv("EXECUTION_HISTORY_MAX_RECORDS")
+	historyMaxAge := os.Getenv("EXECUTION_HISTORY_MAX_AGE")
+	clientTimeout := os.Getenv("CLIENT_STATUS_TIMEOUT")
+	clientMaxWaiting := os.Getenv("CLIENT_MAX_WAITING")
+	retryFailureTimeout := os.Getenv("RETRY_FAILURE_TIMEOUT")
+
+	executionType := os.Getenv("EXECUTION_TYPE")
+	if len(executionType) == 0 {
+		executionType = defaultExecutionType
+	} else if executionType != defaultExecutionType && executionType != wasmexecutor.ExecutionType {
+		log.Fatalf("%s Execution type %s is not supported", logPrefix, executionType)
+		return errors.New("unsupported execution type")
+	}
+
+	maxRecords, maxAge, err := getExecutionRetention(historyMaxRecords, historyMaxAge)
+	if err != nil {
+		log.Fatalf("%s Execution history retention is invalid : %s", logPrefix, err.Error())
+		return err
+	}
+	execution.SetRetention(maxRecords, maxAge)
+
+	timeout, maxClients, err := getClientLimits(clientTimeout, clientMaxWaiting)
+	if err != nil {
+		log.Fatalf("%s Client limits are invalid : %s", logPrefix, err.Error())
+		return err
+	}
+	orchestrationapi.SetClientTimeout(timeout)
+	orchestrationapi.SetMaxClients(maxClients)
+
+	failureTimeout, err := getFailureTimeout(retryFailureTimeout)
+	if err != nil {
+		log.Fatalf("%s Failure timeout is invalid : %s", logPrefix, err.Error())
+		return err
+	}
+	orchestrationapi.SetFailureTimeout(failureTimeout)
 
 	isSecured := false
 	if len(secure) > 0 {
@@ -124,7 +174,29 @@ func orchestrationInit() error {
 	builder.SetVerifierConf(verifier.GetInstance())
 	builder.SetScoring(scoringmgr.GetInstance())
 	builder.SetService(servicemgr.GetInstance())
-	builder.SetExecutor(executor.GetInstance())
+	if executionType == wasmexecutor.ExecutionType {
+		log.Println(logPrefix, "Orchestration init with WebAssembly executor")
+		builder.SetExecutor(wasmexecutor.GetInstance())
+	} else {
+		executor.SetRegistryAuthFile(registryAuthFilePath)
+		ceiling, err := getResourceCeiling(containerMaxCPUShares, containerMaxMemory)
+		if err != nil {
+			log.Fatalf("%s Container resource ceiling is invalid : %s", logPrefix, err.Error())
+			return err
+		}
+		executor.SetResourceCeiling(ceiling)
+		if len(containerRuntime) > 0 || len(containerEndpoint) > 0 {
+			ceImpl, err := executor.NewCEImpl(containerRuntime, containerEndpoint)
+			if err != nil {
+				log.Fatalf("%s Container runtime initialize fail : %s", logPrefix, err.Error())
+				return err
+			}
+			log.Println(logPrefix, "Orchestration init with container runtime", containerRuntime)
+			executor.GetInstance().SetCEImpl(ceImpl)
+		}
+		executor.GetInstance().WatchEvents()
+		builder.SetExecutor(executor.GetInstance())
+	}
 	builder.SetClient(restIns)
 
 	orcheEngine := builder.Build()
@@ -165,6 +237,12 @@ func orchestrationInit() error {
 	ehandle.SetCipher(dummy.GetCipher(cipherKeyFilePath))
 	restEdgeRouter.Add(ehandle)
 
+	if executionType == defaultExecutionType {
+		if err := executor.GetInstance().Reconcile(containerReconcile); err != nil {
+			log.Println(logPrefix, "Containers reconcile fail :", err.Error())
+		}
+	}
+
 	restEdgeRouter.Start()
 
 	if len(mnedc) > 0 {
@@ -189,3 +267,52 @@ func orchestrationInit() error {
 
 	return nil
 }
+
+// getResourceCeiling converts the ceiling of the container resources, the empty values mean the defaults
+func getResourceCeiling(cpuShares, memory string) (ceiling executor.ResourceCeiling, err error) {
+	if len(cpuShares) > 0 {
+		if ceiling.CPUShares, err = strconv.ParseInt(cpuShares, 10, 64); err != nil {
+			return
+		}
+	}
+	if len(memory) > 0 {
+		ceiling.Memory, err = units.RAMInBytes(memory)
+	}
+	return
+}
+
+// getExecutionRetention converts the retention of the execution history, the empty values mean the defaults
+func getExecutionRetention(maxRecords, maxAge string) (records int, age time.Duration, err error) {
+	records, age = execution.DefaultMaxRecords, execution.DefaultMaxAge
+	if len(maxRecords) > 0 {
+		if records, err = strconv.Atoi(maxRecords); err != nil {
+			return
+		}
+	}
+	if len(maxAge) > 0 {
+		age, err = time.ParseDuration(maxAge)
+	}
+	return
+}
+
+// getClientLimits converts the limits of the requesters waiting for the service status, the empty values mean the defaults
+func getClientLimits(timeout, maxWaiting string) (wait time.Duration, maxClients int, err error) {
+	wait, maxClients = orchestrationapi.DefaultClientTimeout, orchestrationapi.DefaultMaxClients
+	if len(timeout) > 0 {
+		if wait, err = time.ParseDuration(timeout); err != nil {
+			return
+		}
+	}
+	if len(maxWaiting) > 0 {
+		maxClients, err = strconv.Atoi(maxWaiting)
+	}
+	return
+}
+
+// getFailureTimeout converts the time to wait for the Failed status before trying the next device, the empty value means the default
+func getFailureTimeout(timeout string) (time.Duration, error) {
+	if len(timeout) == 0 {
+		return orchestrationapi.DefaultFailureTimeout, nil
+	}
+	return time.ParseDuration(timeout)
+}
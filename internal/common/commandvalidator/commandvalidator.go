@@ -53,7 +53,7 @@ func (CommandValidator) GetCommand(serviceName string) (string, error) {
 
 // AddWhiteCommand adds a command to support servicelist
 func (CommandValidator) AddWhiteCommand(serviceInfo configuremgrtypes.ServiceInfo) error {
-	if serviceInfo.ExecType == "native" {
+	if serviceInfo.ExecType == "native" || serviceInfo.ExecType == "wasm" {
 		command, err := getExecutableName(serviceInfo.ExecutableFileName)
 		if err != nil {
 			return err
@@ -19,6 +19,8 @@
 package detector
 
 import (
+	"net"
+
 	"github.com/lf-edge/edge-home-orchestration-go/internal/common/logmgr"
 
 	"github.com/vishvananda/netlink"
@@ -74,7 +76,7 @@ func (dt detectorImpl) AddrSubscribe(isTrue chan<- bool) {
 
 func detectionHandler(detect netlink.AddrUpdate) bool {
 	updatedAddr := detect
-	if updatedAddr.LinkAddress.IP.To4() == nil {
+	if ip := updatedAddr.LinkAddress.IP; ip.To4() == nil && !IsRoutableIPv6(ip) {
 		return false
 	}
 
@@ -86,3 +88,8 @@ func detectionHandler(detect netlink.AddrUpdate) bool {
 	log.Println(logPrefix, "Disconnected : ", updatedAddr.LinkAddress.IP)
 	return true
 }
+
+// IsRoutableIPv6 returns true for global and unique local IPv6 addresses, link local ones are reachable only with the zone
+func IsRoutableIPv6(ip net.IP) bool {
+	return ip.IsGlobalUnicast()
+}
@@ -66,6 +66,40 @@ func TestAddrSubscribe(t *testing.T) {
 			}
 			go GetInstance().AddrSubscribe(retTrue)
 
+			ret := <-retTrue
+			if !ret {
+				t.Error("unexpected result")
+			}
+		})
+		t.Run("IPv6Connected", func(t *testing.T) {
+			lambdaAddrSubscribe = func(ch chan<- netlink.AddrUpdate) error {
+				ch <- netlink.AddrUpdate{
+					NewAddr: true,
+					LinkAddress: net.IPNet{
+						IP: net.ParseIP("2001:db8::1"),
+					},
+				}
+				return nil
+			}
+			go GetInstance().AddrSubscribe(retTrue)
+
+			ret := <-retTrue
+			if !ret {
+				t.Error("unexpected result")
+			}
+		})
+		t.Run("IPv6Disconnected", func(t *testing.T) {
+			lambdaAddrSubscribe = func(ch chan<- netlink.AddrUpdate) error {
+				ch <- netlink.AddrUpdate{
+					NewAddr: false,
+					LinkAddress: net.IPNet{
+						IP: net.ParseIP("fd00::1"),
+					},
+				}
+				return nil
+			}
+			go GetInstance().AddrSubscribe(retTrue)
+
 			ret := <-retTrue
 			if !ret {
 				t.Error("unexpected result")
@@ -73,6 +107,23 @@ func TestAddrSubscribe(t *testing.T) {
 		})
 	})
 	t.Run("Error", func(t *testing.T) {
+		t.Run("IPv6LinkLocal", func(t *testing.T) {
+			lambdaAddrSubscribe = func(ch chan<- netlink.AddrUpdate) error {
+				ch <- netlink.AddrUpdate{
+					NewAddr: true,
+					LinkAddress: net.IPNet{
+						IP: net.ParseIP("fe80::1"),
+					},
+				}
+				return nil
+			}
+			go GetInstance().AddrSubscribe(retTrue)
+
+			ret := <-retTrue
+			if ret {
+				t.Error("unexpected result")
+			}
+		})
 		t.Run("IPisEmpty", func(t *testing.T) {
 			lambdaAddrSubscribe = func(ch chan<- netlink.AddrUpdate) error {
 				ch <- netlink.AddrUpdate{
@@ -83,7 +83,7 @@ func (networkImpl) CheckConnectivity() error {
 	return netInfo.netError
 }
 
-// GetOutboundIP returns IPv4 address
+// GetOutboundIP returns IPv4 address, IPv6 address is returned when the device has no IPv4 address
 func (networkImpl) GetOutboundIP() (string, error) {
 	if netInfo.netError == nil {
 		ip := netInfo.GetIP()
@@ -92,7 +92,7 @@ func (networkImpl) GetOutboundIP() (string, error) {
 	return "", netInfo.netError
 }
 
-// GetOutboundIP returns IPv4 addresses
+// GetIPs returns IPv4 addresses followed by IPv6 addresses
 func (networkImpl) GetIPs() ([]string, error) {
 	ipsStr := make([]string, 0)
 	if netInfo.netError == nil {
@@ -125,7 +125,7 @@ func (networkImpl) GetVirtualIP() (string, error) {
 	log.Println(logPrefix, "Virtual IP asked")
 	if netInfo.netError == nil {
 		for _, addrInfo := range netInfo.addrInfos {
-			if addrInfo.isVirtual {
+			if addrInfo.isVirtual && addrInfo.ipv4 != nil {
 				log.Println(logPrefix, "returning", addrInfo.ipv4.String())
 				return addrInfo.ipv4.String(), nil
 			}
@@ -183,10 +183,14 @@ func setAddrInfo(ifaces []net.Interface) (err error) {
 				continue
 			}
 
-			if ipnet.IP.To4() != nil {
-				var addrInfo addrInformation
+			var addrInfo addrInformation
+			if ipv4 := ipnet.IP.To4(); ipv4 != nil {
+				addrInfo.ipv4 = ipv4
+			} else if isRoutableIPv6(ipnet.IP) {
+				addrInfo.ipv6 = ipnet.IP
+			}
 
-				addrInfo.ipv4 = ipnet.IP.To4()
+			if addrInfo.ipv4 != nil || addrInfo.ipv6 != nil {
 				addrInfo.macAddr = i.HardwareAddr.String()
 				addrInfo.isWired = checkWiredNet(netDirPathPrefix + i.Name)
 				addrInfo.isVirtual = checkTunNet(path)
@@ -238,6 +242,11 @@ func checkTunNet(path string) bool {
 	return strings.Contains(path, virtualInterfaceName)
 }
 
+// isRoutableIPv6 applies the same rule as the netlink detector
+func isRoutableIPv6(ip net.IP) bool {
+	return detector.IsRoutableIPv6(ip)
+}
+
 func (netInfo *networkInformation) Notify(ips []net.IP) {
 	if len(netInfo.addrInfos) == 0 {
 		return
@@ -252,27 +261,52 @@ func (netInfo *networkInformation) Notify(ips []net.IP) {
 	}
 }
 
-func (netInfo *networkInformation) GetIP() (ipv4 net.IP) {
+func (netInfo *networkInformation) GetIP() net.IP {
+	if ipv4 := netInfo.getIP(false); ipv4 != nil {
+		return ipv4
+	}
+	return netInfo.getIP(true)
+}
+
+func (netInfo *networkInformation) getIP(isIPv6 bool) (ip net.IP) {
 	for _, addrInfo := range netInfo.addrInfos {
 		// @Note : ethernet network have a priority
-		if addrInfo.isVirtual {
+		if addrInfo.isVirtual || addrInfo.isIPv6() != isIPv6 {
 			continue
 		}
 		if addrInfo.isWired {
-			return addrInfo.ipv4
+			return addrInfo.getAddr()
 		}
 
-		ipv4 = addrInfo.ipv4
+		ip = addrInfo.getAddr()
 	}
 
-	return ipv4
+	return ip
 }
 
 func (netInfo *networkInformation) GetIPs() []net.IP {
 	ips := make([]net.IP, 0)
 	for _, addrInfo := range netInfo.addrInfos {
-		ips = append(ips, addrInfo.ipv4)
+		if !addrInfo.isIPv6() {
+			ips = append(ips, addrInfo.ipv4)
+		}
+	}
+	for _, addrInfo := range netInfo.addrInfos {
+		if addrInfo.isIPv6() {
+			ips = append(ips, addrInfo.ipv6)
+		}
 	}
 
 	return ips
 }
+
+func (addrInfo addrInformation) isIPv6() bool {
+	return addrInfo.ipv4 == nil && addrInfo.ipv6 != nil
+}
+
+func (addrInfo addrInformation) getAddr() net.IP {
+	if addrInfo.isIPv6() {
+		return addrInfo.ipv6
+	}
+	return addrInfo.ipv4
+}
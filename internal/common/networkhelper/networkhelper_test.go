@@ -263,6 +263,44 @@ func TestSuccessGetIPs(t *testing.T) {
 	}
 }
 
+func TestGetIPsWithIPv6(t *testing.T) {
+	testIPv6 := net.ParseIP("fd00::1")
+
+	netInfo.addrInfos = make([]addrInformation, 2)
+	netInfo.addrInfos[0].isWired = true
+	netInfo.addrInfos[0].ipv6 = testIPv6
+	netInfo.addrInfos[1].ipv4 = TESTNEWIP
+
+	t.Run("IPv4First", func(t *testing.T) {
+		if reflect.DeepEqual(netInfo.GetIPs(), []net.IP{TESTNEWIP, testIPv6}) != true {
+			t.Error(netInfo.GetIPs())
+		}
+		if reflect.DeepEqual(netInfo.GetIP(), TESTNEWIP) != true {
+			t.Error(netInfo.GetIP())
+		}
+	})
+	t.Run("IPv6Only", func(t *testing.T) {
+		netInfo.addrInfos = netInfo.addrInfos[:1]
+		if reflect.DeepEqual(netInfo.GetIP(), testIPv6) != true {
+			t.Error(netInfo.GetIP())
+		}
+	})
+}
+
+func TestIsRoutableIPv6(t *testing.T) {
+	tests := map[string]bool{
+		"2001:db8::1": true,
+		"fd00::1":     true,
+		TESTIPV6:      false,
+		"::1":         false,
+	}
+	for addr, expected := range tests {
+		if isRoutableIPv6(net.ParseIP(addr)) != expected {
+			t.Error(addr, "expected", expected)
+		}
+	}
+}
+
 func TestGetVirtualIP(t *testing.T) {
 
 	t.Run("FailNetInfo", func(t *testing.T) {
@@ -29,9 +29,11 @@ type networkInformation struct {
 	netError     error
 }
 
+// addrInformation holds one address of the interface, either ipv4 or ipv6 is set
 type addrInformation struct {
 	isWired   bool
 	ipv4      net.IP
+	ipv6      net.IP
 	macAddr   string
 	isVirtual bool
 }
@@ -42,6 +42,7 @@ func processMEMInfo() {
 		for {
 			checkMemoryAvailable()
 			checkMemoryFree()
+			checkMemoryTotal()
 
 			time.Sleep(time.Duration(defaultProcessingTime) * time.Second)
 		}
@@ -81,3 +82,20 @@ func checkMemoryFree() {
 		log.Println(logPrefix, "DB error : ", err.Error())
 	}
 }
+
+func checkMemoryTotal() {
+	memStat, err := mem.virtualMemory()
+	if err != nil {
+		log.Println(logPrefix, "mem info getting fail : ", err.Error())
+		return
+	}
+
+	info := resourceDB.Info{}
+	info.Name = MemTotal
+	info.Value = float64(memStat.Total) / 1024
+
+	err = resourceDBExecutor.Set(info)
+	if err != nil {
+		log.Println(logPrefix, "DB error : ", err.Error())
+	}
+}
@@ -59,6 +59,8 @@ const (
 	MemFree = "memory/free"
 	// MemAvailable defined memory/available
 	MemAvailable = "memory/available"
+	// MemTotal defined memory/total
+	MemTotal = "memory/total"
 	// NetMBps defined network/mbps
 	NetMBps = "network/mbps"
 	// NetBandwidth defined network/bandwidth
@@ -117,6 +119,8 @@ func (r *ResourceImpl) GetResource(resourceName string) (float64, error) {
 		return getMemoryFree()
 	case MemAvailable:
 		return getMemoryAvailable()
+	case MemTotal:
+		return getMemoryTotal()
 	case NetMBps:
 		return getNetworkMBps()
 	case NetBandwidth:
@@ -169,6 +173,15 @@ func getMemoryAvailable() (out float64, err error) {
 	return
 }
 
+func getMemoryTotal() (out float64, err error) {
+	info, err := resourceDBExecutor.Get(MemTotal)
+	if err != nil {
+		return
+	}
+	out = info.Value
+	return
+}
+
 func getMemoryFree() (out float64, err error) {
 	info, err := resourceDBExecutor.Get(MemFree)
 	if err != nil {
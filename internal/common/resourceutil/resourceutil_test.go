@@ -28,6 +28,7 @@ import (
 	memutil "github.com/shirou/gopsutil/mem"
 	netutil "github.com/vishvananda/netlink"
 
+	netDB "github.com/lf-edge/edge-home-orchestration-go/internal/db/bolt/network"
 	resourceDB "github.com/lf-edge/edge-home-orchestration-go/internal/db/bolt/resource"
 	resourceDBMock "github.com/lf-edge/edge-home-orchestration-go/internal/db/bolt/resource/mocks"
 )
@@ -83,10 +84,12 @@ var (
 
 	dummyMemAvailableResult = 1.0
 	dummyMemFreeResult      = 1.0
+	dummyMemTotalResult     = 2.0
 
 	dummyVirtualMemoryStat = memutil.VirtualMemoryStat{
 		Available: 1024,
 		Free:      1024,
+		Total:     2048,
 	}
 
 	dummyNetMBpsResult      = 0.0
@@ -234,6 +237,15 @@ func setupMemFreeTest() {
 	monitoringExecutor.rttScoring = func() {}
 }
 
+func setupMemTotalTest() {
+	monitoringExecutor.netScoring = func() {}
+	monitoringExecutor.cpuScoring = func() {}
+	monitoringExecutor.memScoring = func() {
+		checkMemoryTotal()
+	}
+	monitoringExecutor.rttScoring = func() {}
+}
+
 func TestGetCPUUsage(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
@@ -399,6 +411,39 @@ func TestGetMemFree(t *testing.T) {
 	})
 }
 
+func TestGetMemTotal(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		resourceDBMockObj := resourceDBMock.NewMockDBInterface(ctrl)
+
+		info := resourceDB.Info{}
+		info.Name = MemTotal
+		info.Value = dummyMemTotalResult
+
+		resourceDBMockObj.EXPECT().Set(info).Return(nil).AnyTimes()
+		resourceDBMockObj.EXPECT().Get(MemTotal).Return(info, nil)
+
+		resourceDBExecutor = resourceDBMockObj
+
+		setupTestCase()
+
+		monitoringImpl := GetMonitoringInstance()
+		setupMemTotalTest()
+		monitoringImpl.StartMonitoringResource()
+
+		memTotal, err := resourceIns.GetResource(MemTotal)
+		if err != nil {
+			t.Error(err.Error())
+		}
+
+		if memTotal != dummyMemTotalResult {
+			t.Errorf("%f != %f", memTotal, dummyMemTotalResult)
+		}
+	})
+}
+
 func TestGetNetMBps(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
@@ -482,3 +527,38 @@ func TestSetDeviceID(t *testing.T) {
 		}
 	})
 }
+
+func TestSelectMinRTT(t *testing.T) {
+	tests := map[string]struct {
+		results []rttResult
+		rtt     float64
+		family  string
+	}{
+		"IPv6Faster": {
+			results: []rttResult{{0.2, netDB.FamilyIPv4}, {0.1, netDB.FamilyIPv6}, {-1, netDB.FamilyIPv4}},
+			rtt:     0.1,
+			family:  netDB.FamilyIPv6,
+		},
+		"IPv4Only": {
+			results: []rttResult{{-1, netDB.FamilyIPv6}, {0.3, netDB.FamilyIPv4}},
+			rtt:     0.3,
+			family:  netDB.FamilyIPv4,
+		},
+		"NoAnswer": {
+			results: []rttResult{{-1, netDB.FamilyIPv4}, {-1, netDB.FamilyIPv6}},
+			rtt:     -1,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ch := make(chan rttResult, len(test.results))
+			for _, result := range test.results {
+				ch <- result
+			}
+			rtt, family := selectMinRTT(ch, len(test.results))
+			if rtt != test.rtt || family != test.family {
+				t.Error(unexpectedFail, rtt, family)
+			}
+		})
+	}
+}
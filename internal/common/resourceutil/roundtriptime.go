@@ -38,6 +38,12 @@ var (
 	netDBExecutor netDB.DBInterface
 )
 
+// rttResult is the RTT measured on the address of the family
+type rttResult struct {
+	rtt    float64
+	family string
+}
+
 func init() {
 	helper = resthelper.GetHelper()
 	netDBExecutor = netDB.Query{}
@@ -52,28 +58,27 @@ func processRTT() {
 			}
 
 			for _, netInfo := range netInfos {
-				totalCount := len(netInfo.IPv4)
-				ch := make(chan float64, totalCount)
-				for _, ip := range netInfo.IPv4 {
-					go func(targetIP string) {
-						ch <- checkRTT(targetIP)
-					}(ip)
+				totalCount := len(netInfo.IPv4) + len(netInfo.IPv6)
+				ch := make(chan rttResult, totalCount)
+				families := map[string][]string{netDB.FamilyIPv4: netInfo.IPv4, netDB.FamilyIPv6: netInfo.IPv6}
+				for family, ips := range families {
+					for _, ip := range ips {
+						go func(targetIP string, family string) {
+							ch <- rttResult{rtt: checkRTT(targetIP), family: family}
+						}(ip, family)
+					}
 				}
 				go func(info netDB.Info) {
-					result := selectMinRTT(ch, totalCount)
+					result, family := selectMinRTT(ch, totalCount)
 					if info.RTT < 0 && result < 0 {
-						if info.RTT == -1*tryLimit {
-							_, err := netDBExecutor.Get(info.ID)
-							if err == nil {
-								log.Println(logPrefix, "Delete", info.ID, "from netDB")
-								netDBExecutor.Delete(info.ID)
-							}
-						} else {
+						// @Note : the unreachable device is deleted by the liveness check of discoverymgr
+						if info.RTT > -1*tryLimit {
 							info.RTT += result
 							netDBExecutor.Update(info)
 						}
 					} else {
 						info.RTT = result
+						info.Family = family
 						netDBExecutor.Update(info)
 					}
 				}(netInfo)
@@ -96,12 +101,17 @@ func checkRTT(ip string) (rtt float64) {
 	return time.Since(reqTime).Seconds()
 }
 
-func selectMinRTT(ch chan float64, totalCount int) (minRTT float64) {
+// selectMinRTT returns the minimum RTT and its address family, the family is empty when no address answers
+func selectMinRTT(ch chan rttResult, totalCount int) (minRTT float64, family string) {
 	for i := 0; i < totalCount; i++ {
 		select {
-		case rtt := <-ch:
+		case result := <-ch:
+			rtt := result.rtt
 			if (minRTT < 0 && rtt > 0) || (rtt > 0 && rtt < minRTT) || minRTT == 0 {
 				minRTT = rtt
+				if rtt > 0 {
+					family = result.family
+				}
 			}
 		}
 	}
@@ -24,4 +24,26 @@ type ServiceInfo struct {
 	AllowedRequester   []string
 	ExecType           string
 	ExecCmd            []string
+	ScoringType        string
+	ScoringWeights     map[string]float64
+	RunAsUser          string
+	RunAsGroup         string
+	Restart            string
+	Sandbox            Sandbox
+}
+
+// Sandbox struct holds the restrictions of the process of the native service, the zero value restricts nothing
+type Sandbox struct {
+	// Namespaces are the new Linux namespaces of the process : ipc, mount, net, pid and uts
+	Namespaces []string `json:"namespaces,omitempty"`
+	// NoNewPrivileges prevents the process from gaining privileges by executing setuid binaries
+	NoNewPrivileges bool `json:"noNewPrivileges,omitempty"`
+	// MaxCPUTime is the limit of the cpu time in seconds
+	MaxCPUTime uint64 `json:"maxCPUTime,omitempty"`
+	// MaxMemory is the limit of the address space in bytes
+	MaxMemory uint64 `json:"maxMemory,omitempty"`
+	// MaxOpenFiles is the limit of the number of the open files
+	MaxOpenFiles uint64 `json:"maxOpenFiles,omitempty"`
+	// WorkingDir is the root and the working directory of the process, it cannot access the files outside of it
+	WorkingDir string `json:"workingDir,omitempty"`
 }
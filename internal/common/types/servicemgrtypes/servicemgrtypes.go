@@ -1,6 +1,13 @@
 // Package servicemgrtypes defines types for servicemgr
 package servicemgrtypes
 
+import (
+	"strings"
+
+	"github.com/lf-edge/edge-home-orchestration-go/internal/common/networkhelper"
+	"github.com/lf-edge/edge-home-orchestration-go/internal/db/bolt/common"
+)
+
 const (
 	// ConstKeyServiceID is key of service id
 	ConstKeyServiceID = "ServiceID"
@@ -29,6 +36,18 @@ const (
 	// ConstServiceStatusFinished is service status is finished
 	ConstServiceStatusFinished = "Finished"
 
+	// ConstServiceStatusStopped is service status is stopped by request
+	ConstServiceStatusStopped = "Stopped"
+
+	// ConstServiceStatusPulling is service status is pulling the image
+	ConstServiceStatusPulling = "Pulling"
+
+	// ConstServiceStatusUnhealthy is service status is running but its health check fails
+	ConstServiceStatusUnhealthy = "Unhealthy"
+
+	// ConstKeyPullProgress is key of the image pull progress
+	ConstKeyPullProgress = "PullProgress"
+
 	// ConstServiceFound is service status is found
 	ConstServiceFound = "Found"
 
@@ -38,3 +57,26 @@ const (
 	//ConstServicePort is open port for rest server
 	ConstServicePort = 56001
 )
+
+// IsTerminalStatus checks if the service is not running anymore and no more notification follows the status
+func IsTerminalStatus(status string) bool {
+	switch status {
+	case ConstServiceStatusFinished, ConstServiceStatusFailed, ConstServiceStatusStopped:
+		return true
+	}
+	return false
+}
+
+// IsLocalTarget returns true if the target is the outbound IP or one of the other IPv4 and IPv6 addresses of the device
+func IsLocalTarget(target string) bool {
+	outboundIP, outboundIPErr := networkhelper.GetInstance().GetOutboundIP()
+	if outboundIPErr != nil {
+		outboundIP = ""
+	}
+	if strings.Compare(target, outboundIP) == 0 {
+		return true
+	}
+
+	ips, _ := networkhelper.GetInstance().GetIPs()
+	return common.HasElem(ips, target)
+}
@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -167,12 +168,24 @@ func getServiceInfo(path string) (types.ServiceInfo, error) {
 	allowedRequesterName := cfg.Section("ServiceInfo").Key("AllowedRequester").Strings(",")
 	execType := cfg.Section("ServiceInfo").Key("ExecType").String()
 	execCmd := cfg.Section("ServiceInfo").Key("ExecCmd").Strings(" ")
+	scoringType := cfg.Section("ServiceInfo").Key("ScoringType").String()
+	scoringWeights := parseScoringWeights(cfg.Section("ServiceInfo").Key("ScoringWeights").Strings(","))
+	runAsUser := cfg.Section("ServiceInfo").Key("RunAsUser").String()
+	runAsGroup := cfg.Section("ServiceInfo").Key("RunAsGroup").String()
+	restart := cfg.Section("ServiceInfo").Key("Restart").String()
+	sandbox := parseSandbox(cfg.Section("Sandbox"))
 
 	log.Debug(logPrefix, " ServiceName:", serviceName)
 	log.Debug(logPrefix, " ExecutableFileName:", executableName)
 	log.Debug(logPrefix, " AllowedRequester:", allowedRequesterName)
 	log.Debug(logPrefix, " ExecType:", execType)
 	log.Debug(logPrefix, " ExecCmd:", execCmd)
+	log.Debug(logPrefix, " ScoringType:", scoringType)
+	log.Debug(logPrefix, " ScoringWeights:", scoringWeights)
+	log.Debug(logPrefix, " RunAsUser:", runAsUser)
+	log.Debug(logPrefix, " RunAsGroup:", runAsGroup)
+	log.Debug(logPrefix, " Restart:", restart)
+	log.Debug(logPrefix, " Sandbox:", sandbox)
 
 	if execType != configuremgrObj.execType {
 		log.Warn(logPrefix, " Type of ", serviceName, " is not ", configuremgrObj.execType)
@@ -185,6 +198,12 @@ func getServiceInfo(path string) (types.ServiceInfo, error) {
 		AllowedRequester:   allowedRequesterName,
 		ExecType:           execType,
 		ExecCmd:            execCmd,
+		ScoringType:        scoringType,
+		ScoringWeights:     scoringWeights,
+		RunAsUser:          runAsUser,
+		RunAsGroup:         runAsGroup,
+		Restart:            restart,
+		Sandbox:            sandbox,
 	}
 
 	appInfo := appDB.Info{
@@ -193,6 +212,13 @@ func getServiceInfo(path string) (types.ServiceInfo, error) {
 		AllowedRequester:   allowedRequesterName,
 		ExecType:           execType,
 		ExecCmd:            execCmd,
+		ScoringType:        scoringType,
+		ScoringWeights:     scoringWeights,
+		RunAsUser:          runAsUser,
+		RunAsGroup:         runAsGroup,
+		Restart:            restart,
+		Sandbox:            sandbox,
+		AppDir:             filepath.Dir(confPath),
 	}
 
 	setAppDB(appInfo)
@@ -200,6 +226,41 @@ func getServiceInfo(path string) (types.ServiceInfo, error) {
 	return ret, nil
 }
 
+// parseScoringWeights converts "factor:weight" items to the weights of the scoring policy
+func parseScoringWeights(items []string) map[string]float64 {
+	if len(items) == 0 {
+		return nil
+	}
+
+	weights := make(map[string]float64)
+	for _, item := range items {
+		kv := strings.SplitN(item, ":", 2)
+		if len(kv) != 2 {
+			log.Warn(logPrefix, " Wrong ScoringWeights item ", item)
+			continue
+		}
+		weight, err := strconv.ParseFloat(strings.TrimSpace(kv[1]), 64)
+		if err != nil {
+			log.Warn(logPrefix, " Wrong ScoringWeights item ", item)
+			continue
+		}
+		weights[strings.TrimSpace(kv[0])] = weight
+	}
+	return weights
+}
+
+// parseSandbox converts the Sandbox section with the memory in MiB to the restrictions of the native service
+func parseSandbox(section *ini.Section) types.Sandbox {
+	return types.Sandbox{
+		Namespaces:      section.Key("Namespaces").Strings(","),
+		NoNewPrivileges: section.Key("NoNewPrivileges").MustBool(false),
+		MaxCPUTime:      section.Key("MaxCPUTime").MustUint64(0),
+		MaxMemory:       section.Key("MaxMemory").MustUint64(0) * 1024 * 1024,
+		MaxOpenFiles:    section.Key("MaxOpenFiles").MustUint64(0),
+		WorkingDir:      section.Key("WorkingDir").String(),
+	}
+}
+
 func getdirname(path string) (confPath string, err error) {
 
 	idx := strings.LastIndex(path, "/")
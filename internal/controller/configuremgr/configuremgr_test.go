@@ -25,6 +25,8 @@ import (
 	"time"
 
 	"github.com/lf-edge/edge-home-orchestration-go/internal/common/types/configuremgrtypes"
+
+	"gopkg.in/ini.v1"
 )
 
 var name string
@@ -133,3 +135,45 @@ func TestGetdirname(t *testing.T) {
 		}
 	})
 }
+
+func TestParseScoringWeights(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		weights := parseScoringWeights([]string{"cpu:2", " rtt : 0.5"})
+		if len(weights) != 2 || weights["cpu"] != 2 || weights["rtt"] != 0.5 {
+			t.Error(unexpectedFail, weights)
+		}
+	})
+	t.Run("Empty", func(t *testing.T) {
+		if weights := parseScoringWeights(nil); weights != nil {
+			t.Error(unexpectedFail, weights)
+		}
+	})
+	t.Run("WrongItem", func(t *testing.T) {
+		weights := parseScoringWeights([]string{"cpu", "memory:much", "network:1"})
+		if len(weights) != 1 || weights["network"] != 1 {
+			t.Error(unexpectedFail, weights)
+		}
+	})
+}
+
+func TestParseSandbox(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		cfg, err := ini.Load([]byte("[Sandbox]\nNamespaces=pid,net\nNoNewPrivileges=true\nMaxCPUTime=60\nMaxMemory=256\nMaxOpenFiles=64\nWorkingDir=/tmp\n"))
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		sandbox := parseSandbox(cfg.Section("Sandbox"))
+		if len(sandbox.Namespaces) != 2 || sandbox.Namespaces[0] != "pid" || sandbox.Namespaces[1] != "net" ||
+			!sandbox.NoNewPrivileges || sandbox.MaxCPUTime != 60 || sandbox.MaxMemory != 256*1024*1024 ||
+			sandbox.MaxOpenFiles != 64 || sandbox.WorkingDir != "/tmp" {
+			t.Error(unexpectedFail, sandbox)
+		}
+	})
+	t.Run("Empty", func(t *testing.T) {
+		cfg := ini.Empty()
+		sandbox := parseSandbox(cfg.Section("Sandbox"))
+		if len(sandbox.Namespaces) != 0 || sandbox.NoNewPrivileges || sandbox.MaxMemory != 0 || len(sandbox.WorkingDir) != 0 {
+			t.Error(unexpectedFail, sandbox)
+		}
+	})
+}
@@ -27,6 +27,19 @@ type Doc struct {
 		ServiceName        string
 		ExecutableFileName string
 		AllowedRequester   []string
+		ScoringType        string
+		ScoringWeights     []string
+		RunAsUser          string
+		RunAsGroup         string
+		Restart            string
+	}
+	Sandbox struct {
+		Namespaces      []string
+		NoNewPrivileges bool
+		MaxCPUTime      uint64
+		MaxMemory       uint64
+		MaxOpenFiles    uint64
+		WorkingDir      string
 	}
 	// Using this structure is an interesting idea that could be used in the future. See PRs: #20, #383 for quick recovery.
 	// ScoringMethod struct {
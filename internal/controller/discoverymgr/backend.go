@@ -0,0 +1,211 @@
+/*******************************************************************************
+ * Copyright 2020 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+
+package discoverymgr
+
+import (
+	"sync"
+
+	errors "github.com/lf-edge/edge-home-orchestration-go/internal/common/errors"
+	wrapper "github.com/lf-edge/edge-home-orchestration-go/internal/controller/discoverymgr/wrapper"
+)
+
+const (
+	zeroconfBackendName = "zeroconf"
+)
+
+type backendEvent struct {
+	backend string
+	data    *wrapper.Entity
+}
+
+// detectedDevices keeps the latest entity of each device found by each backend
+type detectedDevices struct {
+	// backends are the names of the running backends, the addresses of the devices are merged in their order
+	backends []string
+	entities map[string]map[string]wrapper.Entity
+}
+
+// zeroconfBackend finds the devices advertised by mDNS
+type zeroconfBackend struct{}
+
+var (
+	backendMTX sync.Mutex
+	backends   []wrapper.DiscoveryBackend
+	detecting  bool
+)
+
+// AddBackend adds the backend finding the devices with the other backends, it should be added before StartDiscovery
+func (DiscoveryImpl) AddBackend(backend wrapper.DiscoveryBackend) error {
+	backendMTX.Lock()
+	defer backendMTX.Unlock()
+
+	if detecting {
+		return errors.SystemError{Message: "discovery is already started"}
+	}
+	for _, b := range backends {
+		if b.Name() == backend.Name() {
+			return errors.InvalidParam{Message: "backend " + backend.Name() + " is duplicated"}
+		}
+	}
+	backends = append(backends, backend)
+	return nil
+}
+
+// startBackends starts all backends and returns the channel merging their events until the discovery is stopped
+func startBackends(shutdown chan struct{}) (names []string, events chan backendEvent) {
+	backendMTX.Lock()
+	defer backendMTX.Unlock()
+
+	detecting = true
+	events = make(chan backendEvent, 32)
+	for _, backend := range backends {
+		subchan, err := backend.Start()
+		if err != nil {
+			log.Println(logPrefix, "[startBackends]", backend.Name(), err)
+			continue
+		}
+		names = append(names, backend.Name())
+		go forwardEvents(backend.Name(), subchan, events, shutdown)
+	}
+	log.Println(logPrefix, "[startBackends]", names)
+	return
+}
+
+// stopBackends stops all backends after the discovery is stopped
+func stopBackends() {
+	backendMTX.Lock()
+	defer backendMTX.Unlock()
+
+	for _, backend := range backends {
+		backend.Stop()
+	}
+	detecting = false
+}
+
+func forwardEvents(name string, subchan <-chan *wrapper.Entity, events chan<- backendEvent, shutdown chan struct{}) {
+	for {
+		select {
+		case <-shutdown:
+			return
+		case data, ok := <-subchan:
+			if !ok {
+				log.Println(logPrefix, "[forwardEvents]", name, "is closed")
+				return
+			}
+			select {
+			case events <- backendEvent{backend: name, data: data}:
+			case <-shutdown:
+				return
+			}
+		}
+	}
+}
+
+func newDetectedDevices(names []string) detectedDevices {
+	return detectedDevices{backends: names, entities: make(map[string]map[string]wrapper.Entity)}
+}
+
+// update stores the device of the event to DB, the device is deleted when no backend finds it
+func (d detectedDevices) update(event backendEvent) {
+	switch {
+	case event.data == nil:
+		d.clear(event.backend)
+	case event.data.TTL == 0:
+		d.remove(event.backend, event.data.DeviceID)
+	default:
+		d.set(event.backend, *event.data)
+	}
+}
+
+func (d detectedDevices) set(backend string, data wrapper.Entity) {
+	sources, ok := d.entities[data.DeviceID]
+	if !ok {
+		sources = make(map[string]wrapper.Entity)
+		d.entities[data.DeviceID] = sources
+	}
+	sources[backend] = data
+	setDeviceInfo(d.merge(data, sources))
+}
+
+func (d detectedDevices) remove(backend string, deviceID string) {
+	sources := d.entities[deviceID]
+	delete(sources, backend)
+	if len(sources) == 0 {
+		delete(d.entities, deviceID)
+		deleteDevice(deviceID)
+		return
+	}
+
+	log.Println(logPrefix, "[detectedDevices]", deviceID, "is lost by", backend)
+	for _, name := range d.backends {
+		if data, ok := sources[name]; ok {
+			setDeviceInfo(d.merge(data, sources))
+			return
+		}
+	}
+}
+
+// clear deletes the devices found only by the backend, the devices stored by the others except my device are deleted too
+func (d detectedDevices) clear(backend string) {
+	for deviceID, sources := range d.entities {
+		if _, ok := sources[backend]; ok {
+			d.remove(backend, deviceID)
+		}
+	}
+
+	clearMap(func(deviceID string) bool {
+		_, ok := d.entities[deviceID]
+		return ok
+	})
+}
+
+// merge returns the entity with the addresses of the device found by all backends
+func (d detectedDevices) merge(data wrapper.Entity, sources map[string]wrapper.Entity) wrapper.Entity {
+	var ipv4s, ipv6s []string
+	found := make(map[string]bool)
+	for _, name := range d.backends {
+		ipv4s = appendNewIPs(ipv4s, sources[name].OrchestrationInfo.IPv4, found)
+		ipv6s = appendNewIPs(ipv6s, sources[name].OrchestrationInfo.IPv6, found)
+	}
+	data.OrchestrationInfo.IPv4 = ipv4s
+	data.OrchestrationInfo.IPv6 = ipv6s
+	return data
+}
+
+func appendNewIPs(ips []string, newIPs []string, found map[string]bool) []string {
+	for _, ip := range newIPs {
+		if !found[ip] {
+			found[ip] = true
+			ips = append(ips, ip)
+		}
+	}
+	return ips
+}
+
+// Name returns the name of the zeroconf backend
+func (zeroconfBackend) Name() string {
+	return zeroconfBackendName
+}
+
+// Start subscribes the devices found by zeroconf
+func (zeroconfBackend) Start() (<-chan *wrapper.Entity, error) {
+	return wrapperIns.GetSubscriberChan()
+}
+
+// Stop does nothing, the zeroconf server is shut down by StopDiscovery
+func (zeroconfBackend) Stop() {}
@@ -0,0 +1,160 @@
+/*******************************************************************************
+ * Copyright 2020 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+
+package discoverymgr
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	wrapper "github.com/lf-edge/edge-home-orchestration-go/internal/controller/discoverymgr/wrapper"
+
+	"github.com/golang/mock/gomock"
+)
+
+type fakeBackend struct {
+	name    string
+	subchan chan *wrapper.Entity
+	stopped chan struct{}
+}
+
+func newFakeBackend(name string) *fakeBackend {
+	return &fakeBackend{name: name, subchan: make(chan *wrapper.Entity, 10), stopped: make(chan struct{})}
+}
+
+func (b *fakeBackend) Name() string {
+	return b.name
+}
+
+func (b *fakeBackend) Start() (<-chan *wrapper.Entity, error) {
+	return b.subchan, nil
+}
+
+func (b *fakeBackend) Stop() {
+	close(b.stopped)
+}
+
+func TestAddBackend(t *testing.T) {
+	defaultBackends := backends
+	defer func() { backends = defaultBackends }()
+
+	discoveryInstance := GetInstance()
+	t.Run("Success", func(t *testing.T) {
+		backends = nil
+		if err := discoveryInstance.AddBackend(newFakeBackend("first")); err != nil {
+			t.Fatal(err.Error())
+		}
+		if err := discoveryInstance.AddBackend(newFakeBackend("second")); err != nil {
+			t.Fatal(err.Error())
+		}
+		if len(backends) != 2 {
+			t.Error("unexpected backends", backends)
+		}
+	})
+	t.Run("Duplicated", func(t *testing.T) {
+		backends = nil
+		discoveryInstance.AddBackend(newFakeBackend("first"))
+		if err := discoveryInstance.AddBackend(newFakeBackend("first")); err == nil {
+			t.Error("unexpected success")
+		}
+	})
+	t.Run("Detecting", func(t *testing.T) {
+		backends = nil
+		detecting = true
+		defer func() { detecting = false }()
+		if err := discoveryInstance.AddBackend(newFakeBackend("first")); err == nil {
+			t.Error("unexpected success")
+		}
+	})
+}
+
+func TestDeviceDetectionRoutineWithBackends(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	createMockIns(ctrl)
+	mockDB.EXPECT().GetDeviceID().Return(defaultMyDeviceID, nil).AnyTimes()
+
+	defaultBackends := backends
+	defer func() { backends = defaultBackends }()
+	first, second := newFakeBackend("first"), newFakeBackend("second")
+	backends = []wrapper.DiscoveryBackend{first, second}
+
+	addDevice(false)
+	shutdownChan = make(chan struct{})
+	go deviceDetectionRoutine()
+
+	secondEntity := anotherEntity
+	secondEntity.OrchestrationInfo.IPv4 = []string{"3.3.3.3"}
+	secondEntity.OrchestrationInfo.IPv6 = []string{"fd00::3"}
+
+	t.Run("MergedAddresses", func(t *testing.T) {
+		first.subchan <- &anotherEntity
+		second.subchan <- &secondEntity
+		time.Sleep(500 * time.Millisecond)
+
+		netInfo, err := netQuery.Get(anotherDeviceID)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if !reflect.DeepEqual(netInfo.IPv4, []string{anotherIPv4, "3.3.3.3"}) ||
+			!reflect.DeepEqual(netInfo.IPv6, []string{"fd00::3"}) {
+			t.Error("unexpected addresses", netInfo.IPv4, netInfo.IPv6)
+		}
+	})
+	t.Run("LostByOneBackend", func(t *testing.T) {
+		lostEntity := anotherEntity
+		lostEntity.TTL = 0
+		first.subchan <- &lostEntity
+		time.Sleep(500 * time.Millisecond)
+
+		checkPresence(t, anotherDeviceID)
+		netInfo, _ := netQuery.Get(anotherDeviceID)
+		if !reflect.DeepEqual(netInfo.IPv4, []string{"3.3.3.3"}) {
+			t.Error("unexpected addresses", netInfo.IPv4)
+		}
+	})
+	t.Run("ClearOneBackend", func(t *testing.T) {
+		first.subchan <- &anotherEntity
+		first.subchan <- nil
+		time.Sleep(500 * time.Millisecond)
+
+		checkPresence(t, anotherDeviceID)
+		checkPresence(t, defaultMyDeviceID)
+	})
+	t.Run("LostByAllBackends", func(t *testing.T) {
+		lostEntity := secondEntity
+		lostEntity.TTL = 0
+		second.subchan <- &lostEntity
+		time.Sleep(500 * time.Millisecond)
+
+		checkNotPresence(t, anotherDeviceID)
+	})
+
+	close(shutdownChan)
+	for _, backend := range []*fakeBackend{first, second} {
+		select {
+		case <-backend.stopped:
+		case <-time.After(time.Second):
+			t.Error(backend.name, "is not stopped")
+		}
+	}
+
+	shutdownChan = make(chan struct{})
+	closeTest()
+}
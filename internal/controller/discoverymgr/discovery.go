@@ -21,6 +21,7 @@ import (
 	"net"
 	"os"
 	"reflect"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -34,12 +35,15 @@ import (
 	"gopkg.in/yaml.v3"
 
 	configurationdb "github.com/lf-edge/edge-home-orchestration-go/internal/db/bolt/configuration"
+	livenessdb "github.com/lf-edge/edge-home-orchestration-go/internal/db/bolt/liveness"
 	networkdb "github.com/lf-edge/edge-home-orchestration-go/internal/db/bolt/network"
 	servicedb "github.com/lf-edge/edge-home-orchestration-go/internal/db/bolt/service"
+	snapshotdb "github.com/lf-edge/edge-home-orchestration-go/internal/db/bolt/snapshot"
 	systemdb "github.com/lf-edge/edge-home-orchestration-go/internal/db/bolt/system"
 	dbhelper "github.com/lf-edge/edge-home-orchestration-go/internal/db/helper"
 	"github.com/lf-edge/edge-home-orchestration-go/internal/restinterface/cipher"
 	"github.com/lf-edge/edge-home-orchestration-go/internal/restinterface/client"
+	"github.com/lf-edge/edge-home-orchestration-go/internal/restinterface/resthelper"
 
 	uuid "github.com/satori/go.uuid"
 )
@@ -54,7 +58,11 @@ type Discovery interface {
 	RemoveServiceName(serviceName string) error
 	ResetServiceName()
 	AddDeviceInfo(deviceID string, virtualAddr string, privateAddr string)
+	AddBackend(backend wrapper.DiscoveryBackend) error
 	GetOrchestrationInfo() (platform string, executionType string, serviceList []string, err error)
+	GetDeviceInfo() (deviceID string, labels map[string]string, err error)
+	GetDeviceStates() ([]map[string]interface{}, error)
+	WatchDeviceStates() (<-chan map[string]interface{}, func())
 	SetRestResource()
 	MNEDCClosedCallback()
 	NotifyMNEDCBroadcastServer() error
@@ -76,6 +84,7 @@ var (
 	dbIns        dbhelper.MultipleBucketQuery
 	networkIns   networkhelper.Network
 	storageIns   storagemgr.Storage
+	pingHelper   resthelper.RestHelper
 	mutexLock    sync.Mutex
 	log          = logmgr.GetInstance()
 )
@@ -83,16 +92,20 @@ var (
 func init() {
 	discoveryIns = &DiscoveryImpl{}
 	wrapperIns = wrapper.GetZeroconfImpl()
+	backends = []wrapper.DiscoveryBackend{zeroconfBackend{}}
 	shutdownChan = make(chan struct{})
 
 	dbIns = dbhelper.GetInstance()
 	networkIns = networkhelper.GetInstance()
 	storageIns = storagemgr.GetInstance()
+	pingHelper = resthelper.GetHelper()
 
 	sysQuery = systemdb.Query{}
 	confQuery = configurationdb.Query{}
 	netQuery = networkdb.Query{}
 	serviceQuery = servicedb.Query{}
+	livenessQuery = livenessdb.Query{}
+	snapshotQuery = snapshotdb.Query{}
 }
 
 // GetInstance returns discovery instaance
@@ -112,6 +125,15 @@ func (DiscoveryImpl) StartDiscovery(UUIDpath string, platform string, executionT
 	mutexLock.Unlock()
 	networkIns.StartNetwork()
 
+	// @Note : the static peers are discovered by unicast in addition to mDNS
+	if conf, err := getStaticPeerConf(peersPath); err == nil && len(conf.Peers) > 0 {
+		backend := newStaticPeerBackend(conf)
+		backend.SetClient(discoveryIns.Clienter)
+		if err := discoveryIns.AddBackend(backend); err != nil {
+			log.Println(logPrefix, "[StartDiscovery]", err)
+		}
+	}
+
 	UUIDStr, err := setDeviceID(UUIDpath)
 	if err != nil {
 		log.Print(logPrefix, "[StartDiscovery]", "UUID ", UUIDStr, " is Temporary")
@@ -121,6 +143,7 @@ func (DiscoveryImpl) StartDiscovery(UUIDpath string, platform string, executionT
 	startServer(UUIDStr, platform, executionType)
 
 	go detectNetworkChgRoutine()
+	go heartbeatRoutine(getLivenessPolicy(livenessPath))
 
 	go func() {
 		for {
@@ -240,6 +263,7 @@ func (d *DiscoveryImpl) ResetServiceName() {
 	var serverTXT []string
 	serverTXT = append(serverTXT, "ExecType="+confItem.ExecType)
 	serverTXT = append(serverTXT, "Platform="+confItem.Platform)
+	serverTXT = appendLabelsToTXT(serverTXT, confItem.Labels)
 	if len(servicEnv) > 0 {
 		serverTXT = append(serverTXT, servicEnv)
 	}
@@ -278,6 +302,20 @@ func (DiscoveryImpl) GetOrchestrationInfo() (platform string, executionType stri
 	return
 }
 
+// GetDeviceInfo returns the ID and the labels of the device, which the devices discovering it without mDNS get from it
+func (DiscoveryImpl) GetDeviceInfo() (deviceID string, labels map[string]string, err error) {
+	deviceID, err = dbIns.GetDeviceID()
+	if err != nil {
+		return
+	}
+	confInfo, err := confQuery.Get(deviceID)
+	if err != nil {
+		return
+	}
+	labels = confInfo.Labels
+	return
+}
+
 func isIPPresent(deviceID string, privateIP string) (isPresent bool, err error) {
 	networkInfo, err := netQuery.Get(deviceID)
 	if err != nil {
@@ -343,6 +381,7 @@ func (d *DiscoveryImpl) requestDeviceInfo(deviceID string, address string) {
 		// @Note Is it need to call Update API?
 		setConfigurationDB(confInfo)
 		setServiceDB(serviceInfo)
+		markSeen(deviceID)
 		break
 	}
 }
@@ -360,12 +399,15 @@ func detectNetworkChgRoutine() {
 				continue
 			}
 
-			var strIPs []string
+			netInfo := networkdb.Info{ID: id}
 			for _, ip := range latestIPs {
-				strIPs = append(strIPs, ip.To4().String())
+				if ip.To4() != nil {
+					netInfo.IPv4 = append(netInfo.IPv4, ip.To4().String())
+				} else {
+					netInfo.IPv6 = append(netInfo.IPv6, ip.String())
+				}
 			}
 
-			netInfo := networkdb.Info{ID: id, IPv4: strIPs}
 			setNetworkDB(netInfo)
 
 			err = serverPresenceChecker()
@@ -523,6 +565,7 @@ func setDeviceArgument(deviceUUID string, platform string, executionType string)
 	servicEnv := getServiceFromEnv()
 	Text = append(Text, "Platform="+platform)
 	Text = append(Text, "ExecType="+executionType)
+	Text = appendLabelsToTXT(Text, getDeviceLabels(labelsPath))
 	if len(servicEnv) > 0 {
 		Text = append(Text, servicEnv)
 	}
@@ -545,59 +588,55 @@ func SetNetwotkArgument() (hostIPAddr []string, netIface []net.Interface) {
 	return
 }
 
+// deviceDetectionRoutine stores the devices found by the backends to DB until the discovery is stopped
 func deviceDetectionRoutine() {
 	go func() {
-		subchan, err := wrapperIns.GetSubscriberChan()
-		if err != nil {
-			log.Println(logPrefix, err)
-			return
-		}
+		shutdown := shutdownChan
+		names, events := startBackends(shutdown)
+		defer stopBackends()
+
+		devices := newDetectedDevices(names)
 		for {
 			select {
-			case <-shutdownChan:
+			case <-shutdown:
 				log.Println(logPrefix, "[deviceDetectionRoutine]", "Shutdown")
 				return
-			case data := <-subchan:
-				// log.Println(logPrefix, "[detectDevice] ", data)
-				if data == nil {
-					clearMap()
-					continue
-				}
-				if data.TTL == 0 {
-					deleteDevice(data.DeviceID)
-					continue
-				}
+			case event := <-events:
+				devices.update(event)
+			}
+		}
+	}()
+}
 
-				_, confInfo, netInfo, serviceInfo := convertToDBInfo(*data)
+// setDeviceInfo stores the information of the discovered device to the configuration, network and service DB
+func setDeviceInfo(data wrapper.Entity) {
+	_, confInfo, netInfo, serviceInfo := convertToDBInfo(data)
 
-				log.Printf("[deviceDetectionRoutine] %s", data.DeviceID)
-				log.Printf("[deviceDetectionRoutine] confInfo    : ExecType(%s), Platform(%s)", confInfo.ExecType, confInfo.Platform)
-				log.Printf("[deviceDetectionRoutine] netInfo     : IPv4(%s), RTT(%v)", netInfo.IPv4, netInfo.RTT)
-				log.Printf("[deviceDetectionRoutine] serviceInfo : Services(%v)", serviceInfo.Services)
-				log.Printf("")
+	log.Printf("[deviceDetectionRoutine] %s", data.DeviceID)
+	log.Printf("[deviceDetectionRoutine] confInfo    : ExecType(%s), Platform(%s)", confInfo.ExecType, confInfo.Platform)
+	log.Printf("[deviceDetectionRoutine] netInfo     : IPv4(%s), IPv6(%s), RTT(%v)", netInfo.IPv4, netInfo.IPv6, netInfo.RTT)
+	log.Printf("[deviceDetectionRoutine] serviceInfo : Services(%v)", serviceInfo.Services)
+	log.Printf("")
 
-				var info networkdb.Info
-				info, err = getNetworkDB(netInfo.ID)
+	info, err := getNetworkDB(netInfo.ID)
 
-				if err != nil || !reflect.DeepEqual(netInfo.IPv4, info.IPv4) {
-					setNetworkDB(netInfo)
-				}
+	if err != nil || !reflect.DeepEqual(netInfo.IPv4, info.IPv4) || !reflect.DeepEqual(netInfo.IPv6, info.IPv6) {
+		setNetworkDB(netInfo)
+	}
 
-				// @Note Is it need to call Update API?
-				setConfigurationDB(confInfo)
-				setServiceDB(serviceInfo)
-
-				// Connect to the device which has DataStroage service
-				if len(netInfo.IPv4) > 0 && storageIns.GetStatus() == 0 {
-					for _, s := range serviceInfo.Services {
-						if strings.Contains(s, "DataStorage") {
-							storageIns.StartStorage(netInfo.IPv4[0])
-						}
-					}
-				}
+	// @Note Is it need to call Update API?
+	setConfigurationDB(confInfo)
+	setServiceDB(serviceInfo)
+	markSeen(data.DeviceID)
+
+	// Connect to the device which has DataStroage service
+	if len(netInfo.IPv4) > 0 && storageIns.GetStatus() == 0 {
+		for _, s := range serviceInfo.Services {
+			if strings.Contains(s, "DataStorage") {
+				storageIns.StartStorage(netInfo.IPv4[0])
 			}
 		}
-	}()
+	}
 }
 
 func serverPresenceChecker() error {
@@ -621,7 +660,7 @@ func serviceNameChecker(serviceName string) error {
 	platform, _ := getPlatform()
 	executionType, _ := getExecType()
 
-	if serviceName == platform || serviceName == executionType {
+	if serviceName == platform || serviceName == executionType || strings.HasPrefix(serviceName, wrapper.LabelPrefix) {
 		return errors.InvalidParam{Message: "cannot change fixed field"}
 	}
 
@@ -668,7 +707,12 @@ func getIndexToDelete(serverTXT []string, serviceName string) (idxToDel int, err
 
 func (d *DiscoveryImpl) setNewServiceList(serverTXT []string) {
 	// if len(serverTXT) > 2 {
-	newServiceList := serverTXT[2:]
+	var newServiceList []string
+	for _, str := range serverTXT[2:] {
+		if !strings.HasPrefix(str, wrapper.LabelPrefix) {
+			newServiceList = append(newServiceList, str)
+		}
+	}
 
 	deviceID, err := dbIns.GetDeviceID()
 	if err != nil {
@@ -716,8 +760,8 @@ func (d *DiscoveryImpl) StartMNEDCServer(deviceIDFilePath string) {
 	mnedc.GetServerInstance().StartMNEDCServer(deviceIDFilePath)
 }
 
-// ClearMap makes map empty and only leaves my device info
-func clearMap() {
+// clearMap makes map empty and only leaves my device info and the devices to keep
+func clearMap(keep func(deviceID string) bool) {
 	log.Println(logPrefix, "[clearMap]")
 
 	confItems, err := confQuery.GetList()
@@ -733,7 +777,7 @@ func clearMap() {
 	for _, confItem := range confItems {
 		id := confItem.ID
 
-		if id != deviceID {
+		if id != deviceID && !keep(id) {
 			deleteDevice(id)
 		}
 	}
@@ -752,6 +796,7 @@ func clearAllDeviceInfo() {
 	for _, confItem := range confItems {
 		deleteDevice(confItem.ID)
 	}
+	clearLiveness()
 }
 
 func convertToDBInfo(entity wrapper.Entity) (string, configurationdb.Configuration, networkdb.Info, servicedb.Info) {
@@ -764,9 +809,11 @@ func convertToDBInfo(entity wrapper.Entity) (string, configurationdb.Configurati
 	confInfo.ID = entity.DeviceID
 	confInfo.ExecType = data.ExecutionType
 	confInfo.Platform = data.Platform
+	confInfo.Labels = data.Labels
 
 	netInfo.ID = entity.DeviceID
 	netInfo.IPv4 = data.IPv4
+	netInfo.IPv6 = data.IPv6
 
 	serviceInfo.ID = entity.DeviceID
 	serviceInfo.Services = data.ServiceList
@@ -851,6 +898,13 @@ func deleteDevice(deviceID string) {
 	if err != nil {
 		log.Println(err.Error())
 	}
+
+	err = snapshotQuery.Delete(deviceID)
+	if err != nil {
+		log.Println(err.Error())
+	}
+
+	markRemoved(deviceID)
 }
 
 // activeDiscovery calls advertise function of Zeroconf
@@ -886,3 +940,49 @@ func getMNEDCServerAddress(path string) (string, string, error) {
 
 	return c.ServerIP, c.Port, nil
 }
+
+// getDeviceLabels reads the labels of the device from the yaml file of key: value pairs
+func getDeviceLabels(path string) map[string]string {
+	labels := make(map[string]string)
+	yamlFile, err := os.ReadFile(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Println(logPrefix, "cannot read labels :", err.Error())
+		}
+		return labels
+	}
+
+	err = yaml.Unmarshal(yamlFile, &labels)
+	if err != nil {
+		log.Println(logPrefix, "cannot parse labels :", err.Error())
+		return make(map[string]string)
+	}
+
+	for key := range labels {
+		if len(key) == 0 || strings.Contains(key, "=") {
+			log.Println(logPrefix, "invalid label key :", logmgr.SanitizeUserInput(key)) // lgtm [go/log-injection]
+			delete(labels, key)
+		}
+	}
+	return labels
+}
+
+// appendLabelsToTXT appends the labels to the text fields in the order of keys,
+// the labels over the mDNS TXT size are not advertised
+func appendLabelsToTXT(serverTXT []string, labels map[string]string) []string {
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		newTXT := append(serverTXT, wrapper.LabelPrefix+key+"="+labels[key])
+		if err := mdnsTXTSizeChecker(newTXT); err != nil {
+			log.Println(logPrefix, "label", logmgr.SanitizeUserInput(key), "is not advertised :", err.Error()) // lgtm [go/log-injection]
+			continue
+		}
+		serverTXT = newTXT
+	}
+	return serverTXT
+}
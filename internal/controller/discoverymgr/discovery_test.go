@@ -20,6 +20,7 @@ package discoverymgr
 import (
 	"net"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -196,6 +197,7 @@ func closeTest() {
 		id := confItem.ID
 		deleteDevice(id)
 	}
+	clearLiveness()
 }
 
 func init() {
@@ -648,6 +650,24 @@ func TestMNEDCReconciledCallback(t *testing.T) {
 	closeTest()
 }
 
+func TestGetDeviceInfo(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	createMockIns(ctrl)
+
+	addDevice(false)
+	mockDB.EXPECT().GetDeviceID().Return(defaultMyDeviceID, nil).AnyTimes()
+
+	deviceID, _, err := GetInstance().GetDeviceInfo()
+	if err != nil {
+		t.Error(err.Error())
+	} else if deviceID != defaultMyDeviceID {
+		t.Error("unexpected device ID", deviceID)
+	}
+	closeTest()
+}
+
 func TestGetOrchestrationInfo(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -737,3 +757,66 @@ func TestSetDeviceID(t *testing.T) {
 		}
 	})
 }
+
+func TestDeviceLabels(t *testing.T) {
+	t.Run("GetDeviceLabels", func(t *testing.T) {
+		labels := getDeviceLabels("testdata/labels.yaml")
+		if len(labels) != 3 || labels["room"] != "kitchen" {
+			t.Error("unexpected labels", labels)
+		}
+
+		if labels := getDeviceLabels("/x/y/z/NoFileIsThisName"); len(labels) != 0 {
+			t.Error("unexpected labels", labels)
+		}
+	})
+	t.Run("AppendLabelsToTXT", func(t *testing.T) {
+		serverTXT := []string{"Platform=" + defaultPlatform, "ExecType=" + defaultExecutionType}
+		labels := map[string]string{"room": "kitchen", "power": "mains"}
+
+		serverTXT = appendLabelsToTXT(serverTXT, labels)
+		if len(serverTXT) != 4 || serverTXT[2] != wrapper.LabelPrefix+"power=mains" || serverTXT[3] != wrapper.LabelPrefix+"room=kitchen" {
+			t.Error("unexpected text", serverTXT)
+		}
+
+		labels = map[string]string{"large": strings.Repeat("a", maxTXTSize)}
+		if newTXT := appendLabelsToTXT(serverTXT, labels); len(newTXT) != len(serverTXT) {
+			t.Error("label over the TXT size is advertised")
+		}
+	})
+	t.Run("ServiceListWithLabels", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		createMockIns(ctrl)
+		addDevice(false)
+		isMNEDCConnected = false
+
+		serverTXT := []string{defaultPlatform, defaultExecutionType, wrapper.LabelPrefix + "room=kitchen", defaultService}
+		mockWrapper.EXPECT().SetText(gomock.Eq(serverTXT)).Return()
+		mockDB.EXPECT().GetDeviceID().Return(defaultMyDeviceID, nil).AnyTimes()
+
+		GetInstance().(*DiscoveryImpl).setNewServiceList(serverTXT)
+
+		serviceInfo, err := serviceQuery.Get(defaultMyDeviceID)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if len(serviceInfo.Services) != 1 || serviceInfo.Services[0] != defaultService {
+			t.Error("unexpected services", serviceInfo.Services)
+		}
+
+		if err := serviceNameChecker(wrapper.LabelPrefix + "room=bedroom"); err == nil {
+			t.Error("label is changed as a service")
+		}
+		closeTest()
+	})
+	t.Run("ConvertToDBInfo", func(t *testing.T) {
+		entity := anotherEntity
+		entity.OrchestrationInfo.Labels = map[string]string{"room": "kitchen"}
+
+		_, confInfo, _, _ := convertToDBInfo(entity)
+		if confInfo.Labels["room"] != "kitchen" {
+			t.Error("unexpected labels", confInfo.Labels)
+		}
+	})
+}
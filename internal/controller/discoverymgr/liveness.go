@@ -0,0 +1,321 @@
+/*******************************************************************************
+ * Copyright 2020 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+
+package discoverymgr
+
+import (
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	livenessdb "github.com/lf-edge/edge-home-orchestration-go/internal/db/bolt/liveness"
+	networkdb "github.com/lf-edge/edge-home-orchestration-go/internal/db/bolt/network"
+	snapshotdb "github.com/lf-edge/edge-home-orchestration-go/internal/db/bolt/snapshot"
+)
+
+const (
+	pingAPI      = "/api/v1/ping"
+	internalPort = 56002
+
+	defaultHeartbeatInterval = 5 * time.Second
+	defaultUnreachableAfter  = 30 * time.Second
+	defaultRemoveAfter       = 60 * time.Second
+)
+
+// livenessConf is the configuration of the heartbeats checking the other devices, the times are in seconds
+type livenessConf struct {
+	// HeartbeatInterval is the interval of the heartbeats
+	HeartbeatInterval int `yaml:"heartbeat-interval"`
+	// UnreachableAfter is the grace period from the last answer after which the device is unreachable
+	UnreachableAfter int `yaml:"unreachable-after"`
+	// RemoveAfter is the grace period from the last answer after which the device is removed
+	RemoveAfter int `yaml:"remove-after"`
+}
+
+type livenessPolicy struct {
+	interval         time.Duration
+	unreachableAfter time.Duration
+	removeAfter      time.Duration
+}
+
+// deviceWatchers holds the channels watching the transitions of the device states
+type deviceWatchers struct {
+	sync.Mutex
+	items map[chan map[string]interface{}]bool
+}
+
+var (
+	livenessMTX sync.Mutex
+	watchers    = deviceWatchers{items: make(map[chan map[string]interface{}]bool)}
+)
+
+// getLivenessPolicy reads the configuration of the heartbeats from the yaml file, the default values are used without it
+func getLivenessPolicy(path string) livenessPolicy {
+	policy := livenessPolicy{
+		interval:         defaultHeartbeatInterval,
+		unreachableAfter: defaultUnreachableAfter,
+		removeAfter:      defaultRemoveAfter,
+	}
+
+	conf := livenessConf{}
+	yamlFile, err := os.ReadFile(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Println(logPrefix, "cannot read liveness conf :", err.Error())
+		}
+		return policy
+	}
+	if err = yaml.Unmarshal(yamlFile, &conf); err != nil {
+		log.Println(logPrefix, "cannot parse liveness conf :", err.Error())
+		return policy
+	}
+
+	if conf.HeartbeatInterval > 0 {
+		policy.interval = time.Duration(conf.HeartbeatInterval) * time.Second
+	}
+	if conf.UnreachableAfter > 0 {
+		policy.unreachableAfter = time.Duration(conf.UnreachableAfter) * time.Second
+	}
+	if conf.RemoveAfter > 0 {
+		policy.removeAfter = time.Duration(conf.RemoveAfter) * time.Second
+	}
+	if policy.removeAfter < policy.unreachableAfter {
+		log.Println(logPrefix, "remove-after is shorter than unreachable-after, the device is removed when it is unreachable")
+		policy.removeAfter = policy.unreachableAfter
+	}
+	return policy
+}
+
+// heartbeatRoutine checks the liveness of the other devices by the ping API until the discovery is stopped
+func heartbeatRoutine(policy livenessPolicy) {
+	log.Println(logPrefix, "[heartbeatRoutine]", "every", policy.interval, "unreachable after", policy.unreachableAfter,
+		"removed after", policy.removeAfter)
+
+	shutdown := shutdownChan
+	ticker := time.NewTicker(policy.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-shutdown:
+			log.Println(logPrefix, "[heartbeatRoutine]", "Shutdown")
+			return
+		case <-ticker.C:
+			checkHeartbeats(policy)
+		}
+	}
+}
+
+// checkHeartbeats pings all devices at the same time and updates their states
+func checkHeartbeats(policy livenessPolicy) {
+	deviceID, err := dbIns.GetDeviceID()
+	if err != nil {
+		return
+	}
+	netInfos, err := netQuery.GetList()
+	if err != nil {
+		log.Println(logPrefix, "[checkHeartbeats]", err.Error())
+		return
+	}
+
+	var wg sync.WaitGroup
+	for _, netInfo := range netInfos {
+		if netInfo.ID == deviceID {
+			continue
+		}
+		wg.Add(1)
+		go func(info networkdb.Info) {
+			defer wg.Done()
+			if ip, ok := ping(info.GetIPs()); ok {
+				markSeen(info.ID)
+				keepResource(deviceID, info.ID, ip)
+			} else if markMissed(info.ID, policy) == livenessdb.StateRemoved {
+				deleteDevice(info.ID)
+			}
+		}(netInfo)
+	}
+	wg.Wait()
+}
+
+// ping checks if the device answers to one of its addresses and returns the address answering
+func ping(ips []string) (string, bool) {
+	for _, ip := range ips {
+		_, statusCode, err := pingHelper.DoGet(pingHelper.MakeTargetURL(ip, internalPort, pingAPI))
+		if err == nil && statusCode == http.StatusOK {
+			return ip, true
+		}
+	}
+	return "", false
+}
+
+// keepResource keeps the last resource values of the device answering the heartbeat for the cluster membership
+func keepResource(deviceID string, targetID string, ip string) {
+	if discoveryIns.Clienter == nil {
+		return
+	}
+	resource, err := discoveryIns.Clienter.DoGetResourceRemoteDevice(deviceID, ip)
+	if err != nil {
+		log.Println(logPrefix, "[keepResource]", targetID, err.Error())
+		return
+	}
+	snapshot := snapshotdb.Info{ID: targetID, Resource: resource, Time: time.Now()}
+	if err = snapshotQuery.Set(snapshot); err != nil {
+		log.Println(logPrefix, "[keepResource]", targetID, err.Error())
+	}
+}
+
+// markSeen records that the device is found, the device becomes online
+func markSeen(deviceID string) {
+	updateLiveness(deviceID, func(info *livenessdb.Info, now time.Time) {
+		info.State = livenessdb.StateOnline
+		info.LastSeen = now
+	})
+}
+
+// markMissed records that the device missed a heartbeat and returns its state by the time from the last answer
+func markMissed(deviceID string, policy livenessPolicy) string {
+	return updateLiveness(deviceID, func(info *livenessdb.Info, now time.Time) {
+		switch elapsed := now.Sub(info.LastSeen); {
+		case elapsed >= policy.removeAfter:
+			info.State = livenessdb.StateRemoved
+		case elapsed >= policy.unreachableAfter:
+			info.State = livenessdb.StateUnreachable
+		default:
+			info.State = livenessdb.StateDegraded
+		}
+	})
+}
+
+// markRemoved records that the device is deleted from the device lists
+func markRemoved(deviceID string) {
+	updateLiveness(deviceID, func(info *livenessdb.Info, now time.Time) {
+		info.State = livenessdb.StateRemoved
+	})
+}
+
+// updateLiveness applies the change to the liveness of the device and publishes the transition of its state
+func updateLiveness(deviceID string, change func(info *livenessdb.Info, now time.Time)) string {
+	livenessMTX.Lock()
+	defer livenessMTX.Unlock()
+
+	now := time.Now()
+	info, err := livenessQuery.Get(deviceID)
+	if err != nil {
+		info = livenessdb.Info{ID: deviceID, LastSeen: now}
+	}
+
+	previous := info.State
+	change(&info, now)
+	if info.State != previous {
+		info.Since = now
+		log.Println(logPrefix, "[liveness]", deviceID, previous, "->", info.State)
+
+		event := convertLivenessToMap(info)
+		event["PreviousState"] = previous
+		watchers.publish(event)
+	}
+
+	if err := livenessQuery.Set(info); err != nil {
+		log.Println(logPrefix, err.Error())
+	}
+	return info.State
+}
+
+// clearLiveness deletes the liveness of all devices
+func clearLiveness() {
+	livenessMTX.Lock()
+	defer livenessMTX.Unlock()
+
+	infos, err := livenessQuery.GetList()
+	if err != nil {
+		log.Println(logPrefix, err.Error())
+		return
+	}
+	for _, info := range infos {
+		if err := livenessQuery.Delete(info.ID); err != nil {
+			log.Println(logPrefix, err.Error())
+		}
+	}
+}
+
+// GetDeviceStates returns the liveness states of the devices found after the discovery is started
+func (DiscoveryImpl) GetDeviceStates() ([]map[string]interface{}, error) {
+	infos, err := livenessQuery.GetList()
+	if err != nil {
+		return nil, err
+	}
+
+	states := make([]map[string]interface{}, 0, len(infos))
+	for _, info := range infos {
+		states = append(states, convertLivenessToMap(info))
+	}
+	return states, nil
+}
+
+// WatchDeviceStates returns a channel delivering the transitions of the device states and the function to stop watching
+func (DiscoveryImpl) WatchDeviceStates() (<-chan map[string]interface{}, func()) {
+	return watchers.add()
+}
+
+func convertLivenessToMap(info livenessdb.Info) map[string]interface{} {
+	return map[string]interface{}{
+		"DeviceID": info.ID,
+		"State":    info.State,
+		"LastSeen": info.LastSeen,
+		"Since":    info.Since,
+	}
+}
+
+// add registers a channel to watch the transitions of the device states
+func (dw *deviceWatchers) add() (<-chan map[string]interface{}, func()) {
+	dw.Lock()
+	defer dw.Unlock()
+
+	watchChan := make(chan map[string]interface{}, 16)
+	dw.items[watchChan] = true
+
+	cancel := func() {
+		dw.Lock()
+		defer dw.Unlock()
+
+		if dw.items[watchChan] {
+			delete(dw.items, watchChan)
+			close(watchChan)
+		}
+	}
+	return watchChan, cancel
+}
+
+// publish passes the transition to the watchers without blocking
+func (dw *deviceWatchers) publish(event map[string]interface{}) {
+	dw.Lock()
+	defer dw.Unlock()
+
+	for watchChan := range dw.items {
+		copied := make(map[string]interface{}, len(event))
+		for key, value := range event {
+			copied[key] = value
+		}
+		select {
+		case watchChan <- copied:
+		default:
+			log.Println(logPrefix, "device watcher is too slow, the transition is dropped")
+		}
+	}
+}
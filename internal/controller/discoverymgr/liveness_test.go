@@ -0,0 +1,220 @@
+/*******************************************************************************
+ * Copyright 2020 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+
+package discoverymgr
+
+import (
+	goerror "errors"
+	"net/http"
+	"testing"
+	"time"
+
+	livenessdb "github.com/lf-edge/edge-home-orchestration-go/internal/db/bolt/liveness"
+	clientMocks "github.com/lf-edge/edge-home-orchestration-go/internal/restinterface/client/mocks"
+	helperMocks "github.com/lf-edge/edge-home-orchestration-go/internal/restinterface/resthelper/mocks"
+
+	"github.com/golang/mock/gomock"
+)
+
+var testPolicy = livenessPolicy{
+	interval:         time.Second,
+	unreachableAfter: 10 * time.Second,
+	removeAfter:      20 * time.Second,
+}
+
+func setLastSeen(t *testing.T, deviceID string, state string, elapsed time.Duration) {
+	t.Helper()
+
+	info := livenessdb.Info{ID: deviceID, State: state, LastSeen: time.Now().Add(-elapsed)}
+	if err := livenessQuery.Set(info); err != nil {
+		t.Fatal(err.Error())
+	}
+}
+
+func checkState(t *testing.T, deviceID string, state string) {
+	t.Helper()
+
+	info, err := livenessQuery.Get(deviceID)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if info.State != state {
+		t.Error("unexpected state", info.State, "expected", state)
+	}
+}
+
+func TestGetLivenessPolicy(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		policy := getLivenessPolicy("testdata/liveness.yaml")
+		if policy.interval != 2*time.Second || policy.unreachableAfter != 10*time.Second || policy.removeAfter != 20*time.Second {
+			t.Error("unexpected policy", policy)
+		}
+	})
+	t.Run("ShorterRemoveAfter", func(t *testing.T) {
+		policy := getLivenessPolicy("testdata/liveness_clamp.yaml")
+		if policy.interval != defaultHeartbeatInterval || policy.removeAfter != 40*time.Second {
+			t.Error("unexpected policy", policy)
+		}
+	})
+	t.Run("NoFile", func(t *testing.T) {
+		policy := getLivenessPolicy("testdata/nofile.yaml")
+		if policy.interval != defaultHeartbeatInterval || policy.unreachableAfter != defaultUnreachableAfter ||
+			policy.removeAfter != defaultRemoveAfter {
+			t.Error("unexpected policy", policy)
+		}
+	})
+}
+
+func TestLivenessTransition(t *testing.T) {
+	clearLiveness()
+	defer clearLiveness()
+
+	watchChan, cancel := GetInstance().WatchDeviceStates()
+	defer cancel()
+
+	t.Run("Online", func(t *testing.T) {
+		markSeen(anotherDeviceID)
+		checkState(t, anotherDeviceID, livenessdb.StateOnline)
+
+		select {
+		case event := <-watchChan:
+			if event["DeviceID"] != anotherDeviceID || event["State"] != livenessdb.StateOnline || event["PreviousState"] != "" {
+				t.Error("unexpected event", event)
+			}
+		case <-time.After(time.Second):
+			t.Error("no event")
+		}
+	})
+	t.Run("StillOnline", func(t *testing.T) {
+		markSeen(anotherDeviceID)
+		select {
+		case event := <-watchChan:
+			t.Error("unexpected event", event)
+		default:
+		}
+	})
+	t.Run("Missed", func(t *testing.T) {
+		tests := []struct {
+			elapsed time.Duration
+			state   string
+		}{
+			{time.Second, livenessdb.StateDegraded},
+			{15 * time.Second, livenessdb.StateUnreachable},
+			{25 * time.Second, livenessdb.StateRemoved},
+		}
+		for _, test := range tests {
+			setLastSeen(t, anotherDeviceID, livenessdb.StateOnline, test.elapsed)
+			if state := markMissed(anotherDeviceID, testPolicy); state != test.state {
+				t.Error("unexpected state", state, "expected", test.state)
+			}
+
+			event := <-watchChan
+			if event["State"] != test.state || event["PreviousState"] != livenessdb.StateOnline {
+				t.Error("unexpected event", event)
+			}
+		}
+	})
+	t.Run("Removed", func(t *testing.T) {
+		markSeen(anotherDeviceID)
+		<-watchChan
+
+		markRemoved(anotherDeviceID)
+		checkState(t, anotherDeviceID, livenessdb.StateRemoved)
+		if event := <-watchChan; event["State"] != livenessdb.StateRemoved {
+			t.Error("unexpected event", event)
+		}
+	})
+	t.Run("Canceled", func(t *testing.T) {
+		cancel()
+		if _, ok := <-watchChan; ok {
+			t.Error("channel is not closed")
+		}
+		cancel()
+	})
+}
+
+func TestCheckHeartbeats(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	createMockIns(ctrl)
+	mockHelper := helperMocks.NewMockRestHelper(ctrl)
+	defaultHelper := pingHelper
+	pingHelper = mockHelper
+	defer func() { pingHelper = defaultHelper }()
+	mockClient := clientMocks.NewMockClienter(ctrl)
+	defaultClient := discoveryIns.Clienter
+	discoveryIns.SetClient(mockClient)
+	defer discoveryIns.SetClient(defaultClient)
+
+	mockDB.EXPECT().GetDeviceID().Return(defaultMyDeviceID, nil).AnyTimes()
+	mockHelper.EXPECT().MakeTargetURL(gomock.Any(), gomock.Eq(internalPort), gomock.Eq(pingAPI)).DoAndReturn(
+		func(target string, port int, restapi string) string {
+			return target
+		}).AnyTimes()
+
+	t.Run("Alive", func(t *testing.T) {
+		addDevice(true)
+		setLastSeen(t, anotherDeviceID, livenessdb.StateDegraded, 25*time.Second)
+		mockHelper.EXPECT().DoGet(gomock.Eq(anotherIPv4)).Return(nil, http.StatusOK, nil)
+		mockClient.EXPECT().DoGetResourceRemoteDevice(gomock.Eq(defaultMyDeviceID), gomock.Eq(anotherIPv4)).Return(
+			map[string]interface{}{"cpuUsage": 10.0}, nil)
+
+		checkHeartbeats(testPolicy)
+		checkState(t, anotherDeviceID, livenessdb.StateOnline)
+		checkPresence(t, anotherDeviceID)
+		if snapshot, err := snapshotQuery.Get(anotherDeviceID); err != nil || snapshot.Resource["cpuUsage"] != 10.0 {
+			t.Error("unexpected snapshot", snapshot, err)
+		}
+	})
+	t.Run("Unreachable", func(t *testing.T) {
+		setLastSeen(t, anotherDeviceID, livenessdb.StateDegraded, 15*time.Second)
+		mockHelper.EXPECT().DoGet(gomock.Eq(anotherIPv4)).Return(nil, 0, goerror.New(""))
+
+		checkHeartbeats(testPolicy)
+		checkState(t, anotherDeviceID, livenessdb.StateUnreachable)
+		checkPresence(t, anotherDeviceID)
+	})
+	t.Run("Removed", func(t *testing.T) {
+		setLastSeen(t, anotherDeviceID, livenessdb.StateUnreachable, 25*time.Second)
+		mockHelper.EXPECT().DoGet(gomock.Eq(anotherIPv4)).Return(nil, http.StatusServiceUnavailable, nil)
+
+		checkHeartbeats(testPolicy)
+		checkState(t, anotherDeviceID, livenessdb.StateRemoved)
+		checkNotPresence(t, anotherDeviceID)
+		checkPresence(t, defaultMyDeviceID)
+		if _, err := snapshotQuery.Get(anotherDeviceID); err == nil {
+			t.Error("snapshot of the removed device is kept")
+		}
+	})
+
+	closeTest()
+}
+
+func TestGetDeviceStates(t *testing.T) {
+	clearLiveness()
+	defer clearLiveness()
+
+	markSeen(anotherDeviceID)
+	states, err := GetInstance().GetDeviceStates()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(states) != 1 || states[0]["DeviceID"] != anotherDeviceID || states[0]["State"] != livenessdb.StateOnline {
+		t.Error("unexpected states", states)
+	}
+}
@@ -25,6 +25,7 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+	wrapper "github.com/lf-edge/edge-home-orchestration-go/internal/controller/discoverymgr/wrapper"
 	cipher "github.com/lf-edge/edge-home-orchestration-go/internal/restinterface/cipher"
 	client "github.com/lf-edge/edge-home-orchestration-go/internal/restinterface/client"
 )
@@ -52,6 +53,20 @@ func (m *MockDiscovery) EXPECT() *MockDiscoveryMockRecorder {
 	return m.recorder
 }
 
+// AddBackend mocks base method.
+func (m *MockDiscovery) AddBackend(backend wrapper.DiscoveryBackend) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddBackend", backend)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddBackend indicates an expected call of AddBackend.
+func (mr *MockDiscoveryMockRecorder) AddBackend(backend interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddBackend", reflect.TypeOf((*MockDiscovery)(nil).AddBackend), backend)
+}
+
 // AddDeviceInfo mocks base method.
 func (m *MockDiscovery) AddDeviceInfo(deviceID, virtualAddr, privateAddr string) {
 	m.ctrl.T.Helper()
@@ -102,6 +117,37 @@ func (mr *MockDiscoveryMockRecorder) DeleteDeviceWithIP(targetIP interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDeviceWithIP", reflect.TypeOf((*MockDiscovery)(nil).DeleteDeviceWithIP), targetIP)
 }
 
+// GetDeviceInfo mocks base method.
+func (m *MockDiscovery) GetDeviceInfo() (string, map[string]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeviceInfo")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(map[string]string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetDeviceInfo indicates an expected call of GetDeviceInfo.
+func (mr *MockDiscoveryMockRecorder) GetDeviceInfo() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeviceInfo", reflect.TypeOf((*MockDiscovery)(nil).GetDeviceInfo))
+}
+
+// GetDeviceStates mocks base method.
+func (m *MockDiscovery) GetDeviceStates() ([]map[string]interface{}, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeviceStates")
+	ret0, _ := ret[0].([]map[string]interface{})
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeviceStates indicates an expected call of GetDeviceStates.
+func (mr *MockDiscoveryMockRecorder) GetDeviceStates() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeviceStates", reflect.TypeOf((*MockDiscovery)(nil).GetDeviceStates))
+}
+
 // GetOrchestrationInfo mocks base method.
 func (m *MockDiscovery) GetOrchestrationInfo() (string, string, []string, error) {
 	m.ctrl.T.Helper()
@@ -268,3 +314,18 @@ func (mr *MockDiscoveryMockRecorder) StopDiscovery() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopDiscovery", reflect.TypeOf((*MockDiscovery)(nil).StopDiscovery))
 }
+
+// WatchDeviceStates mocks base method.
+func (m *MockDiscovery) WatchDeviceStates() (<-chan map[string]interface{}, func()) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchDeviceStates")
+	ret0, _ := ret[0].(<-chan map[string]interface{})
+	ret1, _ := ret[1].(func())
+	return ret0, ret1
+}
+
+// WatchDeviceStates indicates an expected call of WatchDeviceStates.
+func (mr *MockDiscoveryMockRecorder) WatchDeviceStates() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchDeviceStates", reflect.TypeOf((*MockDiscovery)(nil).WatchDeviceStates))
+}
@@ -0,0 +1,204 @@
+/*******************************************************************************
+ * Copyright 2020 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+
+package discoverymgr
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	errors "github.com/lf-edge/edge-home-orchestration-go/internal/common/errors"
+	"github.com/lf-edge/edge-home-orchestration-go/internal/common/logmgr"
+	wrapper "github.com/lf-edge/edge-home-orchestration-go/internal/controller/discoverymgr/wrapper"
+	"github.com/lf-edge/edge-home-orchestration-go/internal/restinterface/client"
+)
+
+const (
+	// defaultStaticPeerInterval is the interval of requesting the orchestration info of the static peers
+	defaultStaticPeerInterval = 30 * time.Second
+	// staticPeerMaxFailures is the number of consecutive failed requests to delete the static peer
+	staticPeerMaxFailures = 3
+
+	staticPeerBackendName = "static"
+)
+
+// staticPeerConf is the list of the peers discovered by unicast, for the networks dropping mDNS
+type staticPeerConf struct {
+	// Peers are the addresses of the peers
+	Peers []string `yaml:"peers"`
+	// Interval is the interval of the requests in seconds
+	Interval int `yaml:"interval"`
+}
+
+type staticPeer struct {
+	address  string
+	deviceID string
+	failures int
+}
+
+// staticPeerBackend finds the static peers by requesting their orchestration info periodically
+type staticPeerBackend struct {
+	client.HasClient
+	conf staticPeerConf
+	stop chan struct{}
+}
+
+// getStaticPeerConf reads the static peers from the yaml file
+func getStaticPeerConf(path string) (conf staticPeerConf, err error) {
+	yamlFile, err := os.ReadFile(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Println(logPrefix, "cannot read static peers :", err.Error())
+		}
+		return
+	}
+
+	if err = yaml.Unmarshal(yamlFile, &conf); err != nil {
+		log.Println(logPrefix, "cannot parse static peers :", err.Error())
+	}
+	return
+}
+
+func newStaticPeerBackend(conf staticPeerConf) *staticPeerBackend {
+	return &staticPeerBackend{conf: conf}
+}
+
+// Name returns the name of the static peer backend
+func (b *staticPeerBackend) Name() string {
+	return staticPeerBackendName
+}
+
+// Start starts to request the orchestration info of the static peers
+func (b *staticPeerBackend) Start() (<-chan *wrapper.Entity, error) {
+	if b.Clienter == nil {
+		return nil, errors.InvalidParam{Message: "client is nil"}
+	}
+
+	b.stop = make(chan struct{})
+	subchan := make(chan *wrapper.Entity, len(b.conf.Peers))
+	go b.requestRoutine(subchan, b.stop)
+	return subchan, nil
+}
+
+// Stop stops to request the orchestration info of the static peers
+func (b *staticPeerBackend) Stop() {
+	if b.stop != nil {
+		close(b.stop)
+		b.stop = nil
+	}
+}
+
+// requestRoutine requests the orchestration info of the static peers periodically until the backend is stopped
+func (b *staticPeerBackend) requestRoutine(subchan chan<- *wrapper.Entity, stop chan struct{}) {
+	peers := make([]*staticPeer, 0, len(b.conf.Peers))
+	for _, address := range b.conf.Peers {
+		peers = append(peers, &staticPeer{address: address})
+	}
+
+	interval := defaultStaticPeerInterval
+	if b.conf.Interval > 0 {
+		interval = time.Duration(b.conf.Interval) * time.Second
+	}
+	log.Println(logPrefix, "[staticPeerBackend]", b.conf.Peers, "every", interval)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		for _, peer := range peers {
+			for _, data := range b.request(peer) {
+				select {
+				case subchan <- data:
+				case <-stop:
+					return
+				}
+			}
+		}
+
+		select {
+		case <-stop:
+			log.Println(logPrefix, "[staticPeerBackend]", "Stop")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// request returns the entities of the changes of the static peer,
+// the device is lost after the peer does not respond several times
+func (b *staticPeerBackend) request(peer *staticPeer) (entities []*wrapper.Entity) {
+	respMsg, err := b.Clienter.DoGetDeviceInfo(peer.address)
+	var data wrapper.Entity
+	if err == nil {
+		data, err = convertToEntity(peer.address, respMsg)
+	}
+	if err != nil {
+		peer.failures++
+		log.Println(logPrefix, "[staticPeerBackend]", logmgr.SanitizeUserInput(peer.address), err.Error()) // lgtm [go/log-injection]
+		if peer.failures == staticPeerMaxFailures && len(peer.deviceID) > 0 {
+			entities = append(entities, &wrapper.Entity{DeviceID: peer.deviceID})
+			peer.deviceID = ""
+		}
+		return
+	}
+	peer.failures = 0
+
+	if deviceID, err := dbIns.GetDeviceID(); err == nil && deviceID == data.DeviceID {
+		return
+	}
+	if len(peer.deviceID) > 0 && peer.deviceID != data.DeviceID {
+		entities = append(entities, &wrapper.Entity{DeviceID: peer.deviceID})
+	}
+	peer.deviceID = data.DeviceID
+	return append(entities, &data)
+}
+
+// convertToEntity converts the orchestration info of the peer to the entity of the device with the address of the peer
+func convertToEntity(address string, respMsg map[string]interface{}) (data wrapper.Entity, err error) {
+	deviceID, _ := respMsg["DeviceID"].(string)
+	if len(deviceID) == 0 {
+		return data, errors.InvalidParam{Message: "no device ID in orchestration info"}
+	}
+
+	data = wrapper.Entity{
+		DeviceID: deviceID,
+		TTL:      1,
+	}
+	if ip := net.ParseIP(address); ip != nil && ip.To4() == nil {
+		data.OrchestrationInfo.IPv6 = []string{address}
+	} else {
+		data.OrchestrationInfo.IPv4 = []string{address}
+	}
+	data.OrchestrationInfo.Platform, _ = respMsg["Platform"].(string)
+	data.OrchestrationInfo.ExecutionType, _ = respMsg["ExecutionType"].(string)
+
+	services, _ := respMsg["ServiceList"].([]interface{})
+	for _, service := range services {
+		data.OrchestrationInfo.ServiceList = append(data.OrchestrationInfo.ServiceList, fmt.Sprint(service))
+	}
+
+	if labels, ok := respMsg["Labels"].(map[string]interface{}); ok {
+		data.OrchestrationInfo.Labels = make(map[string]string)
+		for key, value := range labels {
+			data.OrchestrationInfo.Labels[key] = fmt.Sprint(value)
+		}
+	}
+	return data, nil
+}
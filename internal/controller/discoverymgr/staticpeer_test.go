@@ -0,0 +1,183 @@
+/*******************************************************************************
+ * Copyright 2020 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+
+package discoverymgr
+
+import (
+	goerror "errors"
+	"reflect"
+	"testing"
+	"time"
+
+	clientMocks "github.com/lf-edge/edge-home-orchestration-go/internal/restinterface/client/mocks"
+
+	"github.com/golang/mock/gomock"
+)
+
+func getAnotherDeviceInfo() map[string]interface{} {
+	return map[string]interface{}{
+		"DeviceID":      anotherDeviceID,
+		"Platform":      defaultPlatform,
+		"ExecutionType": defaultExecutionType,
+		"ServiceList":   []interface{}{anotherService},
+		"Labels":        map[string]interface{}{"room": "kitchen"},
+	}
+}
+
+func TestGetStaticPeerConf(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		conf, err := getStaticPeerConf("testdata/peers.yaml")
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if !reflect.DeepEqual(conf.Peers, []string{"192.168.2.10", "10.0.3.7"}) || conf.Interval != 10 {
+			t.Error("unexpected conf", conf)
+		}
+	})
+	t.Run("NoFile", func(t *testing.T) {
+		if _, err := getStaticPeerConf("testdata/nofile.yaml"); err == nil {
+			t.Error("unexpected success")
+		}
+	})
+}
+
+func TestConvertToEntity(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		data, err := convertToEntity(anotherIPv4, getAnotherDeviceInfo())
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if data.DeviceID != anotherDeviceID ||
+			!reflect.DeepEqual(data.OrchestrationInfo.IPv4, anotherIPv4List) ||
+			!reflect.DeepEqual(data.OrchestrationInfo.ServiceList, anotherServiceList) ||
+			data.OrchestrationInfo.Labels["room"] != "kitchen" {
+			t.Error("unexpected entity", data)
+		}
+	})
+	t.Run("IPv6", func(t *testing.T) {
+		data, err := convertToEntity("fd00::2", getAnotherDeviceInfo())
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if len(data.OrchestrationInfo.IPv4) != 0 || !reflect.DeepEqual(data.OrchestrationInfo.IPv6, []string{"fd00::2"}) {
+			t.Error("unexpected entity", data)
+		}
+	})
+	t.Run("NoDeviceID", func(t *testing.T) {
+		respMsg := getAnotherDeviceInfo()
+		delete(respMsg, "DeviceID")
+		if _, err := convertToEntity(anotherIPv4, respMsg); err == nil {
+			t.Error("unexpected success")
+		}
+	})
+}
+
+func TestStaticPeerRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	createMockIns(ctrl)
+	mockClient := clientMocks.NewMockClienter(ctrl)
+	backend := newStaticPeerBackend(staticPeerConf{Peers: []string{anotherIPv4}})
+	backend.SetClient(mockClient)
+
+	mockDB.EXPECT().GetDeviceID().Return(defaultMyDeviceID, nil).AnyTimes()
+
+	peer := &staticPeer{address: anotherIPv4}
+	t.Run("Success", func(t *testing.T) {
+		mockClient.EXPECT().DoGetDeviceInfo(gomock.Eq(anotherIPv4)).Return(getAnotherDeviceInfo(), nil)
+
+		entities := backend.request(peer)
+		if len(entities) != 1 || entities[0].DeviceID != anotherDeviceID || entities[0].TTL == 0 {
+			t.Error("unexpected entities", entities)
+		}
+		if peer.deviceID != anotherDeviceID {
+			t.Error("unexpected device ID", peer.deviceID)
+		}
+	})
+	t.Run("ChangedDeviceID", func(t *testing.T) {
+		respMsg := getAnotherDeviceInfo()
+		respMsg["DeviceID"] = "edge-orchestration-test-device-id3"
+		mockClient.EXPECT().DoGetDeviceInfo(gomock.Eq(anotherIPv4)).Return(respMsg, nil)
+
+		entities := backend.request(peer)
+		if len(entities) != 2 || entities[0].DeviceID != anotherDeviceID || entities[0].TTL != 0 ||
+			entities[1].DeviceID != "edge-orchestration-test-device-id3" {
+			t.Error("unexpected entities", entities)
+		}
+	})
+	t.Run("Unreachable", func(t *testing.T) {
+		mockClient.EXPECT().DoGetDeviceInfo(gomock.Eq(anotherIPv4)).Return(nil, goerror.New("get return error")).Times(staticPeerMaxFailures)
+
+		for i := 0; i < staticPeerMaxFailures-1; i++ {
+			if entities := backend.request(peer); len(entities) != 0 {
+				t.Error("unexpected entities", entities)
+			}
+		}
+
+		entities := backend.request(peer)
+		if len(entities) != 1 || entities[0].TTL != 0 || len(peer.deviceID) != 0 {
+			t.Error("peer is not lost", entities)
+		}
+	})
+	t.Run("MyDevice", func(t *testing.T) {
+		respMsg := getAnotherDeviceInfo()
+		respMsg["DeviceID"] = defaultMyDeviceID
+		mockClient.EXPECT().DoGetDeviceInfo(gomock.Any()).Return(respMsg, nil)
+
+		myPeer := &staticPeer{address: defaultIPv4}
+		if entities := backend.request(myPeer); len(entities) != 0 || len(myPeer.deviceID) != 0 {
+			t.Error("my device is added as a peer")
+		}
+	})
+}
+
+func TestStaticPeerBackend(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	createMockIns(ctrl)
+	mockClient := clientMocks.NewMockClienter(ctrl)
+	mockDB.EXPECT().GetDeviceID().Return(defaultMyDeviceID, nil).AnyTimes()
+
+	t.Run("Success", func(t *testing.T) {
+		mockClient.EXPECT().DoGetDeviceInfo(gomock.Eq(anotherIPv4)).Return(getAnotherDeviceInfo(), nil)
+
+		backend := newStaticPeerBackend(staticPeerConf{Peers: []string{anotherIPv4}, Interval: 60})
+		backend.SetClient(mockClient)
+		subchan, err := backend.Start()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		defer backend.Stop()
+
+		select {
+		case data := <-subchan:
+			if data.DeviceID != anotherDeviceID {
+				t.Error("unexpected entity", data)
+			}
+		case <-time.After(5 * time.Second):
+			t.Error("static peer is not found")
+		}
+	})
+	t.Run("NoClient", func(t *testing.T) {
+		backend := newStaticPeerBackend(staticPeerConf{Peers: []string{anotherIPv4}})
+		if _, err := backend.Start(); err == nil {
+			t.Error("unexpected success")
+		}
+	})
+}
@@ -21,8 +21,10 @@ package discoverymgr
 import (
 	wrapper "github.com/lf-edge/edge-home-orchestration-go/internal/controller/discoverymgr/wrapper"
 	configurationdb "github.com/lf-edge/edge-home-orchestration-go/internal/db/bolt/configuration"
+	livenessdb "github.com/lf-edge/edge-home-orchestration-go/internal/db/bolt/liveness"
 	networkdb "github.com/lf-edge/edge-home-orchestration-go/internal/db/bolt/network"
 	servicedb "github.com/lf-edge/edge-home-orchestration-go/internal/db/bolt/service"
+	snapshotdb "github.com/lf-edge/edge-home-orchestration-go/internal/db/bolt/snapshot"
 	systemdb "github.com/lf-edge/edge-home-orchestration-go/internal/db/bolt/system"
 	"sync"
 )
@@ -39,9 +41,10 @@ type OrchestrationInformation struct {
 	ExecutionType string `json:"ExecutionType"`
 
 	// List of IP and Services
-	IPv4 []string `json:"IPv4"`
-	// IPv6       []string   `json:"IPv6"`
-	ServiceList []string `json:"ServiceList"`
+	IPv4        []string          `json:"IPv4"`
+	IPv6        []string          `json:"IPv6,omitempty"`
+	ServiceList []string          `json:"ServiceList"`
+	Labels      map[string]string `json:"Labels,omitempty"`
 }
 
 // ExportDeviceMap gives device info map for discoverymgr user
@@ -65,10 +68,15 @@ var (
 	shutdownChan     chan struct{}
 	isMNEDCConnected bool
 
-	sysQuery     systemdb.DBInterface
-	confQuery    configurationdb.DBInterface
-	netQuery     networkdb.DBInterface
-	serviceQuery servicedb.DBInterface
+	sysQuery      systemdb.DBInterface
+	confQuery     configurationdb.DBInterface
+	netQuery      networkdb.DBInterface
+	serviceQuery  servicedb.DBInterface
+	livenessQuery livenessdb.DBInterface
+	snapshotQuery snapshotdb.DBInterface
 
 	configAlternate = "/storage/emulated/0/client-config.yaml"
+	labelsPath      = edgeDirect + "device/labels.yaml"
+	peersPath       = edgeDirect + "device/peers.yaml"
+	livenessPath    = edgeDirect + "device/liveness.yaml"
 )
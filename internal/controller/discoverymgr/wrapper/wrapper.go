@@ -27,7 +27,12 @@ import (
 	"github.com/grandcat/zeroconf"
 )
 
-const logPrefix = "[discovery][wrapper]"
+const (
+	logPrefix = "[discovery][wrapper]"
+
+	// LabelPrefix is the prefix of the text fields holding the device labels as Label.key=value
+	LabelPrefix = "Label."
+)
 
 var (
 	log = logmgr.GetInstance()
@@ -47,6 +52,18 @@ type ZeroconfInterface interface {
 	Shutdown()
 }
 
+// DiscoveryBackend is the interface implemented by the ways to find the other orchestration devices,
+// the devices found by several backends are merged by their device ID in discoverymgr
+type DiscoveryBackend interface {
+	// Name returns the unique name of the backend
+	Name() string
+	// Start starts to find the devices and returns the channel of their entities,
+	// the entity with TTL 0 is a lost device and nil means that all devices found by the backend are lost
+	Start() (<-chan *Entity, error)
+	// Stop stops to find the devices
+	Stop()
+}
+
 // Entity provides wrapper entity info
 type Entity struct {
 	DeviceID          string
@@ -57,9 +74,11 @@ type Entity struct {
 // OrchestrationInformation provides orchestration info
 type OrchestrationInformation struct {
 	IPv4          []string
+	IPv6          []string
 	Platform      string
 	ExecutionType string
 	ServiceList   []string
+	Labels        map[string]string
 }
 
 // ZeroconfImpl struct
@@ -168,9 +187,25 @@ func convertServiceEntrytoDB(data *zeroconf.ServiceEntry) (newDevice Orchestrati
 	for _, val := range data.AddrIPv4 {
 		newDevice.IPv4 = append(newDevice.IPv4, val.String())
 	}
+	for _, val := range data.AddrIPv6 {
+		// @Note : link local address is not reachable without the zone of the interface
+		if val.IsLinkLocalUnicast() {
+			continue
+		}
+		newDevice.IPv6 = append(newDevice.IPv6, val.String())
+	}
 
 	for _, val := range data.Text {
-		if strings.Contains(val, "ExecType=") {
+		if strings.HasPrefix(val, LabelPrefix) {
+			label := strings.SplitN(strings.TrimPrefix(val, LabelPrefix), "=", 2)
+			if len(label) != 2 || len(label[0]) == 0 {
+				continue
+			}
+			if newDevice.Labels == nil {
+				newDevice.Labels = make(map[string]string)
+			}
+			newDevice.Labels[label[0]] = label[1]
+		} else if strings.Contains(val, "ExecType=") {
 			execType := strings.Split(val, "=")
 			newDevice.ExecutionType = execType[1]
 		} else if strings.Contains(val, "Platform=") {
@@ -0,0 +1,278 @@
+/*******************************************************************************
+* Copyright 2020 Samsung Electronics All Rights Reserved.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+* http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*
+*******************************************************************************/
+
+package scoringmgr
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+
+	errormsg "github.com/lf-edge/edge-home-orchestration-go/internal/common/errors"
+)
+
+const (
+	// DefaultPolicy is the name of the policy balancing network, cpu and round trip time
+	DefaultPolicy = "default"
+	// ResourcePolicy is the former scoringType value of the default policy, kept for the existing requesters
+	ResourcePolicy = "resource"
+	// CPUWeightedPolicy is the name of the policy preferring the device with powerful and idle cpu
+	CPUWeightedPolicy = "cpu"
+	// MemoryWeightedPolicy is the name of the policy preferring the device with much available memory
+	MemoryWeightedPolicy = "memory"
+	// LatencyFirstPolicy is the name of the policy preferring the device with short round trip time
+	LatencyFirstPolicy = "latency"
+	// WeightedLinearPolicy is the name of the policy summing every factor with the given weights
+	WeightedLinearPolicy = "weighted"
+)
+
+const (
+	// FactorCPU is the weight key of the idle cpu capacity score
+	FactorCPU = "cpu"
+	// FactorMemory is the weight key of the memory score
+	FactorMemory = "memory"
+	// FactorNetwork is the weight key of the network bandwidth score
+	FactorNetwork = "network"
+	// FactorRTT is the weight key of the round trip time score
+	FactorRTT = "rtt"
+)
+
+// ScoringPolicy is the interface to calculate the score of a device from the resource values given by GetResource
+type ScoringPolicy interface {
+	Score(resource map[string]interface{}) (scoreValue float64, err error)
+}
+
+// defaultFormula is the policy balancing network, cpu, round trip time and available memory
+type defaultFormula struct{}
+
+// WeightedLinear is the policy summing the score of each factor multiplied by its weight
+type WeightedLinear struct {
+	Network float64
+	CPU     float64
+	RTT     float64
+	Memory  float64
+}
+
+type policyRegistry struct {
+	sync.RWMutex
+	items map[string]ScoringPolicy
+}
+
+var policies = policyRegistry{items: make(map[string]ScoringPolicy)}
+
+func init() {
+	policies.items[DefaultPolicy] = defaultFormula{}
+	policies.items[ResourcePolicy] = defaultFormula{}
+	policies.items[CPUWeightedPolicy] = WeightedLinear{Network: 0.5, CPU: 2, RTT: 0.5, Memory: 0.5}
+	policies.items[MemoryWeightedPolicy] = WeightedLinear{Network: 0.5, CPU: 0.5, RTT: 0.5, Memory: 2}
+	policies.items[LatencyFirstPolicy] = WeightedLinear{Network: 1, CPU: 0.25, RTT: 4, Memory: 0.25}
+	policies.items[WeightedLinearPolicy] = WeightedLinear{Network: 1, CPU: 1, RTT: 1, Memory: 1}
+}
+
+// RegisterPolicy adds the scoring policy which can be selected by name
+func RegisterPolicy(name string, policy ScoringPolicy) error {
+	if len(name) == 0 || policy == nil {
+		return errormsg.InvalidParam{Message: "empty scoring policy"}
+	}
+
+	policies.Lock()
+	defer policies.Unlock()
+
+	if _, exists := policies.items[name]; exists {
+		return errormsg.InvalidParam{Message: "scoring policy " + name + " is already registered"}
+	}
+	policies.items[name] = policy
+	return nil
+}
+
+// GetPolicy returns the scoring policy registered with the name
+func GetPolicy(name string) (ScoringPolicy, error) {
+	policies.RLock()
+	defer policies.RUnlock()
+
+	policy, exists := policies.items[name]
+	if !exists {
+		return nil, errormsg.NotFound{Message: "scoring policy " + name + " is not registered"}
+	}
+	return policy, nil
+}
+
+// GetPolicyNames returns the names of the registered scoring policies
+func GetPolicyNames() []string {
+	policies.RLock()
+	defer policies.RUnlock()
+
+	names := make([]string, 0, len(policies.items))
+	for name := range policies.items {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewWeightedLinear creates the weighted linear policy with the weights keyed by factor name
+func NewWeightedLinear(weights map[string]float64) (ScoringPolicy, error) {
+	policy := WeightedLinear{}
+	for factor, weight := range weights {
+		if weight < 0 {
+			return nil, errormsg.InvalidParam{Message: "negative weight of " + factor}
+		}
+		switch factor {
+		case FactorCPU:
+			policy.CPU = weight
+		case FactorMemory:
+			policy.Memory = weight
+		case FactorNetwork:
+			policy.Network = weight
+		case FactorRTT:
+			policy.RTT = weight
+		default:
+			return nil, errormsg.InvalidParam{Message: "unknown scoring factor " + factor}
+		}
+	}
+	if policy.CPU+policy.Memory+policy.Network+policy.RTT == 0 {
+		return nil, errormsg.InvalidParam{Message: "every weight is zero"}
+	}
+	return policy, nil
+}
+
+// Score provides the sum of the network score, the half of the cpu score and the rendering score.
+// Memory is left out so that the devices not reporting it keep the same score scale, the other policies weigh it.
+func (defaultFormula) Score(resource map[string]interface{}) (scoreValue float64, err error) {
+	if _, found := resource["error"]; found {
+		return InvalidScore, errors.New("resource Not Found")
+	}
+
+	cpu, err := factorScore(FactorCPU, resource)
+	if err != nil {
+		return InvalidScore, err
+	}
+	net, err := factorScore(FactorNetwork, resource)
+	if err != nil {
+		return InvalidScore, err
+	}
+	rendering, err := factorScore(FactorRTT, resource)
+	if err != nil {
+		return InvalidScore, err
+	}
+	return float64(net + (cpu / 2) + rendering), nil
+}
+
+// Score provides the weighted sum of the factor scores, a factor with zero weight does not need its resource values
+func (p WeightedLinear) Score(resource map[string]interface{}) (scoreValue float64, err error) {
+	if _, found := resource["error"]; found {
+		return InvalidScore, errors.New("resource Not Found")
+	}
+
+	factors := []struct {
+		name   string
+		weight float64
+	}{
+		{FactorNetwork, p.Network},
+		{FactorCPU, p.CPU},
+		{FactorRTT, p.RTT},
+		{FactorMemory, p.Memory},
+	}
+	for _, factor := range factors {
+		if factor.weight == 0 {
+			continue
+		}
+
+		var score float64
+		if factor.name == FactorCPU {
+			score, err = idleCPUScore(resource)
+		} else {
+			score, err = factorScore(factor.name, resource)
+		}
+		if err != nil {
+			return InvalidScore, err
+		}
+		scoreValue += factor.weight * score
+	}
+
+	return scoreValue, nil
+}
+
+func factorScore(factor string, resource map[string]interface{}) (float64, error) {
+	switch factor {
+	case FactorCPU:
+		usage, err := getValue(resource, "cpuUsage")
+		if err != nil {
+			return InvalidScore, err
+		}
+		count, err := getValue(resource, "cpuCount")
+		if err != nil {
+			return InvalidScore, err
+		}
+		freq, err := getValue(resource, "cpuFreq")
+		if err != nil {
+			return InvalidScore, err
+		}
+		return cpuScore(usage, count, freq), nil
+	case FactorMemory:
+		available, err := getValue(resource, "memAvailable")
+		if err != nil {
+			return InvalidScore, err
+		}
+		return memoryScore(available), nil
+	case FactorNetwork:
+		bandwidth, err := getValue(resource, "netBandwidth")
+		if err != nil {
+			return InvalidScore, err
+		}
+		return netScore(bandwidth), nil
+	case FactorRTT:
+		rtt, err := getValue(resource, "rtt")
+		if err != nil {
+			return InvalidScore, err
+		}
+		return renderingScore(rtt), nil
+	}
+	return InvalidScore, errormsg.InvalidParam{Message: "unknown scoring factor " + factor}
+}
+
+// idleCPUScore scores the idle cpu capacity, 4 cores of 1GHz left idle score 0.5
+func idleCPUScore(resource map[string]interface{}) (float64, error) {
+	usage, err := getValue(resource, "cpuUsage")
+	if err != nil {
+		return InvalidScore, err
+	}
+	count, err := getValue(resource, "cpuCount")
+	if err != nil {
+		return InvalidScore, err
+	}
+	freq, err := getValue(resource, "cpuFreq")
+	if err != nil {
+		return InvalidScore, err
+	}
+
+	idle := count * freq * (100 - math.Min(math.Max(usage, 0), 100)) / 100
+	if idle <= 0 {
+		return 0, nil
+	}
+	return idle / (idle + 4000), nil
+}
+
+func getValue(resource map[string]interface{}, name string) (float64, error) {
+	value, ok := resource[name].(float64)
+	if !ok {
+		return InvalidScore, fmt.Errorf("resource %s is not found", name)
+	}
+	return value, nil
+}
@@ -0,0 +1,186 @@
+/*******************************************************************************
+* Copyright 2020 Samsung Electronics All Rights Reserved.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+* http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*
+*******************************************************************************/
+
+package scoringmgr
+
+import (
+	"testing"
+)
+
+type constantPolicy float64
+
+func (p constantPolicy) Score(resource map[string]interface{}) (float64, error) {
+	return float64(p), nil
+}
+
+func getDummyResource() map[string]interface{} {
+	resource := make(map[string]interface{})
+	resource["cpuUsage"] = 10.0
+	resource["cpuCount"] = 10.0
+	resource["cpuFreq"] = 10.0
+	resource["memAvailable"] = 10.0
+	resource["memFree"] = 10.0
+	resource["memTotal"] = 10.0
+	resource["netBandwidth"] = 10.0
+	resource["rtt"] = 10.0
+	return resource
+}
+
+func TestDefaultPolicy(t *testing.T) {
+	score, err := GetScoreWithPolicy(DefaultPolicy, getDummyResource())
+	if err != nil {
+		t.Error(unexpectedFail, err.Error())
+	} else if score != expectedScore {
+		t.Error(unexpectedFail, "score : ", score, " expectedScore : ", expectedScore)
+	}
+
+	resourceScore, err := GetScoreWithPolicy(ResourcePolicy, getDummyResource())
+	if err != nil || resourceScore != score {
+		t.Error(unexpectedFail, "resource policy is different from the default policy")
+	}
+
+	reporting, notReporting := getDummyResource(), getDummyResource()
+	reporting["memAvailable"] = 4.0 * 1024 * 1024
+	delete(notReporting, "memAvailable")
+	reportingScore, err := GetScoreWithPolicy(DefaultPolicy, reporting)
+	if err != nil {
+		t.Error(unexpectedFail, err.Error())
+	}
+	notReportingScore, err := GetScoreWithPolicy(DefaultPolicy, notReporting)
+	if err != nil {
+		t.Error(unexpectedFail, err.Error())
+	} else if notReportingScore != reportingScore {
+		t.Error(unexpectedFail, "score without memory : ", notReportingScore, " score with memory : ", reportingScore)
+	}
+}
+
+func TestBuiltinPolicies(t *testing.T) {
+	for _, name := range []string{CPUWeightedPolicy, MemoryWeightedPolicy, LatencyFirstPolicy, WeightedLinearPolicy} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := GetScoreWithPolicy(name, getDummyResource()); err != nil {
+				t.Error(unexpectedFail, err.Error())
+			}
+		})
+	}
+
+	t.Run("Preference", func(t *testing.T) {
+		idle, busy := getDummyResource(), getDummyResource()
+		busy["cpuUsage"] = 90.0
+		idle["rtt"] = 0.5
+
+		idleScore, _ := GetScoreWithPolicy(CPUWeightedPolicy, idle)
+		busyScore, _ := GetScoreWithPolicy(CPUWeightedPolicy, busy)
+		if idleScore <= busyScore {
+			t.Error(unexpectedFail, "cpu weighted policy prefers the busy device")
+		}
+
+		small, large := getDummyResource(), getDummyResource()
+		large["memAvailable"] = 4.0 * 1024 * 1024
+
+		smallScore, _ := GetScoreWithPolicy(MemoryWeightedPolicy, small)
+		largeScore, _ := GetScoreWithPolicy(MemoryWeightedPolicy, large)
+		if smallScore >= largeScore {
+			t.Error(unexpectedFail, "memory weighted policy prefers the device with less memory")
+		}
+	})
+	t.Run("MissingResource", func(t *testing.T) {
+		resource := getDummyResource()
+		delete(resource, "memAvailable")
+
+		if _, err := GetScoreWithPolicy(MemoryWeightedPolicy, resource); err == nil {
+			t.Error(unexpectedSuccess)
+		}
+		if _, err := GetScoreWithPolicy(DefaultPolicy, resource); err != nil {
+			t.Error(unexpectedFail, err.Error())
+		}
+	})
+	t.Run("MemoryPreference", func(t *testing.T) {
+		small, large := getDummyResource(), getDummyResource()
+		large["memAvailable"] = 4.0 * 1024 * 1024
+
+		for _, name := range []string{CPUWeightedPolicy, LatencyFirstPolicy} {
+			smallScore, _ := GetScoreWithPolicy(name, small)
+			largeScore, _ := GetScoreWithPolicy(name, large)
+			if smallScore >= largeScore {
+				t.Error(unexpectedFail, name, "policy prefers the device with less memory")
+			}
+		}
+	})
+}
+
+func TestRegisterPolicy(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		if err := RegisterPolicy("constant", constantPolicy(3.0)); err != nil {
+			t.Error(unexpectedFail, err.Error())
+		}
+
+		score, err := GetScoreWithPolicy("constant", nil)
+		if err != nil || score != 3.0 {
+			t.Error(unexpectedFail, "score : ", score)
+		}
+
+		found := false
+		for _, name := range GetPolicyNames() {
+			if name == "constant" {
+				found = true
+			}
+		}
+		if !found {
+			t.Error(unexpectedFail, "registered policy is not listed")
+		}
+	})
+	t.Run("Fail", func(t *testing.T) {
+		if err := RegisterPolicy(DefaultPolicy, constantPolicy(1.0)); err == nil {
+			t.Error(unexpectedSuccess)
+		}
+		if err := RegisterPolicy("", constantPolicy(1.0)); err == nil {
+			t.Error(unexpectedSuccess)
+		}
+		if _, err := GetPolicy("unknown"); err == nil {
+			t.Error(unexpectedSuccess)
+		}
+	})
+}
+
+func TestNewWeightedLinear(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		policy, err := NewWeightedLinear(map[string]float64{FactorRTT: 1})
+		if err != nil {
+			t.Error(unexpectedFail, err.Error())
+			return
+		}
+
+		score, err := policy.Score(getDummyResource())
+		if err != nil {
+			t.Error(unexpectedFail, err.Error())
+		} else if score != renderingScore(10.0) {
+			t.Error(unexpectedFail, "score : ", score)
+		}
+	})
+	t.Run("Fail", func(t *testing.T) {
+		invalidWeights := []map[string]float64{
+			{"gpu": 1},
+			{FactorCPU: -1},
+			{FactorCPU: 0},
+		}
+		for _, weights := range invalidWeights {
+			if _, err := NewWeightedLinear(weights); err == nil {
+				t.Error(unexpectedSuccess, weights)
+			}
+		}
+	})
+}
@@ -19,9 +19,10 @@
 package scoringmgr
 
 import (
-	"errors"
 	"math"
+	"runtime"
 
+	"github.com/lf-edge/edge-home-orchestration-go/internal/common/logmgr"
 	"github.com/lf-edge/edge-home-orchestration-go/internal/common/resourceutil"
 )
 
@@ -49,6 +50,8 @@ var (
 	scoringIns *ScoringImpl
 
 	resourceIns resourceutil.GetResource
+
+	log = logmgr.GetInstance()
 )
 
 func init() {
@@ -91,6 +94,24 @@ func (ScoringImpl) GetResource(ID string) (resource map[string]interface{}, err
 	}
 	resource["cpuFreq"] = cpuFreq
 
+	// @Note : memory is left out for the devices not reporting it, the scoring skips it
+	memoryResources := []struct {
+		key  string
+		name string
+	}{
+		{"memAvailable", resourceutil.MemAvailable},
+		{"memFree", resourceutil.MemFree},
+		{"memTotal", resourceutil.MemTotal},
+	}
+	for _, memory := range memoryResources {
+		value, memErr := resourceIns.GetResource(memory.name)
+		if memErr != nil {
+			log.Println(logPrefix, "cannot read", memory.key, ":", memErr.Error())
+			continue
+		}
+		resource[memory.key] = value
+	}
+
 	netBandwidth, err := resourceIns.GetResource(resourceutil.NetBandwidth)
 	if err != nil {
 		resource["error"] = InvalidScore
@@ -105,51 +126,37 @@ func (ScoringImpl) GetResource(ID string) (resource map[string]interface{}, err
 		return
 	}
 	resource["rtt"] = rtt
+	resource["arch"] = runtime.GOARCH
 
 	return
 }
 
-// GetScoreWithResource provides score value of an edge device
+// GetScoreWithResource provides score value of an edge device with the default policy
 func (ScoringImpl) GetScoreWithResource(resource map[string]interface{}) (scoreValue float64, err error) {
-	if _, found := resource["error"]; found {
-		return InvalidScore, errors.New("resource Not Found")
-	}
-
-	cpuScore := cpuScore(resource["cpuUsage"].(float64), resource["cpuCount"].(float64), resource["cpuFreq"].(float64))
-	netScore := netScore(resource["netBandwidth"].(float64))
-	renderingScore := renderingScore(resource["rtt"].(float64))
-	return float64(netScore + (cpuScore / 2) + renderingScore), nil
+	return GetScoreWithPolicy(DefaultPolicy, resource)
 }
 
-func calculateScore(ID string) float64 {
-	cpuUsage, err := resourceIns.GetResource(resourceutil.CPUUsage)
-	if err != nil {
-		return InvalidScore
-	}
-	cpuCount, err := resourceIns.GetResource(resourceutil.CPUCount)
-	if err != nil {
-		return InvalidScore
-	}
-	cpuFreq, err := resourceIns.GetResource(resourceutil.CPUFreq)
+// GetScoreWithPolicy provides score value of an edge device with the scoring policy registered with the name
+func GetScoreWithPolicy(name string, resource map[string]interface{}) (scoreValue float64, err error) {
+	policy, err := GetPolicy(name)
 	if err != nil {
-		return InvalidScore
+		return InvalidScore, err
 	}
-	cpuScore := cpuScore(cpuUsage, cpuCount, cpuFreq)
+	return policy.Score(resource)
+}
 
-	netBandwidth, err := resourceIns.GetResource(resourceutil.NetBandwidth)
+func calculateScore(ID string) float64 {
+	resource, err := GetInstance().GetResource(ID)
 	if err != nil {
 		return InvalidScore
 	}
-	netScore := netScore(netBandwidth)
 
-	resourceIns.SetDeviceID(ID)
-	rtt, err := resourceIns.GetResource(resourceutil.NetRTT)
+	score, err := GetScoreWithPolicy(DefaultPolicy, resource)
 	if err != nil {
+		log.Println(logPrefix, "cannot calculate score :", err.Error())
 		return InvalidScore
 	}
-	renderingScore := renderingScore(rtt)
-
-	return float64(netScore + (cpuScore / 2) + renderingScore)
+	return score
 }
 
 func netScore(bandWidth float64) (score float64) {
@@ -162,6 +169,14 @@ func cpuScore(usage float64, count float64, freq float64) (score float64) {
 		(1 / (4 * math.Pow(count, -0.3)))) / 3
 }
 
+func memoryScore(available float64) (score float64) {
+	// 1 GiB of available memory (in KiB) scores 0.5 and the score approaches 1 as the memory grows
+	if available <= 0 {
+		return 0
+	}
+	return available / (available + 1024*1024)
+}
+
 func renderingScore(rtt float64) (score float64) {
 	if rtt <= 0 {
 		score = 0
@@ -19,6 +19,7 @@ package scoringmgr
 
 import (
 	"errors"
+	"runtime"
 	"testing"
 
 	"github.com/lf-edge/edge-home-orchestration-go/internal/common/resourceutil"
@@ -48,6 +49,9 @@ func TestGetScore_ExpectedSuccess(t *testing.T) {
 			resourceutilMockObj.EXPECT().GetResource(resourceutil.CPUUsage).Return(10.0, nil),
 			resourceutilMockObj.EXPECT().GetResource(resourceutil.CPUCount).Return(10.0, nil),
 			resourceutilMockObj.EXPECT().GetResource(resourceutil.CPUFreq).Return(10.0, nil),
+			resourceutilMockObj.EXPECT().GetResource(resourceutil.MemAvailable).Return(10.0, nil),
+			resourceutilMockObj.EXPECT().GetResource(resourceutil.MemFree).Return(10.0, nil),
+			resourceutilMockObj.EXPECT().GetResource(resourceutil.MemTotal).Return(10.0, nil),
 			resourceutilMockObj.EXPECT().GetResource(resourceutil.NetBandwidth).Return(10.0, nil),
 			resourceutilMockObj.EXPECT().SetDeviceID(dummyDevID),
 			resourceutilMockObj.EXPECT().GetResource(resourceutil.NetRTT).Return(10.0, nil),
@@ -75,14 +79,47 @@ func TestGetResource(t *testing.T) {
 			resourceutilMockObj.EXPECT().GetResource(resourceutil.CPUUsage).Return(10.0, nil),
 			resourceutilMockObj.EXPECT().GetResource(resourceutil.CPUCount).Return(10.0, nil),
 			resourceutilMockObj.EXPECT().GetResource(resourceutil.CPUFreq).Return(10.0, nil),
+			resourceutilMockObj.EXPECT().GetResource(resourceutil.MemAvailable).Return(10.0, nil),
+			resourceutilMockObj.EXPECT().GetResource(resourceutil.MemFree).Return(10.0, nil),
+			resourceutilMockObj.EXPECT().GetResource(resourceutil.MemTotal).Return(10.0, nil),
 			resourceutilMockObj.EXPECT().GetResource(resourceutil.NetBandwidth).Return(10.0, nil),
 			resourceutilMockObj.EXPECT().SetDeviceID(dummyDevID),
 			resourceutilMockObj.EXPECT().GetResource(resourceutil.NetRTT).Return(10.0, nil),
 		)
 		resourceIns = resourceutilMockObj
-		_, err := GetInstance().GetResource(dummyDevID)
+		resource, err := GetInstance().GetResource(dummyDevID)
 		if err != nil {
 			t.Error(unexpectedFail, err.Error())
+		} else if resource["arch"] != runtime.GOARCH {
+			t.Error(unexpectedFail, "arch : ", resource["arch"])
+		}
+	})
+	t.Run("MemoryNotReported", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		resourceutilMockObj := resourceUtilMock.NewMockGetResource(ctrl)
+
+		gomock.InOrder(
+			resourceutilMockObj.EXPECT().GetResource(resourceutil.CPUUsage).Return(10.0, nil),
+			resourceutilMockObj.EXPECT().GetResource(resourceutil.CPUCount).Return(10.0, nil),
+			resourceutilMockObj.EXPECT().GetResource(resourceutil.CPUFreq).Return(10.0, nil),
+			resourceutilMockObj.EXPECT().GetResource(resourceutil.MemAvailable).Return(0.0, errors.New("")),
+			resourceutilMockObj.EXPECT().GetResource(resourceutil.MemFree).Return(0.0, errors.New("")),
+			resourceutilMockObj.EXPECT().GetResource(resourceutil.MemTotal).Return(0.0, errors.New("")),
+			resourceutilMockObj.EXPECT().GetResource(resourceutil.NetBandwidth).Return(10.0, nil),
+			resourceutilMockObj.EXPECT().SetDeviceID(dummyDevID),
+			resourceutilMockObj.EXPECT().GetResource(resourceutil.NetRTT).Return(10.0, nil),
+		)
+		resourceIns = resourceutilMockObj
+		resource, err := GetInstance().GetResource(dummyDevID)
+		if err != nil {
+			t.Error(unexpectedFail, err.Error())
+		}
+		for _, key := range []string{"error", "memAvailable", "memFree", "memTotal"} {
+			if _, found := resource[key]; found {
+				t.Error(unexpectedFail, key, " : ", resource[key])
+			}
 		}
 	})
 	t.Run("Fail", func(t *testing.T) {
@@ -129,6 +166,9 @@ func TestGetResource(t *testing.T) {
 				resourceutilMockObj.EXPECT().GetResource(resourceutil.CPUUsage).Return(10.0, nil),
 				resourceutilMockObj.EXPECT().GetResource(resourceutil.CPUCount).Return(10.0, nil),
 				resourceutilMockObj.EXPECT().GetResource(resourceutil.CPUFreq).Return(10.0, nil),
+				resourceutilMockObj.EXPECT().GetResource(resourceutil.MemAvailable).Return(10.0, nil),
+				resourceutilMockObj.EXPECT().GetResource(resourceutil.MemFree).Return(10.0, nil),
+				resourceutilMockObj.EXPECT().GetResource(resourceutil.MemTotal).Return(10.0, nil),
 				resourceutilMockObj.EXPECT().GetResource(resourceutil.NetBandwidth).Return(10.0, errors.New("")),
 			)
 			resourceIns = resourceutilMockObj
@@ -142,6 +182,9 @@ func TestGetResource(t *testing.T) {
 				resourceutilMockObj.EXPECT().GetResource(resourceutil.CPUUsage).Return(10.0, nil),
 				resourceutilMockObj.EXPECT().GetResource(resourceutil.CPUCount).Return(10.0, nil),
 				resourceutilMockObj.EXPECT().GetResource(resourceutil.CPUFreq).Return(10.0, nil),
+				resourceutilMockObj.EXPECT().GetResource(resourceutil.MemAvailable).Return(10.0, nil),
+				resourceutilMockObj.EXPECT().GetResource(resourceutil.MemFree).Return(10.0, nil),
+				resourceutilMockObj.EXPECT().GetResource(resourceutil.MemTotal).Return(10.0, nil),
 				resourceutilMockObj.EXPECT().GetResource(resourceutil.NetBandwidth).Return(10.0, nil),
 				resourceutilMockObj.EXPECT().SetDeviceID(dummyDevID),
 				resourceutilMockObj.EXPECT().GetResource(resourceutil.NetRTT).Return(10.0, errors.New("")),
@@ -167,6 +210,32 @@ func TestCalculateScore(t *testing.T) {
 			resourceutilMockObj.EXPECT().GetResource(resourceutil.CPUUsage).Return(10.0, nil),
 			resourceutilMockObj.EXPECT().GetResource(resourceutil.CPUCount).Return(10.0, nil),
 			resourceutilMockObj.EXPECT().GetResource(resourceutil.CPUFreq).Return(10.0, nil),
+			resourceutilMockObj.EXPECT().GetResource(resourceutil.MemAvailable).Return(10.0, nil),
+			resourceutilMockObj.EXPECT().GetResource(resourceutil.MemFree).Return(10.0, nil),
+			resourceutilMockObj.EXPECT().GetResource(resourceutil.MemTotal).Return(10.0, nil),
+			resourceutilMockObj.EXPECT().GetResource(resourceutil.NetBandwidth).Return(10.0, nil),
+			resourceutilMockObj.EXPECT().SetDeviceID(dummyDevID),
+			resourceutilMockObj.EXPECT().GetResource(resourceutil.NetRTT).Return(10.0, nil),
+		)
+		resourceIns = resourceutilMockObj
+		score := calculateScore(dummyDevID)
+		if score != expectedScore {
+			t.Error(unexpectedFail, "score : ", score, " expectedScore : ", expectedScore)
+		}
+	})
+	t.Run("MemoryNotReported", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		resourceutilMockObj := resourceUtilMock.NewMockGetResource(ctrl)
+
+		gomock.InOrder(
+			resourceutilMockObj.EXPECT().GetResource(resourceutil.CPUUsage).Return(10.0, nil),
+			resourceutilMockObj.EXPECT().GetResource(resourceutil.CPUCount).Return(10.0, nil),
+			resourceutilMockObj.EXPECT().GetResource(resourceutil.CPUFreq).Return(10.0, nil),
+			resourceutilMockObj.EXPECT().GetResource(resourceutil.MemAvailable).Return(0.0, errors.New("")),
+			resourceutilMockObj.EXPECT().GetResource(resourceutil.MemFree).Return(0.0, errors.New("")),
+			resourceutilMockObj.EXPECT().GetResource(resourceutil.MemTotal).Return(0.0, errors.New("")),
 			resourceutilMockObj.EXPECT().GetResource(resourceutil.NetBandwidth).Return(10.0, nil),
 			resourceutilMockObj.EXPECT().SetDeviceID(dummyDevID),
 			resourceutilMockObj.EXPECT().GetResource(resourceutil.NetRTT).Return(10.0, nil),
@@ -221,6 +290,9 @@ func TestCalculateScore(t *testing.T) {
 				resourceutilMockObj.EXPECT().GetResource(resourceutil.CPUUsage).Return(10.0, nil),
 				resourceutilMockObj.EXPECT().GetResource(resourceutil.CPUCount).Return(10.0, nil),
 				resourceutilMockObj.EXPECT().GetResource(resourceutil.CPUFreq).Return(10.0, nil),
+				resourceutilMockObj.EXPECT().GetResource(resourceutil.MemAvailable).Return(10.0, nil),
+				resourceutilMockObj.EXPECT().GetResource(resourceutil.MemFree).Return(10.0, nil),
+				resourceutilMockObj.EXPECT().GetResource(resourceutil.MemTotal).Return(10.0, nil),
 				resourceutilMockObj.EXPECT().GetResource(resourceutil.NetBandwidth).Return(10.0, errors.New("")),
 			)
 			resourceIns = resourceutilMockObj
@@ -234,6 +306,9 @@ func TestCalculateScore(t *testing.T) {
 				resourceutilMockObj.EXPECT().GetResource(resourceutil.CPUUsage).Return(10.0, nil),
 				resourceutilMockObj.EXPECT().GetResource(resourceutil.CPUCount).Return(10.0, nil),
 				resourceutilMockObj.EXPECT().GetResource(resourceutil.CPUFreq).Return(10.0, nil),
+				resourceutilMockObj.EXPECT().GetResource(resourceutil.MemAvailable).Return(10.0, nil),
+				resourceutilMockObj.EXPECT().GetResource(resourceutil.MemFree).Return(10.0, nil),
+				resourceutilMockObj.EXPECT().GetResource(resourceutil.MemTotal).Return(10.0, nil),
 				resourceutilMockObj.EXPECT().GetResource(resourceutil.NetBandwidth).Return(10.0, nil),
 				resourceutilMockObj.EXPECT().SetDeviceID(dummyDevID),
 				resourceutilMockObj.EXPECT().GetResource(resourceutil.NetRTT).Return(10.0, errors.New("")),
@@ -35,6 +35,7 @@ const (
 	rbacPolicyFileName = "policy.csv"
 	policyTemplate     = "p, admin, /*, *\n" +
 		"p, member, /api/v1/orchestration/services, *\n" +
+		"p, member, /api/v1/orchestration/services/*, *\n" +
 		"p, member, /api/v1/orchestration/cloudsyncmgr/publish, *\n"
 	rbacAuthModelFileName = "auth_model.conf"
 	authModelTemplate     = "[request_definition]\n" +
@@ -15,10 +15,12 @@
 *
 *******************************************************************************/
 
-// Package verifier ensures that only allowed containers (images) are launched
+// Package verifier ensures that only allowed containers (images) and WebAssembly modules are launched
 package verifier
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"os"
 	"strings"
@@ -148,6 +150,16 @@ func (VerificationImpl) ContainerIsInWhiteList(containerName string) error {
 	return containerHashIsInWhiteList(containerName[index:])
 }
 
+// ModuleIsInWhiteList checks if the sha256 hash of the WebAssembly module is in containerWhiteList,
+// the modules are whitelisted by the same commands as the containers
+func (VerificationImpl) ModuleIsInWhiteList(module []byte) error {
+	if !initialized {
+		return nil
+	}
+	hash := sha256.Sum256(module)
+	return containerHashIsInWhiteList(hex.EncodeToString(hash[:]))
+}
+
 // addHashToContainerWhiteList add the hash to containerWhiteList
 // if it exists then ignore this command
 func addHashToContainerWhiteList(hash string) error {
@@ -97,6 +97,24 @@ func TestContainerIsInWhiteList(t *testing.T) {
 	})
 }
 
+func TestModuleIsInWhiteList(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		module := []byte("\x00asm\x01\x00\x00\x00")
+		hashModule := "93a44bbb96c751218e4c00d479e4c14358122a389acca16205b1e4d0dc5f9476"
+
+		m := GetInstance()
+		initialized = true
+		if err := m.ModuleIsInWhiteList(module); err == nil {
+			t.Error(unexpectedSuccess)
+		}
+
+		containerWhiteList = append(containerWhiteList, hashModule)
+		if err := m.ModuleIsInWhiteList(module); err != nil {
+			t.Error(unexpectedFail)
+		}
+	})
+}
+
 func TestInitContainerWhiteList(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		defer os.RemoveAll(fakecwlPath)
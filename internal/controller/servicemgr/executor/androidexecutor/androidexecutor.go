@@ -25,6 +25,7 @@ import (
 	"strings"
 	"sync"
 
+	errormsg "github.com/lf-edge/edge-home-orchestration-go/internal/common/errors"
 	"github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr"
 	"github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr/executor"
 	"github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr/notification"
@@ -82,7 +83,7 @@ func (t *AndroidExecutor) Execute(s executor.ServiceExecutionInfo) (err error) {
 	executeCh := make(chan error)
 	go func() {
 		status, _ := t.waitService(executeCh)
-		t.notifyServiceStatus(status)
+		t.notifyServiceStatus(status, result)
 		wait.Done()
 	}()
 
@@ -98,6 +99,11 @@ func (t *AndroidExecutor) Execute(s executor.ServiceExecutionInfo) (err error) {
 	return
 }
 
+// Stop is not supported because the java layer does not report the launched application
+func (t *AndroidExecutor) Stop(s executor.ServiceExecutionInfo) error {
+	return errormsg.NotSupport{Message: "stopping android service application"}
+}
+
 func (t AndroidExecutor) setService() (result int, err error) {
 	if len(t.ParamStr) < 1 {
 		err = errors.New("error: empty parameter")
@@ -147,6 +153,6 @@ func (t AndroidExecutor) waitService(executeCh <-chan error) (status string, e e
 	return
 }
 
-func (t AndroidExecutor) notifyServiceStatus(status string) {
-	t.NotiImplIns.InvokeNotification(t.NotificationTargetURL, float64(t.ServiceID), status)
+func (t AndroidExecutor) notifyServiceStatus(status string, exitCode int) {
+	t.NotiImplIns.InvokeNotification(t.NotificationTargetURL, float64(t.ServiceID), status, exitCode)
 }
@@ -70,7 +70,7 @@ func TestExecute(t *testing.T) {
 	tExecutor.executeCB = fakeExecuteCB{}
 
 	gomock.InOrder(
-		noti.EXPECT().InvokeNotification(gomock.Any(), gomock.Any(), gomock.Any()),
+		noti.EXPECT().InvokeNotification(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()),
 	)
 
 	s := executor.ServiceExecutionInfo{ServiceID: uint64(1), ServiceName: "ls_service", ParamStr: []string{"ls", "-ail"}, NotificationTargetURL: ""}
@@ -19,13 +19,17 @@ package containerexecutor
 
 import (
 	"context"
+	"encoding/json"
 	"io"
-	"os"
+	"time"
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/api/types/network"
 	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/jsonmessage"
 )
 
 // CEImpl is the interface implemented by container execution functions
@@ -35,13 +39,12 @@ type CEImpl interface {
 	Start(id string) error
 	Wait(id string, condition container.WaitCondition) (<-chan container.ContainerWaitOKBody, <-chan error)
 	Logs(id string) (io.ReadCloser, error)
-	ImagePull(image string) error
-
-	// @Note : When below api is need to implements, it will be opened
-	// PS() ([]types.Container, error)
-	// Stop(id string, timeout *time.Duration) error
-	// Events() (<-chan events.Message, <-chan error)
-	// ImageTag(source string, target string) error
+	ImagePull(image string, auth *types.AuthConfig, progress func(jsonmessage.JSONMessage)) error
+	ImageExists(image string) (bool, error)
+	Stop(id string, timeout *time.Duration) error
+	PS(labels map[string]string) ([]types.Container, error)
+	Events(ctx context.Context, labels map[string]string) (<-chan events.Message, <-chan error)
+	ImageTag(source string, target string) error
 }
 
 // CEDocker structure
@@ -58,6 +61,14 @@ func newCEDocker() (ceDocker *CEDocker) {
 	return
 }
 
+func newCEDockerWithHost(host string) (CEImpl, error) {
+	cli, err := client.NewClientWithOpts(client.FromEnv, client.WithHost(host))
+	if err != nil {
+		return nil, err
+	}
+	return &CEDocker{context.Background(), cli}, nil
+}
+
 // Create is to create container
 func (ce CEDocker) Create(conf *container.Config, hostConf *container.HostConfig, networkConf *network.NetworkingConfig) (resp container.ContainerCreateCreatedBody, err error) {
 	resp, err = ce.cli.ContainerCreate(ce.ctx, conf, hostConf, networkConf, nil, "")
@@ -84,41 +95,78 @@ func (ce CEDocker) Logs(id string) (io.ReadCloser, error) {
 	opts := types.ContainerLogsOptions{
 		ShowStdout: true,
 		ShowStderr: true,
-		Timestamps: true,
 		Follow:     true,
 	}
 	return ce.cli.ContainerLogs(ce.ctx, id, opts)
 }
 
-// ImagePull is to pull container images
-func (ce CEDocker) ImagePull(image string) (err error) {
-	reader, err := ce.cli.ImagePull(ce.ctx, image, types.ImagePullOptions{})
-	if err == nil {
-		io.Copy(os.Stdout, reader)
+// ImagePull is to pull container images with the credential of the registry, the progress is called with the pull messages
+func (ce CEDocker) ImagePull(image string, auth *types.AuthConfig, progress func(jsonmessage.JSONMessage)) (err error) {
+	pullOpts := types.ImagePullOptions{}
+	if auth != nil {
+		if pullOpts.RegistryAuth, err = encodeAuth(*auth); err != nil {
+			return
+		}
 	}
 
-	return
+	reader, err := ce.cli.ImagePull(ce.ctx, image, pullOpts)
+	if err != nil {
+		return
+	}
+	defer reader.Close()
+
+	decoder := json.NewDecoder(reader)
+	for {
+		var msg jsonmessage.JSONMessage
+		if err = decoder.Decode(&msg); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return
+		} else if msg.Error != nil {
+			return msg.Error
+		}
+
+		if progress != nil {
+			progress(msg)
+		}
+	}
+}
+
+// ImageExists is to check whether the image is present
+func (ce CEDocker) ImageExists(image string) (bool, error) {
+	_, _, err := ce.cli.ImageInspectWithRaw(ce.ctx, image)
+	if client.IsErrNotFound(err) {
+		return false, nil
+	}
+	return err == nil, err
 }
 
-// PS function
-// func (ce CEDocker) PS() ([]types.Container, error) {
-// 	return ce.cli.ContainerList(ce.ctx, types.ContainerListOptions{})
-// }
-
-// Stop function
-// func (ce CEDocker) Stop(id string, timeout *time.Duration) (err error) {
-// 	return ce.cli.ContainerStop(ce.ctx, id, timeout)
-// }
-
-// Events function
-// func (ce CEDocker) Events() (<-chan events.Message, <-chan error) {
-// 	return ce.cli.Events(ce.ctx, types.EventsOptions{})
-// }// Events function
-// func (ce CEDocker) Events() (<-chan events.Message, <-chan error) {
-// 	return ce.cli.Events(ce.ctx, types.EventsOptions{})
-// }
-
-// ImageTag function
-// func (ce CEDocker) ImageTag(source string, target string) (err error) {
-// 	return ce.cli.ImageTag(ce.ctx, source, target)
-// }
+// PS is to list containers having every label, including the stopped ones
+func (ce CEDocker) PS(labels map[string]string) ([]types.Container, error) {
+	return ce.cli.ContainerList(ce.ctx, types.ContainerListOptions{All: true, Filters: labelFilters(labels)})
+}
+
+// Stop is to stop container
+func (ce CEDocker) Stop(id string, timeout *time.Duration) (err error) {
+	return ce.cli.ContainerStop(ce.ctx, id, timeout)
+}
+
+// Events is to receive the events of containers having every label until ctx is done
+func (ce CEDocker) Events(ctx context.Context, labels map[string]string) (<-chan events.Message, <-chan error) {
+	args := labelFilters(labels)
+	args.Add("type", events.ContainerEventType)
+	return ce.cli.Events(ctx, types.EventsOptions{Filters: args})
+}
+
+// ImageTag is to tag the source image with the target name
+func (ce CEDocker) ImageTag(source string, target string) (err error) {
+	return ce.cli.ImageTag(ce.ctx, source, target)
+}
+
+func labelFilters(labels map[string]string) filters.Args {
+	args := filters.NewArgs()
+	for key, value := range labels {
+		args.Add("label", key+"="+value)
+	}
+	return args
+}
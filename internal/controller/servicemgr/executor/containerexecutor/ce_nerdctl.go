@@ -0,0 +1,631 @@
+/*******************************************************************************
+* Copyright 2020 Samsung Electronics All Rights Reserved.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+* http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*
+*******************************************************************************/
+
+package containerexecutor
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/pkg/jsonmessage"
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+const (
+	nerdctlBinary     = "nerdctl"
+	containerdDefault = "default"
+)
+
+// CENerdctl structure runs the containers on containerd with nerdctl, the docker-run compatible
+// CLI of containerd. The nerdctl binary has to be installed in the PATH of the orchestration.
+type CENerdctl struct {
+	binary    string
+	namespace string
+	address   string
+	command   func(name string, args ...string) *exec.Cmd
+}
+
+// newCENerdctl returns the CENerdctl using the containerd socket of address,
+// the default socket of nerdctl is used when address is empty
+func newCENerdctl(address string) (CEImpl, error) {
+	binary, err := exec.LookPath(nerdctlBinary)
+	if err != nil {
+		return nil, err
+	}
+	return &CENerdctl{
+		binary:    binary,
+		namespace: containerdDefault,
+		address:   address,
+		command:   exec.Command,
+	}, nil
+}
+
+// Create is to create container
+func (ce CENerdctl) Create(conf *container.Config, hostConf *container.HostConfig, networkConf *network.NetworkingConfig) (resp container.ContainerCreateCreatedBody, err error) {
+	out, err := ce.output(append([]string{"create"}, createArgs(conf, hostConf, networkConf)...)...)
+	if err != nil {
+		return
+	}
+	resp.ID = strings.TrimSpace(out)
+	if len(resp.ID) == 0 {
+		err = errors.New("empty container id")
+	}
+	return
+}
+
+// Remove is to remove container
+func (ce CENerdctl) Remove(id string) (err error) {
+	_, err = ce.output("rm", id)
+	return
+}
+
+// Start is to start container
+func (ce CENerdctl) Start(id string) (err error) {
+	_, err = ce.output("start", id)
+	return
+}
+
+// Wait is to wait container
+func (ce CENerdctl) Wait(id string, condition container.WaitCondition) (<-chan container.ContainerWaitOKBody, <-chan error) {
+	statusCh := make(chan container.ContainerWaitOKBody, 1)
+	errCh := make(chan error, 1)
+
+	go func() {
+		out, err := ce.output("wait", id)
+		if err != nil {
+			errCh <- err
+			return
+		}
+		code, err := strconv.ParseInt(strings.TrimSpace(out), 10, 64)
+		if err != nil {
+			errCh <- err
+			return
+		}
+		statusCh <- container.ContainerWaitOKBody{StatusCode: code}
+	}()
+
+	return statusCh, errCh
+}
+
+// Logs is to logs container, the output is multiplexed like the docker logs
+func (ce CENerdctl) Logs(id string) (io.ReadCloser, error) {
+	reader, writer := io.Pipe()
+
+	cmd := ce.cmd("logs", "--follow", id)
+	cmd.Stdout = stdcopy.NewStdWriter(writer, stdcopy.Stdout)
+	cmd.Stderr = stdcopy.NewStdWriter(writer, stdcopy.Stderr)
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	go func() {
+		writer.CloseWithError(cmd.Wait())
+	}()
+	return reader, nil
+}
+
+// ImagePull is to pull container images, nerdctl does not report the progress of the layers
+func (ce CENerdctl) ImagePull(image string, auth *types.AuthConfig, progress func(jsonmessage.JSONMessage)) (err error) {
+	cmd := ce.cmd("pull", "--quiet", image)
+	if auth != nil {
+		configDir, err := writeDockerConfig(*auth)
+		if err != nil {
+			return err
+		}
+		defer os.RemoveAll(configDir)
+		cmd.Env = append(os.Environ(), "DOCKER_CONFIG="+configDir)
+	}
+
+	if progress != nil {
+		progress(jsonmessage.JSONMessage{Status: "Pulling from " + image})
+	}
+	if err = runCommand(cmd); err != nil {
+		return
+	}
+	if progress != nil {
+		progress(jsonmessage.JSONMessage{Status: "Downloaded image for " + image})
+	}
+	return
+}
+
+// ImageExists is to check whether the image is present
+func (ce CENerdctl) ImageExists(image string) (bool, error) {
+	if _, err := ce.output("image", "inspect", image); err != nil {
+		return false, nil
+	}
+	return true, nil
+}
+
+// Stop is to stop container
+func (ce CENerdctl) Stop(id string, timeout *time.Duration) (err error) {
+	args := []string{"stop"}
+	if timeout != nil {
+		args = append(args, "--time", strconv.Itoa(int(timeout.Seconds())))
+	}
+	_, err = ce.output(append(args, id)...)
+	return
+}
+
+// PS is to list containers having every label, including the stopped ones
+func (ce CENerdctl) PS(labels map[string]string) ([]types.Container, error) {
+	args := []string{"ps", "--all", "--no-trunc", "--format", "{{json .}}"}
+	for _, label := range joinMap(labels) {
+		args = append(args, "--filter", "label="+label)
+	}
+	out, err := ce.output(args...)
+	if err != nil {
+		return nil, err
+	}
+
+	containers := make([]types.Container, 0)
+	for _, line := range strings.Split(out, "\n") {
+		if len(strings.TrimSpace(line)) == 0 {
+			continue
+		}
+		var ps nerdctlContainer
+		if err := json.Unmarshal([]byte(line), &ps); err != nil {
+			return nil, err
+		}
+		c := ps.toContainer()
+		if hasLabels(c.Labels, labels) {
+			containers = append(containers, c)
+		}
+	}
+	return containers, nil
+}
+
+// Events is to receive the events of containers having every label until ctx is done,
+// the containerd task events are translated into the docker container events
+func (ce CENerdctl) Events(ctx context.Context, labels map[string]string) (<-chan events.Message, <-chan error) {
+	msgCh := make(chan events.Message)
+	errCh := make(chan error, 1)
+
+	cmd := ce.cmd("events", "--format", "{{json .}}")
+	stdout, err := cmd.StdoutPipe()
+	if err == nil {
+		err = cmd.Start()
+	}
+	if err != nil {
+		errCh <- err
+		return msgCh, errCh
+	}
+
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+			cmd.Process.Kill()
+		case <-done:
+		}
+	}()
+
+	go func() {
+		defer close(done)
+
+		owned := make(map[string]types.Container)
+		scanner := bufio.NewScanner(stdout)
+		for scanner.Scan() {
+			msg, ok := parseContainerdEvent(scanner.Bytes())
+			if !ok {
+				continue
+			}
+			c, ok := owned[msg.Actor.ID]
+			if !ok {
+				containers, err := ce.PS(labels)
+				if err != nil {
+					continue
+				}
+				for _, item := range containers {
+					owned[item.ID] = item
+				}
+				if c, ok = owned[msg.Actor.ID]; !ok {
+					continue
+				}
+			}
+			for key, value := range c.Labels {
+				msg.Actor.Attributes[key] = value
+			}
+			select {
+			case msgCh <- msg:
+			case <-ctx.Done():
+			}
+		}
+		err := cmd.Wait()
+		if ctx.Err() != nil {
+			err = ctx.Err()
+		} else if err == nil {
+			err = io.EOF
+		}
+		errCh <- err
+	}()
+
+	return msgCh, errCh
+}
+
+// ImageTag is to tag the source image with the target name
+func (ce CENerdctl) ImageTag(source string, target string) (err error) {
+	_, err = ce.output("tag", source, target)
+	return
+}
+
+func (ce CENerdctl) cmd(args ...string) *exec.Cmd {
+	global := []string{"--namespace", ce.namespace}
+	if len(ce.address) > 0 {
+		global = append(global, "--address", ce.address)
+	}
+	return ce.command(ce.binary, append(global, args...)...) // lgtm[go/command-injection]
+}
+
+func (ce CENerdctl) output(args ...string) (string, error) {
+	var stdout bytes.Buffer
+	cmd := ce.cmd(args...)
+	cmd.Stdout = &stdout
+	err := runCommand(cmd)
+	return stdout.String(), err
+}
+
+// nerdctlContainer is the container printed by nerdctl ps
+type nerdctlContainer struct {
+	ID     string
+	Image  string
+	Names  string
+	Status string
+	Labels string
+}
+
+func (ps nerdctlContainer) toContainer() types.Container {
+	c := types.Container{
+		ID:     ps.ID,
+		Image:  ps.Image,
+		Status: ps.Status,
+		Labels: make(map[string]string),
+	}
+	if len(ps.Names) > 0 {
+		c.Names = strings.Split(ps.Names, ",")
+	}
+	for _, label := range strings.Split(ps.Labels, ",") {
+		if kv := strings.SplitN(label, "=", 2); len(kv) == 2 {
+			c.Labels[kv[0]] = kv[1]
+		}
+	}
+	switch {
+	case strings.HasPrefix(ps.Status, "Up"):
+		c.State = "running"
+	case strings.HasPrefix(ps.Status, "Exited"):
+		c.State = "exited"
+	default:
+		c.State = strings.ToLower(ps.Status)
+	}
+	return c
+}
+
+// containerdTopics maps the containerd event topics onto the docker container event actions
+var containerdTopics = map[string]string{
+	"/tasks/start":       "start",
+	"/tasks/exit":        "die",
+	"/tasks/oom":         "oom",
+	"/containers/delete": "destroy",
+}
+
+// parseContainerdEvent translates the containerd event printed by nerdctl events
+func parseContainerdEvent(data []byte) (msg events.Message, ok bool) {
+	var envelope struct {
+		Timestamp time.Time
+		Topic     string
+		Event     json.RawMessage
+	}
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return
+	}
+	action, ok := containerdTopics[envelope.Topic]
+	if !ok {
+		return
+	}
+
+	// @Note : nerdctl prints the event as the json string or the json object
+	event := []byte(envelope.Event)
+	var str string
+	if err := json.Unmarshal(event, &str); err == nil {
+		event = []byte(str)
+	}
+	var body struct {
+		ID          string `json:"id"`
+		ContainerID string `json:"container_id"`
+		ExitStatus  int    `json:"exit_status"`
+	}
+	if err := json.Unmarshal(event, &body); err != nil {
+		return msg, false
+	}
+	if len(body.ContainerID) == 0 {
+		body.ContainerID = body.ID
+	}
+
+	msg = events.Message{
+		Type:     events.ContainerEventType,
+		Action:   action,
+		Actor:    events.Actor{ID: body.ContainerID, Attributes: make(map[string]string)},
+		Time:     envelope.Timestamp.Unix(),
+		TimeNano: envelope.Timestamp.UnixNano(),
+	}
+	msg.ID, msg.Status = msg.Actor.ID, msg.Action
+	if action == "die" {
+		msg.Actor.Attributes["exitCode"] = strconv.Itoa(body.ExitStatus)
+	}
+	return msg, len(body.ContainerID) > 0
+}
+
+func hasLabels(labels, selector map[string]string) bool {
+	for key, value := range selector {
+		if labels[key] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// writeDockerConfig writes the credential to the config.json of a temporary directory for nerdctl
+func writeDockerConfig(auth types.AuthConfig) (string, error) {
+	dir, err := os.MkdirTemp("", "nerdctl-auth")
+	if err != nil {
+		return "", err
+	}
+
+	entry := map[string]string{"auth": base64.StdEncoding.EncodeToString([]byte(auth.Username + ":" + auth.Password))}
+	if len(auth.IdentityToken) > 0 {
+		entry["identitytoken"] = auth.IdentityToken
+	}
+	data, err := json.Marshal(map[string]interface{}{
+		"auths": map[string]interface{}{auth.ServerAddress: entry},
+	})
+	if err == nil {
+		err = os.WriteFile(filepath.Join(dir, "config.json"), data, 0600)
+	}
+	if err != nil {
+		os.RemoveAll(dir)
+		return "", err
+	}
+	return dir, nil
+}
+
+func runCommand(cmd *exec.Cmd) error {
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); len(msg) > 0 {
+			return fmt.Errorf("%s : %s", err.Error(), msg)
+		}
+		return err
+	}
+	return nil
+}
+
+// createArgs maps the configuration converted from the docker-run flags onto the nerdctl create arguments
+func createArgs(conf *container.Config, hostConf *container.HostConfig, networkConf *network.NetworkingConfig) (args []string) {
+	add := func(flag string, values ...string) {
+		for _, value := range values {
+			if len(value) > 0 {
+				args = append(args, flag, value)
+			}
+		}
+	}
+	set := func(flag string, on bool) {
+		if on {
+			args = append(args, flag)
+		}
+	}
+
+	add("--hostname", conf.Hostname)
+	add("--domainname", conf.Domainname)
+	add("--user", conf.User)
+	add("--workdir", conf.WorkingDir)
+	add("--stop-signal", conf.StopSignal)
+	if conf.StopTimeout != nil {
+		add("--stop-timeout", strconv.Itoa(*conf.StopTimeout))
+	}
+	set("--tty", conf.Tty)
+	set("--interactive", conf.OpenStdin)
+	add("--env", conf.Env...)
+	add("--label", joinMap(conf.Labels)...)
+	// @Note : the containerd events have no health status, so the health check is given to nerdctl
+	// but the services on nerdctl are never notified as Unhealthy
+	if health := conf.Healthcheck; health != nil && len(health.Test) > 1 {
+		switch health.Test[0] {
+		case "CMD-SHELL":
+			add("--health-cmd", health.Test[1])
+		case "CMD":
+			add("--health-cmd", shellJoin(health.Test[1:]))
+		}
+		if health.Interval > 0 {
+			add("--health-interval", health.Interval.String())
+		}
+		if health.Timeout > 0 {
+			add("--health-timeout", health.Timeout.String())
+		}
+		if health.StartPeriod > 0 {
+			add("--health-start-period", health.StartPeriod.String())
+		}
+		if health.Retries > 0 {
+			add("--health-retries", strconv.Itoa(health.Retries))
+		}
+	}
+	if len(conf.Entrypoint) > 0 {
+		args = append(args, "--entrypoint", conf.Entrypoint[0])
+	}
+
+	if hostConf != nil {
+		if !hostConf.NetworkMode.IsDefault() {
+			add("--network", string(hostConf.NetworkMode))
+		}
+		publish := make([]string, 0, len(hostConf.PortBindings))
+		for port, bindings := range hostConf.PortBindings {
+			for _, binding := range bindings {
+				ports := port.Port() + "/" + port.Proto()
+				if len(binding.HostPort) > 0 {
+					ports = binding.HostPort + ":" + ports
+				}
+				if len(binding.HostIP) > 0 {
+					ports = binding.HostIP + ":" + ports
+				}
+				publish = append(publish, ports)
+			}
+		}
+		sort.Strings(publish)
+		add("--publish", publish...)
+		add("--volume", hostConf.Binds...)
+		for _, m := range hostConf.Mounts {
+			add("--mount", mountArg(m))
+		}
+		add("--tmpfs", joinTmpfs(hostConf.Tmpfs)...)
+		add("--dns", hostConf.DNS...)
+		add("--dns-option", hostConf.DNSOptions...)
+		add("--dns-search", hostConf.DNSSearch...)
+		add("--add-host", hostConf.ExtraHosts...)
+		add("--group-add", hostConf.GroupAdd...)
+		add("--cap-add", hostConf.CapAdd...)
+		add("--cap-drop", hostConf.CapDrop...)
+		add("--security-opt", hostConf.SecurityOpt...)
+		add("--sysctl", joinMap(hostConf.Sysctls)...)
+		add("--ipc", string(hostConf.IpcMode))
+		add("--pid", string(hostConf.PidMode))
+		add("--uts", string(hostConf.UTSMode))
+		add("--runtime", hostConf.Runtime)
+		set("--privileged", hostConf.Privileged)
+		set("--read-only", hostConf.ReadonlyRootfs)
+		set("--rm", hostConf.AutoRemove)
+		set("--init", hostConf.Init != nil && *hostConf.Init)
+		if len(hostConf.RestartPolicy.Name) > 0 && !hostConf.RestartPolicy.IsNone() {
+			policy := hostConf.RestartPolicy.Name
+			if hostConf.RestartPolicy.IsOnFailure() && hostConf.RestartPolicy.MaximumRetryCount > 0 {
+				policy += ":" + strconv.Itoa(hostConf.RestartPolicy.MaximumRetryCount)
+			}
+			add("--restart", policy)
+		}
+		if hostConf.ShmSize > 0 {
+			add("--shm-size", strconv.FormatInt(hostConf.ShmSize, 10))
+		}
+
+		resources := hostConf.Resources
+		if resources.Memory > 0 {
+			add("--memory", strconv.FormatInt(resources.Memory, 10))
+		}
+		if resources.MemorySwap != 0 {
+			add("--memory-swap", strconv.FormatInt(resources.MemorySwap, 10))
+		}
+		if resources.NanoCPUs > 0 {
+			add("--cpus", strconv.FormatFloat(float64(resources.NanoCPUs)/1e9, 'f', -1, 64))
+		}
+		if resources.CPUShares > 0 {
+			add("--cpu-shares", strconv.FormatInt(resources.CPUShares, 10))
+		}
+		if resources.CPUQuota > 0 {
+			add("--cpu-quota", strconv.FormatInt(resources.CPUQuota, 10))
+		}
+		if resources.CPUPeriod > 0 {
+			add("--cpu-period", strconv.FormatInt(resources.CPUPeriod, 10))
+		}
+		add("--cpuset-cpus", resources.CpusetCpus)
+		add("--cpuset-mems", resources.CpusetMems)
+		if resources.PidsLimit != nil && *resources.PidsLimit > 0 {
+			add("--pids-limit", strconv.FormatInt(*resources.PidsLimit, 10))
+		}
+		for _, device := range resources.Devices {
+			add("--device", strings.TrimSuffix(strings.Join([]string{device.PathOnHost, device.PathInContainer, device.CgroupPermissions}, ":"), ":"))
+		}
+		for _, ulimit := range resources.Ulimits {
+			add("--ulimit", ulimit.String())
+		}
+	}
+
+	if networkConf != nil {
+		for _, endpoint := range networkConf.EndpointsConfig {
+			if endpoint == nil {
+				continue
+			}
+			add("--mac-address", endpoint.MacAddress)
+			if endpoint.IPAMConfig != nil {
+				add("--ip", endpoint.IPAMConfig.IPv4Address)
+				add("--ip6", endpoint.IPAMConfig.IPv6Address)
+			}
+		}
+	}
+
+	// @Note : nerdctl takes only the executable as the entrypoint, its arguments precede the command
+	args = append(args, conf.Image)
+	if len(conf.Entrypoint) > 1 {
+		args = append(args, conf.Entrypoint[1:]...)
+	}
+	return append(args, conf.Cmd...)
+}
+
+// shellJoin joins the arguments into a shell command line, quoting each argument
+func shellJoin(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		quoted[i] = "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+	}
+	return strings.Join(quoted, " ")
+}
+
+func mountArg(m mount.Mount) string {
+	fields := []string{"type=" + string(m.Type), "target=" + m.Target}
+	if len(m.Source) > 0 {
+		fields = append(fields, "source="+m.Source)
+	}
+	if m.ReadOnly {
+		fields = append(fields, "readonly")
+	}
+	return strings.Join(fields, ",")
+}
+
+func joinMap(m map[string]string) []string {
+	joined := make([]string, 0, len(m))
+	for key, value := range m {
+		joined = append(joined, key+"="+value)
+	}
+	sort.Strings(joined)
+	return joined
+}
+
+func joinTmpfs(m map[string]string) []string {
+	joined := make([]string, 0, len(m))
+	for path, options := range m {
+		if len(options) > 0 {
+			path += ":" + options
+		}
+		joined = append(joined, path)
+	}
+	sort.Strings(joined)
+	return joined
+}
@@ -0,0 +1,196 @@
+/*******************************************************************************
+ * Copyright 2020 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+
+package containerexecutor
+
+import (
+	"context"
+	"io"
+	"os"
+	"os/exec"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+func fakeNerdctl(output string, called *[]string) CENerdctl {
+	return CENerdctl{
+		binary:    nerdctlBinary,
+		namespace: containerdDefault,
+		address:   "/run/containerd/containerd.sock",
+		command: func(name string, args ...string) *exec.Cmd {
+			*called = append([]string{name}, args...)
+			return exec.Command("echo", output)
+		},
+	}
+}
+
+func TestCreateArgs(t *testing.T) {
+	conf, hostConf, networkConf := convertConfig([]string{"docker", "run",
+		"-e", "REQUEST=url", "-l", "app=hello", "-p", "8080:80", "-v", "/tmp:/tmp:ro",
+		"--network", "host", "--privileged", "-m", "64m", "--cpus", "0.5", "--restart", "on-failure:3",
+		"alpine"})
+	conf.Cmd = []string{"echo", "hello"}
+
+	expected := []string{
+		"--env", "REQUEST=url",
+		"--label", "app=hello",
+		"--network", "host",
+		"--publish", "8080:80/tcp",
+		"--volume", "/tmp:/tmp:ro",
+		"--privileged",
+		"--restart", "on-failure:3",
+		"--memory", "67108864",
+		"--cpus", "0.5",
+		"alpine", "echo", "hello",
+	}
+	if args := createArgs(conf, hostConf, networkConf); !reflect.DeepEqual(args, expected) {
+		t.Error("unexpected args", args)
+	}
+
+	conf = &container.Config{Image: "alpine", Entrypoint: []string{"sh", "-c"}, Cmd: []string{"echo hello"},
+		Healthcheck: &container.HealthConfig{Test: []string{"CMD", "cat", "/tmp/it's ok"}}}
+	expected = []string{"--health-cmd", `'cat' '/tmp/it'\''s ok'`, "--entrypoint", "sh", "alpine", "-c", "echo hello"}
+	if args := createArgs(conf, nil, nil); !reflect.DeepEqual(args, expected) {
+		t.Error("unexpected args", args)
+	}
+}
+
+func TestCENerdctl(t *testing.T) {
+	var called []string
+
+	t.Run("Create", func(t *testing.T) {
+		ce := fakeNerdctl(containerID, &called)
+		resp, err := ce.Create(&container.Config{Image: "alpine"}, nil, nil)
+		if err != nil || resp.ID != containerID {
+			t.Error("unexpected response", resp, err)
+		}
+		expected := []string{nerdctlBinary, "--namespace", containerdDefault, "--address", "/run/containerd/containerd.sock", "create", "alpine"}
+		if !reflect.DeepEqual(called, expected) {
+			t.Error("unexpected command", called)
+		}
+	})
+	t.Run("Stop", func(t *testing.T) {
+		ce := fakeNerdctl("", &called)
+		timeout := 10 * time.Second
+		if err := ce.Stop(containerID, &timeout); err != nil {
+			t.Error(err.Error())
+		}
+		if strings.Join(called[5:], " ") != "stop --time 10 "+containerID {
+			t.Error("unexpected command", called)
+		}
+	})
+	t.Run("Wait", func(t *testing.T) {
+		ce := fakeNerdctl("3", &called)
+		statusCh, errCh := ce.Wait(containerID, container.WaitConditionNotRunning)
+		select {
+		case status := <-statusCh:
+			if status.StatusCode != 3 {
+				t.Error("unexpected status", status)
+			}
+		case err := <-errCh:
+			t.Error(err.Error())
+		}
+	})
+	t.Run("Logs", func(t *testing.T) {
+		ce := fakeNerdctl("hello", &called)
+		out, err := ce.Logs(containerID)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		reader, writer := io.Pipe()
+		go func() {
+			_, err := stdcopy.StdCopy(writer, os.Stderr, out)
+			writer.CloseWithError(err)
+		}()
+		if logs, err := io.ReadAll(reader); err != nil || string(logs) != "hello\n" {
+			t.Error("unexpected logs", string(logs), err)
+		}
+	})
+	t.Run("EventsCanceled", func(t *testing.T) {
+		ce := fakeNerdctl("", &called)
+		ce.command = func(name string, args ...string) *exec.Cmd {
+			return exec.Command("sleep", "60")
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		_, errCh := ce.Events(ctx, map[string]string{LabelOwner: ownerName})
+		cancel()
+		select {
+		case err := <-errCh:
+			if err != context.Canceled {
+				t.Error("unexpected error", err)
+			}
+		case <-time.After(5 * time.Second):
+			t.Error("events command is not killed")
+		}
+	})
+	t.Run("Failed", func(t *testing.T) {
+		ce := fakeNerdctl("", &called)
+		ce.command = func(name string, args ...string) *exec.Cmd {
+			return exec.Command("sh", "-c", "echo no such container >&2; exit 1")
+		}
+		if err := ce.Remove(containerID); err == nil || !strings.Contains(err.Error(), "no such container") {
+			t.Error("unexpected error", err)
+		}
+	})
+}
+
+func TestParseContainerdEvent(t *testing.T) {
+	data := `{"Timestamp":"2020-01-02T03:04:05Z","Namespace":"default","Topic":"/tasks/exit",` +
+		`"Event":"{\"container_id\":\"` + containerID + `\",\"exit_status\":137}"}`
+	msg, ok := parseContainerdEvent([]byte(data))
+	if !ok || msg.Action != "die" || msg.Actor.ID != containerID || msg.Actor.Attributes["exitCode"] != "137" {
+		t.Error("unexpected event", msg)
+	}
+
+	data = `{"Topic":"/tasks/oom","Event":{"container_id":"` + containerID + `"}}`
+	if msg, ok = parseContainerdEvent([]byte(data)); !ok || msg.Action != "oom" {
+		t.Error("unexpected event", msg)
+	}
+
+	if _, ok = parseContainerdEvent([]byte(`{"Topic":"/images/create","Event":{}}`)); ok {
+		t.Error("unexpected success")
+	}
+}
+
+func TestNerdctlPS(t *testing.T) {
+	var called []string
+	ce := fakeNerdctl(`{"ID":"`+containerID+`","Image":"alpine","Names":"hello","Status":"Up 3 seconds","Labels":"`+LabelOwner+`=`+ownerName+`,app=hello"}`, &called)
+
+	list, err := ce.PS(map[string]string{LabelOwner: ownerName})
+	if err != nil || len(list) != 1 || list[0].ID != containerID || list[0].State != "running" || list[0].Labels["app"] != "hello" {
+		t.Error("unexpected containers", list, err)
+	}
+	if list, err = ce.PS(map[string]string{"app": "other"}); err != nil || len(list) != 0 {
+		t.Error("unexpected containers", list, err)
+	}
+}
+
+func TestNewCEImpl(t *testing.T) {
+	if _, err := NewCEImpl("unknown", ""); err == nil {
+		t.Error("unexpected success")
+	}
+	if ce, err := NewCEImpl(RuntimePodman, "unix:///tmp/podman.sock"); err != nil {
+		t.Error(err.Error())
+	} else if _, ok := ce.(*CEDocker); !ok {
+		t.Error("unexpected implementation", ce)
+	}
+}
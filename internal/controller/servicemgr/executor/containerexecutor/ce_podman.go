@@ -0,0 +1,55 @@
+/*******************************************************************************
+* Copyright 2020 Samsung Electronics All Rights Reserved.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+* http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*
+*******************************************************************************/
+
+package containerexecutor
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/docker/docker/client"
+)
+
+const podmanRootSocket = "unix:///run/podman/podman.sock"
+
+// newCEPodman returns the CEDocker connected to the Docker compatible REST socket of podman,
+// the socket of the current user is used for the rootless podman when endpoint is empty
+func newCEPodman(endpoint string) (CEImpl, error) {
+	if len(endpoint) == 0 {
+		endpoint = podmanSocket()
+	}
+
+	cli, err := client.NewClientWithOpts(client.WithHost(endpoint), client.WithAPIVersionNegotiation())
+	if err != nil {
+		return nil, err
+	}
+	return &CEDocker{context.Background(), cli}, nil
+}
+
+func podmanSocket() string {
+	uid := os.Geteuid()
+	if uid == 0 {
+		return podmanRootSocket
+	}
+
+	runtimeDir := os.Getenv("XDG_RUNTIME_DIR")
+	if len(runtimeDir) == 0 {
+		runtimeDir = fmt.Sprintf("/run/user/%d", uid)
+	}
+	return "unix://" + runtimeDir + "/podman/podman.sock"
+}
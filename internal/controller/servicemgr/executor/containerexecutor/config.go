@@ -123,6 +123,7 @@ type containerOptions struct {
 	ipcMode            string
 	pidsLimit          int64
 	restartPolicy      string
+	pull               string
 	readonlyRootfs     bool
 	loggingDriver      string
 	cgroupParent       string
@@ -199,6 +200,7 @@ func addFlags(flags *pflag.FlagSet) *containerOptions {
 	flags.Var(&copts.labelsFile, "label-file", "Read in a line delimited file of labels")
 	flags.BoolVar(&copts.readonlyRootfs, "read-only", false, "Mount the container's root filesystem as read only")
 	flags.StringVar(&copts.restartPolicy, "restart", "no", "Restart policy to apply when a container exits")
+	flags.StringVar(&copts.pull, "pull", PullAlways, "Pull image before running (\"always\"|\"missing\"|\"never\")")
 	flags.StringVar(&copts.stopSignal, "stop-signal", signal.DefaultStopSignal, "Signal to stop a container")
 	flags.IntVar(&copts.stopTimeout, "stop-timeout", 0, "Timeout (in seconds) to stop a container")
 	flags.SetAnnotation("stop-timeout", "version", []string{"1.25"})
@@ -19,12 +19,15 @@
 package containerexecutor
 
 import (
-	"os"
+	"fmt"
 	"runtime"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/lf-edge/edge-home-orchestration-go/internal/common/logmgr"
 
+	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/network"
 	"github.com/docker/docker/pkg/stdcopy"
@@ -34,12 +37,37 @@ import (
 	servicemgr "github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr"
 	"github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr/executor"
 	"github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr/notification"
+	"github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr/servicelog"
+)
+
+const (
+	// RuntimeDocker is the container runtime name of docker
+	RuntimeDocker = "docker"
+	// RuntimePodman is the container runtime name of podman
+	RuntimePodman = "podman"
+	// RuntimeNerdctl is the container runtime name of containerd driven by the nerdctl command
+	RuntimeNerdctl = "nerdctl"
+
+	// LabelOwner is the label of the containers started by the orchestration
+	LabelOwner = "org.lfedge.edge-orchestration.owner"
+	// LabelServiceID is the label of the service ID of the container
+	LabelServiceID = "org.lfedge.edge-orchestration.service-id"
+	// LabelServiceName is the label of the service name of the container
+	LabelServiceName = "org.lfedge.edge-orchestration.service-name"
+	// LabelNotificationTarget is the label of the device notified of the service status
+	LabelNotificationTarget = "org.lfedge.edge-orchestration.notification-target"
+
+	// DefaultStopTimeout is the time to wait for the container to stop before killing it
+	DefaultStopTimeout = 10 * time.Second
+
+	ownerName = "edge-orchestration"
 )
 
 var (
 	logPrefix         = "[containerexecutor]"
 	log               = logmgr.GetInstance()
 	containerExecutor *ContainerExecutor
+	running           = executor.NewRunningServices()
 )
 
 // ContainerExecutor struct
@@ -75,62 +103,126 @@ func (c *ContainerExecutor) Execute(s executor.ServiceExecutionInfo) error {
 	err := verifier.GetInstance().ContainerIsInWhiteList(c.ParamStr[paramLen-1])
 	if err != nil {
 		log.Println(logPrefix, err.Error())
+		c.notifyFailed(s)
 		return err
 	}
 
-	// @Note : Pull docker image
-	err = c.ceImplIns.ImagePull(c.ParamStr[paramLen-1])
+	// @Note : Pull docker image according to the pull policy,
+	// the image may be present already when the pull of the always policy fails
+	policy, err := getPullPolicy(c.ParamStr)
 	if err != nil {
 		log.Println(logPrefix, err.Error())
+		c.notifyFailed(s)
+		return err
+	}
+	pullErr := c.pullImage(c.ServiceExecutionInfo, c.ParamStr[paramLen-1], policy)
+	if pullErr != nil {
+		log.Println(logPrefix, pullErr.Error())
+		if policy != PullAlways {
+			c.notifyFailed(s)
+			return pullErr
+		}
 	}
 
 	// @Note : Create containers with converting configuration
-	resp, err := c.ceImplIns.Create(convertConfig(c.ParamStr))
+	conf, hostConf, networkConf := convertConfig(c.ParamStr)
+	applyLimits(conf, hostConf, c.Limits)
+	setOwnerLabels(conf, c.ServiceExecutionInfo)
+	resp, err := c.ceImplIns.Create(conf, hostConf, networkConf)
 	if err != nil {
 		log.Println(logPrefix, err.Error())
-	} else {
-		log.Println(logPrefix, "create container :", resp.ID[:10])
+		c.notifyFailed(s)
+		return err
 	}
+	log.Println(logPrefix, "create container :", resp.ID[:10])
 
 	// @Note : Start container
 	err = c.ceImplIns.Start(resp.ID)
 	if err != nil {
 		log.Println("err :", err)
+		c.notifyFailed(s)
+		if err := c.ceImplIns.Remove(resp.ID); err != nil {
+			log.Println(logPrefix, err.Error())
+		}
 		return err
 	}
+	if pullErr != nil {
+		// @Note : the failed pull did not notify the end of the pulling
+		c.NotiImplIns.InvokeNotification(s.NotificationTargetURL, float64(s.ServiceID), servicemgr.ConstServiceStatusStarted, 0)
+	}
 
-	// @Note : get log of container
+	containerID := resp.ID
+	stopTimeout := getStopTimeout(conf)
+	running.Add(s, func() error {
+		return c.ceImplIns.Stop(containerID, &stopTimeout)
+	})
+	states.add(containerID, s)
+
+	// @Note : capture log of container
 	out, err := c.ceImplIns.Logs(resp.ID)
 	if err != nil {
 		log.Println(logPrefix, err.Error())
 	} else {
-		stdcopy.StdCopy(os.Stdout, os.Stderr, out)
+		key := servicelog.Key(s.NotificationTargetURL, s.ServiceID)
+		stdout := servicelog.NewWriter(key, servicelog.StreamStdout)
+		stderr := servicelog.NewWriter(key, servicelog.StreamStderr)
+		stdcopy.StdCopy(stdout, stderr, out)
+		stdout.Close()
+		stderr.Close()
+		out.Close()
 	}
 
+	c.waitContainer(s, containerID)
+
+	return nil
+}
+
+// Stop stops the container of the running service application
+func (c *ContainerExecutor) Stop(s executor.ServiceExecutionInfo) error {
+	log.Println(logPrefix, "stop service", s.ServiceID)
+	return running.Stop(s)
+}
+
+// waitContainer waits for the container to stop, notifies the service status and removes the container
+func (c *ContainerExecutor) waitContainer(s executor.ServiceExecutionInfo, containerID string) {
 	// @Note : Waiting Container execution status
 	var executionStatus string
-	statusCh, errCh := c.ceImplIns.Wait(resp.ID, container.WaitConditionNotRunning)
+	exitCode := -1
+	statusCh, errCh := c.ceImplIns.Wait(containerID, container.WaitConditionNotRunning)
 	select {
-	case err = <-errCh:
+	case err := <-errCh:
 		log.Println(logPrefix, err.Error())
 		executionStatus = servicemgr.ConstServiceStatusFailed
 	case status := <-statusCh:
 		log.Println(logPrefix, "container execution status :", status.StatusCode)
+		exitCode = int(status.StatusCode)
 		if status.StatusCode == 0 {
 			executionStatus = servicemgr.ConstServiceStatusFinished
+		} else {
+			executionStatus = servicemgr.ConstServiceStatusFailed
 		}
 	}
 
+	if state := states.remove(containerID); state.oomKilled {
+		log.Println(logPrefix, logmgr.SanitizeUserInput(s.ServiceName), "is killed by out of memory") // lgtm [go/log-injection]
+		executionStatus = servicemgr.ConstServiceStatusFailed
+	}
+	if running.Remove(s) {
+		executionStatus = servicemgr.ConstServiceStatusStopped
+	}
+
 	// @Note : make notification
-	c.NotiImplIns.InvokeNotification(c.NotificationTargetURL, float64(c.ServiceID), executionStatus)
+	c.NotiImplIns.InvokeNotification(s.NotificationTargetURL, float64(s.ServiceID), executionStatus, exitCode)
 
 	// @Note : Remove container after execution
-	err = c.ceImplIns.Remove(resp.ID)
-	if err != nil {
+	if err := c.ceImplIns.Remove(containerID); err != nil {
 		log.Println(logPrefix, err.Error())
 	}
+}
 
-	return nil
+// notifyFailed notifies the service failed before its container runs
+func (c *ContainerExecutor) notifyFailed(s executor.ServiceExecutionInfo) {
+	c.NotiImplIns.InvokeNotification(s.NotificationTargetURL, float64(s.ServiceID), servicemgr.ConstServiceStatusFailed, -1)
 }
 
 // SetCEImpl sets executor implementation
@@ -138,9 +230,46 @@ func (c *ContainerExecutor) SetCEImpl(ce CEImpl) {
 	c.ceImplIns = ce
 }
 
+// ListContainers returns the containers started by the orchestration, including the stopped ones
+func (c *ContainerExecutor) ListContainers() ([]types.Container, error) {
+	return c.ceImplIns.PS(map[string]string{LabelOwner: ownerName})
+}
+
+// TagImage tags the source image with the target name
+func (c *ContainerExecutor) TagImage(source, target string) error {
+	return c.ceImplIns.ImageTag(source, target)
+}
+
+// NewCEImpl returns the executor implementation of the container runtime,
+// endpoint is the socket address of the runtime and the default one is used when it is empty
+func NewCEImpl(containerRuntime, endpoint string) (CEImpl, error) {
+	switch strings.ToLower(containerRuntime) {
+	case "", RuntimeDocker:
+		if len(endpoint) == 0 {
+			return newCEDocker(), nil
+		}
+		return newCEDockerWithHost(endpoint)
+	case RuntimePodman:
+		return newCEPodman(endpoint)
+	case RuntimeNerdctl:
+		return newCENerdctl(endpoint)
+	}
+	return nil, fmt.Errorf("unsupported container runtime : %s", containerRuntime)
+}
+
 func convertConfig(paramStr []string) (
 	containerConf *container.Config, hostConf *container.HostConfig, networkConf *network.NetworkingConfig) {
 
+	flags, copts := parseFlags(paramStr)
+
+	conf, _ := parse(flags, copts, runtime.GOOS)
+	conf.Config.Image = paramStr[len(paramStr)-1]
+
+	return conf.Config, conf.HostConfig, conf.NetworkingConfig
+}
+
+// parseFlags parses the docker-run flags between "docker run" and the image
+func parseFlags(paramStr []string) (*pflag.FlagSet, *containerOptions) {
 	// @Note : initialize getting docker run configurations
 	flags := pflag.NewFlagSet(" ", pflag.ContinueOnError)
 	copts := addFlags(flags)
@@ -149,10 +278,25 @@ func convertConfig(paramStr []string) (
 	param := paramStr[2 : paramLen-1]
 	flags.Parse(param)
 
-	conf, _ := parse(flags, copts, runtime.GOOS)
-	conf.Config.Image = paramStr[paramLen-1]
+	return flags, copts
+}
 
-	return conf.Config, conf.HostConfig, conf.NetworkingConfig
+// setOwnerLabels labels the container so that the orchestration can find the containers it started
+func setOwnerLabels(conf *container.Config, s executor.ServiceExecutionInfo) {
+	if conf.Labels == nil {
+		conf.Labels = make(map[string]string)
+	}
+	conf.Labels[LabelOwner] = ownerName
+	conf.Labels[LabelServiceID] = strconv.FormatUint(s.ServiceID, 10)
+	conf.Labels[LabelServiceName] = s.ServiceName
+	conf.Labels[LabelNotificationTarget] = s.NotificationTargetURL
+}
+
+func getStopTimeout(conf *container.Config) time.Duration {
+	if conf.StopTimeout != nil && *conf.StopTimeout > 0 {
+		return time.Duration(*conf.StopTimeout) * time.Second
+	}
+	return DefaultStopTimeout
 }
 
 func addRequestEnv(s executor.ServiceExecutionInfo) executor.ServiceExecutionInfo {
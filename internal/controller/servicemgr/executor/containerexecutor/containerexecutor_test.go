@@ -30,6 +30,7 @@ import (
 
 	"github.com/docker/docker/api/types/blkiodev"
 
+	servicemgr "github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr"
 	"github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr/executor"
 	"github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr/executor/containerexecutor/mocks"
 	notificationMock "github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr/notification/mocks"
@@ -40,6 +41,8 @@ import (
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/api/types/network"
 )
 
 var (
@@ -105,12 +108,12 @@ func TestExecute(t *testing.T) {
 	con, noti, _ := initializeMock(t)
 
 	gomock.InOrder(
-		con.EXPECT().ImagePull(gomock.Any()).Return(nil),
+		con.EXPECT().ImagePull(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil),
 		con.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).Return(resp, nil),
 		con.EXPECT().Start(containerID).Return(nil),
 		con.EXPECT().Logs(containerID).Return(readCloser, nil),
 		con.EXPECT().Wait(containerID, container.WaitConditionNotRunning).Return(statusChan, errCh),
-		noti.EXPECT().InvokeNotification(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes(),
+		noti.EXPECT().InvokeNotification(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes(),
 		con.EXPECT().Remove(containerID),
 	)
 
@@ -131,14 +134,67 @@ func TestExecute(t *testing.T) {
 	statusChan <- container.ContainerWaitOKBody{StatusCode: 0}
 	wait.Wait()
 }
+func TestStop(t *testing.T) {
+	cExecutor := GetInstance()
+	con, noti, _ := initializeMock(t)
+
+	started := make(chan bool, 1)
+	gomock.InOrder(
+		con.EXPECT().ImagePull(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil),
+		con.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).Return(resp, nil),
+		con.EXPECT().Start(containerID).Return(nil),
+		con.EXPECT().Logs(containerID).DoAndReturn(func(id string) (io.ReadCloser, error) {
+			started <- true
+			return io.NopCloser(strings.NewReader("")), nil
+		}),
+		con.EXPECT().Wait(containerID, container.WaitConditionNotRunning).Return(statusChan, errCh),
+		noti.EXPECT().InvokeNotification(gomock.Any(), float64(serviceInfo.ServiceID), servicemgr.ConstServiceStatusStopped, gomock.Any()),
+		con.EXPECT().Remove(containerID),
+	)
+
+	con.EXPECT().Stop(containerID, gomock.Any()).Return(nil)
+
+	cExecutor.SetCEImpl(con)
+	cExecutor.SetNotiImpl(noti)
+
+	var wait sync.WaitGroup
+	wait.Add(1)
+
+	go func() {
+		err := cExecutor.Execute(serviceInfo)
+		if err != nil {
+			t.Fail()
+		}
+		wait.Done()
+	}()
+
+	<-started
+	if err := cExecutor.Stop(serviceInfo); err != nil {
+		t.Error(err.Error())
+	}
+
+	statusChan <- container.ContainerWaitOKBody{StatusCode: 137}
+	wait.Wait()
+}
+
+func TestStopFailWithNotRunningService(t *testing.T) {
+	cExecutor := GetInstance()
+
+	if err := cExecutor.Stop(serviceInfo); err == nil {
+		t.Error("unexpected success")
+	}
+}
+
 func TestExecuteFailedStartInvokedError(t *testing.T) {
 	cExecutor := GetInstance()
 	con, noti, _ := initializeMock(t)
 
 	gomock.InOrder(
-		con.EXPECT().ImagePull(gomock.Any()).Return(nil),
+		con.EXPECT().ImagePull(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil),
 		con.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).Return(resp, nil),
 		con.EXPECT().Start(containerID).Return(errors.New("invoked error")),
+		noti.EXPECT().InvokeNotification(gomock.Any(), float64(serviceInfo.ServiceID), servicemgr.ConstServiceStatusFailed, -1),
+		con.EXPECT().Remove(containerID),
 	)
 
 	// cExecutor.SetClient(client)
@@ -160,17 +216,89 @@ func TestExecuteFailedStartInvokedError(t *testing.T) {
 	wait.Wait()
 }
 
+func TestExecuteFailedBeforeStart(t *testing.T) {
+	withParams := func(params ...string) executor.ServiceExecutionInfo {
+		s := serviceInfo
+		s.ParamStr = params
+		return s
+	}
+
+	t.Run("InvalidPullPolicy", func(t *testing.T) {
+		cExecutor := GetInstance()
+		con, noti, _ := initializeMock(t)
+
+		noti.EXPECT().InvokeNotification(gomock.Any(), float64(serviceInfo.ServiceID), servicemgr.ConstServiceStatusFailed, -1)
+
+		cExecutor.SetCEImpl(con)
+		cExecutor.SetNotiImpl(noti)
+
+		if err := cExecutor.Execute(withParams("docker", "run", "--pull", "sometimes", "alpine")); err == nil {
+			t.Error("unexpected success")
+		}
+	})
+	t.Run("ImageNotPresent", func(t *testing.T) {
+		cExecutor := GetInstance()
+		con, noti, _ := initializeMock(t)
+
+		gomock.InOrder(
+			con.EXPECT().ImageExists("alpine").Return(false, nil),
+			noti.EXPECT().InvokeNotification(gomock.Any(), float64(serviceInfo.ServiceID), servicemgr.ConstServiceStatusFailed, -1),
+		)
+
+		cExecutor.SetCEImpl(con)
+		cExecutor.SetNotiImpl(noti)
+
+		if err := cExecutor.Execute(withParams("docker", "run", "--pull", "never", "alpine")); err == nil {
+			t.Error("unexpected success")
+		}
+	})
+	t.Run("PullFailedIfNotPresent", func(t *testing.T) {
+		cExecutor := GetInstance()
+		con, noti, _ := initializeMock(t)
+
+		gomock.InOrder(
+			con.EXPECT().ImageExists("alpine").Return(false, nil),
+			con.EXPECT().ImagePull("alpine", gomock.Any(), gomock.Any()).Return(errors.New("pull error")),
+			noti.EXPECT().InvokeNotification(gomock.Any(), float64(serviceInfo.ServiceID), servicemgr.ConstServiceStatusFailed, -1),
+		)
+
+		cExecutor.SetCEImpl(con)
+		cExecutor.SetNotiImpl(noti)
+
+		if err := cExecutor.Execute(withParams("docker", "run", "--pull", "missing", "alpine")); err == nil {
+			t.Error("unexpected success")
+		}
+	})
+	t.Run("CreateFailed", func(t *testing.T) {
+		cExecutor := GetInstance()
+		con, noti, _ := initializeMock(t)
+
+		gomock.InOrder(
+			con.EXPECT().ImagePull(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("pull error")),
+			con.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).Return(container.ContainerCreateCreatedBody{}, errors.New("create error")),
+			noti.EXPECT().InvokeNotification(gomock.Any(), float64(serviceInfo.ServiceID), servicemgr.ConstServiceStatusFailed, -1),
+		)
+
+		cExecutor.SetCEImpl(con)
+		cExecutor.SetNotiImpl(noti)
+
+		if err := cExecutor.Execute(serviceInfo); err == nil {
+			t.Error("unexpected success")
+		}
+	})
+}
+
 func TestExecuteWaitInvokedError(t *testing.T) {
 	cExecutor := GetInstance()
 	con, noti, _ := initializeMock(t)
 
 	gomock.InOrder(
-		con.EXPECT().ImagePull(gomock.Any()).Return(nil),
+		con.EXPECT().ImagePull(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil),
 		con.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).Return(resp, nil),
 		con.EXPECT().Start(containerID).Return(nil),
 		con.EXPECT().Logs(containerID).Return(readCloser, nil),
 		con.EXPECT().Wait(containerID, container.WaitConditionNotRunning).Return(statusChan, errCh),
-		noti.EXPECT().InvokeNotification(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes(),
+		noti.EXPECT().InvokeNotification(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes(),
 		con.EXPECT().Remove(containerID),
 	)
 
@@ -192,6 +320,70 @@ func TestExecuteWaitInvokedError(t *testing.T) {
 	wait.Wait()
 }
 
+func TestExecuteOutOfMemory(t *testing.T) {
+	cExecutor := GetInstance()
+	con, noti, _ := initializeMock(t)
+
+	started := make(chan bool, 1)
+	gomock.InOrder(
+		con.EXPECT().ImagePull(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil),
+		con.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(conf *container.Config, hostConf *container.HostConfig, networkConf *network.NetworkingConfig) (container.ContainerCreateCreatedBody, error) {
+				if conf.Labels[LabelOwner] != ownerName || conf.Labels[LabelServiceID] != "1" || conf.Labels[LabelServiceName] != serviceInfo.ServiceName {
+					t.Error("unexpected labels", conf.Labels)
+				}
+				return resp, nil
+			}),
+		con.EXPECT().Start(containerID).Return(nil),
+		con.EXPECT().Logs(containerID).DoAndReturn(func(id string) (io.ReadCloser, error) {
+			started <- true
+			return io.NopCloser(strings.NewReader("")), nil
+		}),
+		con.EXPECT().Wait(containerID, container.WaitConditionNotRunning).Return(statusChan, errCh),
+		noti.EXPECT().InvokeNotification(gomock.Any(), float64(serviceInfo.ServiceID), servicemgr.ConstServiceStatusFailed, 137),
+		con.EXPECT().Remove(containerID),
+	)
+
+	cExecutor.SetCEImpl(con)
+	cExecutor.SetNotiImpl(noti)
+
+	var wait sync.WaitGroup
+	wait.Add(1)
+
+	go func() {
+		if err := cExecutor.Execute(serviceInfo); err != nil {
+			t.Error(err.Error())
+		}
+		wait.Done()
+	}()
+
+	<-started
+	cExecutor.handleEvent(events.Message{Action: "oom", Actor: events.Actor{ID: containerID}})
+
+	statusChan <- container.ContainerWaitOKBody{StatusCode: 137}
+	wait.Wait()
+
+	if _, _, ok := states.update(events.Message{Action: "die", Actor: events.Actor{ID: containerID}}); ok {
+		t.Error("unexpected state of the removed container")
+	}
+}
+
+func TestListContainers(t *testing.T) {
+	cExecutor := GetInstance()
+	con, _, _ := initializeMock(t)
+
+	con.EXPECT().PS(map[string]string{LabelOwner: ownerName}).Return(containerList, nil)
+	con.EXPECT().ImageTag("alpine", "alpine:edge").Return(nil)
+	cExecutor.SetCEImpl(con)
+
+	if list, err := cExecutor.ListContainers(); err != nil || !reflect.DeepEqual(list, containerList) {
+		t.Error("unexpected containers", list, err)
+	}
+	if err := cExecutor.TagImage("alpine", "alpine:edge"); err != nil {
+		t.Error(err.Error())
+	}
+}
+
 func TestSuccessConvertConfigWithAttach(t *testing.T) {
 	validStr := []string{"docker", "run", "-a", "stdin", "-a", "stdout", "-a", "stderr", imageName}
 	container, _, _ := convertConfig(validStr)
@@ -0,0 +1,159 @@
+/*******************************************************************************
+ * Copyright 2020 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+
+package containerexecutor
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types/events"
+
+	"github.com/lf-edge/edge-home-orchestration-go/internal/common/logmgr"
+	servicemgr "github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr"
+	"github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr/executor"
+)
+
+const (
+	eventRetryInterval = 5 * time.Second
+
+	healthStatusAction = "health_status"
+	healthUnhealthy    = "unhealthy"
+	healthHealthy      = "healthy"
+)
+
+// containerState is the state of the running container updated by the container events
+type containerState struct {
+	service   executor.ServiceExecutionInfo
+	oomKilled bool
+	health    string
+}
+
+type containerStates struct {
+	sync.Mutex
+	items map[string]*containerState
+}
+
+var (
+	states    = &containerStates{items: make(map[string]*containerState)}
+	watchOnce sync.Once
+)
+
+func (cs *containerStates) add(id string, s executor.ServiceExecutionInfo) {
+	cs.Lock()
+	defer cs.Unlock()
+
+	cs.items[id] = &containerState{service: s}
+}
+
+func (cs *containerStates) has(id string) bool {
+	cs.Lock()
+	defer cs.Unlock()
+
+	_, ok := cs.items[id]
+	return ok
+}
+
+func (cs *containerStates) remove(id string) containerState {
+	cs.Lock()
+	defer cs.Unlock()
+
+	state, ok := cs.items[id]
+	if !ok {
+		return containerState{}
+	}
+	delete(cs.items, id)
+	return *state
+}
+
+// update applies the event to the state of the container, it returns false when the container is not running.
+// The previous health status is returned to find the change of the health.
+func (cs *containerStates) update(msg events.Message) (containerState, string, bool) {
+	cs.Lock()
+	defer cs.Unlock()
+
+	state, ok := cs.items[msg.Actor.ID]
+	if !ok {
+		return containerState{}, "", false
+	}
+
+	health := state.health
+	switch {
+	case msg.Action == "oom":
+		state.oomKilled = true
+	case strings.HasPrefix(msg.Action, healthStatusAction):
+		state.health = strings.TrimSpace(strings.TrimPrefix(msg.Action, healthStatusAction+":"))
+	}
+	return *state, health, true
+}
+
+// WatchEvents starts receiving the events of the containers started by the orchestration,
+// it reconnects to the container runtime when the event stream is broken
+func (c *ContainerExecutor) WatchEvents() {
+	watchOnce.Do(func() {
+		go func() {
+			for {
+				c.receiveEvents()
+				time.Sleep(eventRetryInterval)
+			}
+		}()
+	})
+}
+
+func (c *ContainerExecutor) receiveEvents() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	msgCh, errCh := c.ceImplIns.Events(ctx, map[string]string{LabelOwner: ownerName})
+	for {
+		select {
+		case msg := <-msgCh:
+			c.handleEvent(msg)
+		case err := <-errCh:
+			log.Println(logPrefix, "container events are broken :", err.Error())
+			return
+		}
+	}
+}
+
+// handleEvent logs the event of the container, and notifies the service as unhealthy when its health check fails
+// and as started again when it recovers
+func (c *ContainerExecutor) handleEvent(msg events.Message) {
+	state, health, ok := states.update(msg)
+	if !ok {
+		return
+	}
+	s := state.service
+	name := logmgr.SanitizeUserInput(s.ServiceName)
+
+	switch {
+	case msg.Action == "oom":
+		log.Println(logPrefix, name, "is out of memory") // lgtm [go/log-injection]
+	case msg.Action == "die":
+		log.Println(logPrefix, name, "is died with exit code", msg.Actor.Attributes["exitCode"]) // lgtm [go/log-injection]
+	case state.health == healthUnhealthy && health != healthUnhealthy:
+		log.Println(logPrefix, name, "is unhealthy") // lgtm [go/log-injection]
+		c.NotiImplIns.InvokeNotification(s.NotificationTargetURL, float64(s.ServiceID), servicemgr.ConstServiceStatusUnhealthy, 0)
+	case state.health == healthHealthy && health == healthUnhealthy:
+		log.Println(logPrefix, name, "is healthy again") // lgtm [go/log-injection]
+		c.NotiImplIns.InvokeNotification(s.NotificationTargetURL, float64(s.ServiceID), servicemgr.ConstServiceStatusStarted, 0)
+	case strings.HasPrefix(msg.Action, healthStatusAction):
+		log.Println(logPrefix, name, "health status :", state.health) // lgtm [go/log-injection]
+	}
+}
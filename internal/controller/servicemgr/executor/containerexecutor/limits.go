@@ -0,0 +1,115 @@
+/*******************************************************************************
+ * Copyright 2020 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+
+package containerexecutor
+
+import (
+	"sync"
+
+	"github.com/docker/docker/api/types/container"
+	memutil "github.com/shirou/gopsutil/mem"
+
+	"github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr/executor"
+)
+
+const (
+	// DefaultMaxCPUShares is the default ceiling of the cpu shares, it is the default weight of the containers
+	DefaultMaxCPUShares = 1024
+	// DefaultMaxMemoryRatio is the default ceiling of the memory as the ratio of the total memory of the device
+	DefaultMaxMemoryRatio = 0.5
+)
+
+// ResourceCeiling struct holds the maximum resources of a container started by the orchestration
+type ResourceCeiling struct {
+	// CPUShares is the maximum cpu shares
+	CPUShares int64
+	// Memory is the maximum memory limit in bytes, 0 means no ceiling
+	Memory int64
+}
+
+var ceiling = struct {
+	sync.RWMutex
+	ResourceCeiling
+}{ResourceCeiling: defaultCeiling()}
+
+// SetResourceCeiling sets the ceiling of the resources of the containers, the zero values are replaced by the defaults
+func SetResourceCeiling(c ResourceCeiling) {
+	defaults := defaultCeiling()
+	if c.CPUShares <= 0 {
+		c.CPUShares = defaults.CPUShares
+	}
+	if c.Memory <= 0 {
+		c.Memory = defaults.Memory
+	}
+
+	ceiling.Lock()
+	defer ceiling.Unlock()
+
+	ceiling.ResourceCeiling = c
+}
+
+// GetResourceCeiling returns the ceiling of the resources of the containers
+func GetResourceCeiling() ResourceCeiling {
+	ceiling.RLock()
+	defer ceiling.RUnlock()
+
+	return ceiling.ResourceCeiling
+}
+
+func defaultCeiling() ResourceCeiling {
+	c := ResourceCeiling{CPUShares: DefaultMaxCPUShares}
+	if memStat, err := memutil.VirtualMemory(); err == nil {
+		c.Memory = int64(float64(memStat.Total) * DefaultMaxMemoryRatio)
+	}
+	return c
+}
+
+// applyLimits overrides the resources and the health check of the docker-run parameters by the limits of the request,
+// and caps the resources by the ceiling of the device whatever the requester asks
+func applyLimits(conf *container.Config, hostConf *container.HostConfig, limits executor.ResourceLimits) {
+	if limits.CPUShares > 0 {
+		hostConf.CPUShares = limits.CPUShares
+	}
+	if limits.Memory > 0 {
+		hostConf.Memory = limits.Memory
+	}
+	if limits.HealthCheck != nil {
+		conf.Healthcheck = healthConfig(limits.HealthCheck)
+	}
+
+	c := GetResourceCeiling()
+	if c.CPUShares > 0 && hostConf.CPUShares > c.CPUShares {
+		hostConf.CPUShares = c.CPUShares
+	}
+	if c.Memory > 0 && (hostConf.Memory <= 0 || hostConf.Memory > c.Memory) {
+		hostConf.Memory = c.Memory
+	}
+	if hostConf.MemorySwap > 0 && hostConf.MemorySwap < hostConf.Memory {
+		hostConf.MemorySwap = hostConf.Memory
+	}
+}
+
+// healthConfig runs the command of the health check without a shell, so its arguments are kept as they are
+func healthConfig(check *executor.HealthCheck) *container.HealthConfig {
+	return &container.HealthConfig{
+		Test:        append([]string{"CMD"}, check.Command...),
+		Interval:    check.Interval,
+		Timeout:     check.Timeout,
+		StartPeriod: check.StartPeriod,
+		Retries:     check.Retries,
+	}
+}
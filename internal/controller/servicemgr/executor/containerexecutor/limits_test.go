@@ -0,0 +1,86 @@
+/*******************************************************************************
+ * Copyright 2020 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+
+package containerexecutor
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/events"
+
+	servicemgr "github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr"
+	"github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr/executor"
+)
+
+const testMemoryCeiling = 256 * 1024 * 1024
+
+func TestApplyLimits(t *testing.T) {
+	SetResourceCeiling(ResourceCeiling{CPUShares: 512, Memory: testMemoryCeiling})
+	defer SetResourceCeiling(ResourceCeiling{})
+
+	t.Run("Request", func(t *testing.T) {
+		conf, hostConf, _ := convertConfig([]string{"docker", "run", "alpine"})
+		applyLimits(conf, hostConf, executor.ResourceLimits{
+			CPUShares: 256,
+			Memory:    64 * 1024 * 1024,
+			HealthCheck: &executor.HealthCheck{
+				Command:  []string{"curl", "-f", "http://localhost"},
+				Interval: 30 * time.Second,
+				Retries:  3,
+			},
+		})
+		if hostConf.CPUShares != 256 || hostConf.Memory != 64*1024*1024 {
+			t.Error("unexpected resources", hostConf.Resources)
+		}
+		expected := []string{"CMD", "curl", "-f", "http://localhost"}
+		if conf.Healthcheck == nil || !reflect.DeepEqual(conf.Healthcheck.Test, expected) ||
+			conf.Healthcheck.Interval != 30*time.Second || conf.Healthcheck.Retries != 3 {
+			t.Error("unexpected health check", conf.Healthcheck)
+		}
+	})
+	t.Run("Ceiling", func(t *testing.T) {
+		conf, hostConf, _ := convertConfig([]string{"docker", "run", "--cpu-shares", "4096", "-m", "1g", "--memory-swap", "300m", "alpine"})
+		applyLimits(conf, hostConf, executor.ResourceLimits{})
+		if hostConf.CPUShares != 512 || hostConf.Memory != testMemoryCeiling || hostConf.MemorySwap != 300*1024*1024 {
+			t.Error("unexpected resources", hostConf.Resources)
+		}
+
+		conf, hostConf, _ = convertConfig([]string{"docker", "run", "alpine"})
+		applyLimits(conf, hostConf, executor.ResourceLimits{CPUShares: 2048})
+		if hostConf.CPUShares != 512 || hostConf.Memory != testMemoryCeiling {
+			t.Error("unexpected resources", hostConf.Resources)
+		}
+	})
+}
+
+func TestHandleHealthEvent(t *testing.T) {
+	cExecutor := GetInstance()
+	_, noti, _ := initializeMock(t)
+	cExecutor.SetNotiImpl(noti)
+
+	states.add(containerID, serviceInfo)
+	defer states.remove(containerID)
+
+	noti.EXPECT().InvokeNotification(serviceInfo.NotificationTargetURL, float64(serviceInfo.ServiceID), servicemgr.ConstServiceStatusUnhealthy, 0)
+	noti.EXPECT().InvokeNotification(serviceInfo.NotificationTargetURL, float64(serviceInfo.ServiceID), servicemgr.ConstServiceStatusStarted, 0)
+
+	for _, health := range []string{"starting", "unhealthy", "unhealthy", "healthy", "healthy"} {
+		cExecutor.handleEvent(events.Message{Action: healthStatusAction + ": " + health, Actor: events.Actor{ID: containerID}})
+	}
+}
@@ -5,11 +5,16 @@
 package mocks
 
 import (
+	context "context"
+	types "github.com/docker/docker/api/types"
 	container "github.com/docker/docker/api/types/container"
+	events "github.com/docker/docker/api/types/events"
 	network "github.com/docker/docker/api/types/network"
+	jsonmessage "github.com/docker/docker/pkg/jsonmessage"
 	gomock "github.com/golang/mock/gomock"
 	io "io"
 	reflect "reflect"
+	time "time"
 )
 
 // MockCEImpl is a mock of CEImpl interface
@@ -64,6 +69,20 @@ func (mr *MockCEImplMockRecorder) Remove(id interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockCEImpl)(nil).Remove), id)
 }
 
+// Stop mocks base method
+func (m *MockCEImpl) Stop(id string, timeout *time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stop", id, timeout)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Stop indicates an expected call of Stop
+func (mr *MockCEImplMockRecorder) Stop(id, timeout interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockCEImpl)(nil).Stop), id, timeout)
+}
+
 // Start mocks base method
 func (m *MockCEImpl) Start(id string) error {
 	m.ctrl.T.Helper()
@@ -109,15 +128,74 @@ func (mr *MockCEImplMockRecorder) Logs(id interface{}) *gomock.Call {
 }
 
 // ImagePull mocks base method
-func (m *MockCEImpl) ImagePull(image string) error {
+func (m *MockCEImpl) ImagePull(image string, auth *types.AuthConfig, progress func(jsonmessage.JSONMessage)) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ImagePull", image)
+	ret := m.ctrl.Call(m, "ImagePull", image, auth, progress)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // ImagePull indicates an expected call of ImagePull
-func (mr *MockCEImplMockRecorder) ImagePull(image interface{}) *gomock.Call {
+func (mr *MockCEImplMockRecorder) ImagePull(image, auth, progress interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImagePull", reflect.TypeOf((*MockCEImpl)(nil).ImagePull), image, auth, progress)
+}
+
+// ImageExists mocks base method
+func (m *MockCEImpl) ImageExists(image string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImageExists", image)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImageExists indicates an expected call of ImageExists
+func (mr *MockCEImplMockRecorder) ImageExists(image interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImageExists", reflect.TypeOf((*MockCEImpl)(nil).ImageExists), image)
+}
+
+// PS mocks base method
+func (m *MockCEImpl) PS(labels map[string]string) ([]types.Container, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PS", labels)
+	ret0, _ := ret[0].([]types.Container)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PS indicates an expected call of PS
+func (mr *MockCEImplMockRecorder) PS(labels interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PS", reflect.TypeOf((*MockCEImpl)(nil).PS), labels)
+}
+
+// Events mocks base method
+func (m *MockCEImpl) Events(ctx context.Context, labels map[string]string) (<-chan events.Message, <-chan error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Events", ctx, labels)
+	ret0, _ := ret[0].(<-chan events.Message)
+	ret1, _ := ret[1].(<-chan error)
+	return ret0, ret1
+}
+
+// Events indicates an expected call of Events
+func (mr *MockCEImplMockRecorder) Events(ctx, labels interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Events", reflect.TypeOf((*MockCEImpl)(nil).Events), ctx, labels)
+}
+
+// ImageTag mocks base method
+func (m *MockCEImpl) ImageTag(source, target string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImageTag", source, target)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ImageTag indicates an expected call of ImageTag
+func (mr *MockCEImplMockRecorder) ImageTag(source, target interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImagePull", reflect.TypeOf((*MockCEImpl)(nil).ImagePull), image)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImageTag", reflect.TypeOf((*MockCEImpl)(nil).ImageTag), source, target)
 }
@@ -0,0 +1,250 @@
+/*******************************************************************************
+ * Copyright 2020 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+
+package containerexecutor
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/distribution/reference"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/pkg/jsonmessage"
+
+	servicemgr "github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr"
+	"github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr/executor"
+)
+
+const (
+	// PullAlways pulls the image before every execution
+	PullAlways = "always"
+	// PullIfNotPresent pulls the image only when it is not present on the device
+	PullIfNotPresent = "missing"
+	// PullNever never pulls the image, the execution fails when it is not present on the device
+	PullNever = "never"
+
+	dockerHubDomain  = "docker.io"
+	dockerHubAddress = "https://index.docker.io/v1/"
+
+	progressInterval = time.Second
+)
+
+var registryAuth = struct {
+	sync.RWMutex
+	path string
+}{}
+
+// SetRegistryAuthFile sets the file of the registry credentials, the file has the auths of docker config.json
+// and must be accessible only by its owner
+func SetRegistryAuthFile(path string) {
+	registryAuth.Lock()
+	defer registryAuth.Unlock()
+
+	registryAuth.path = path
+}
+
+// getPullPolicy returns the pull policy of the --pull flag of the docker-run parameters
+func getPullPolicy(paramStr []string) (string, error) {
+	_, copts := parseFlags(paramStr)
+
+	switch strings.ToLower(copts.pull) {
+	case "", PullAlways:
+		return PullAlways, nil
+	case PullIfNotPresent, "ifnotpresent":
+		return PullIfNotPresent, nil
+	case PullNever:
+		return PullNever, nil
+	}
+	return "", fmt.Errorf("invalid pull policy : %s", copts.pull)
+}
+
+// pullImage pulls the image according to the policy and notifies the progress of the pull as the status of the service
+func (c *ContainerExecutor) pullImage(s executor.ServiceExecutionInfo, image, policy string) error {
+	if policy != PullAlways {
+		exists, err := c.ceImplIns.ImageExists(image)
+		if err != nil {
+			return err
+		} else if exists {
+			return nil
+		} else if policy == PullNever {
+			return fmt.Errorf("image %s is not present and the pull policy is %s", image, PullNever)
+		}
+	}
+
+	auth, err := getRegistryAuth(image)
+	if err != nil {
+		log.Println(logPrefix, "cannot get registry auth :", err.Error())
+	}
+
+	reporter := newPullReporter(image, func(progress map[string]interface{}) {
+		c.NotiImplIns.InvokeProgress(s.NotificationTargetURL, float64(s.ServiceID), progress)
+	})
+	err = c.ceImplIns.ImagePull(image, auth, reporter.update)
+	if reporter.flush() && err == nil {
+		c.NotiImplIns.InvokeNotification(s.NotificationTargetURL, float64(s.ServiceID), servicemgr.ConstServiceStatusStarted, 0)
+	}
+	return err
+}
+
+// getRegistryAuth returns the credential of the registry of the image from the registry auth file
+func getRegistryAuth(image string) (*types.AuthConfig, error) {
+	registryAuth.RLock()
+	path := registryAuth.path
+	registryAuth.RUnlock()
+
+	if len(path) == 0 {
+		return nil, nil
+	}
+
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else if info.Mode().Perm()&0077 != 0 {
+		return nil, fmt.Errorf("%s is accessible by other users", path)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var config struct {
+		Auths map[string]types.AuthConfig `json:"auths"`
+	}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, err
+	}
+
+	named, err := reference.ParseNormalizedNamed(image)
+	if err != nil {
+		return nil, err
+	}
+	domain := reference.Domain(named)
+
+	addresses := []string{domain, "https://" + domain, "http://" + domain}
+	if domain == dockerHubDomain {
+		addresses = append(addresses, dockerHubAddress)
+	}
+	for _, address := range addresses {
+		auth, ok := config.Auths[address]
+		if !ok {
+			continue
+		}
+		if len(auth.Username) == 0 && len(auth.Auth) > 0 {
+			decoded, err := base64.StdEncoding.DecodeString(auth.Auth)
+			if err != nil {
+				return nil, err
+			}
+			if userPass := strings.SplitN(string(decoded), ":", 2); len(userPass) == 2 {
+				auth.Username, auth.Password = userPass[0], userPass[1]
+			}
+		}
+		auth.Auth = ""
+		auth.ServerAddress = address
+		return &auth, nil
+	}
+	return nil, nil
+}
+
+func encodeAuth(auth types.AuthConfig) (string, error) {
+	encoded, err := json.Marshal(auth)
+	if err != nil {
+		return "", err
+	}
+	return base64.URLEncoding.EncodeToString(encoded), nil
+}
+
+// pullReporter gathers the progress of the layers and reports it at most every progressInterval
+type pullReporter struct {
+	sync.Mutex
+	image    string
+	status   string
+	layers   map[string]jsonmessage.JSONProgress
+	last     time.Time
+	reported bool
+	report   func(map[string]interface{})
+}
+
+func newPullReporter(image string, report func(map[string]interface{})) *pullReporter {
+	return &pullReporter{image: image, layers: make(map[string]jsonmessage.JSONProgress), report: report}
+}
+
+func (r *pullReporter) update(msg jsonmessage.JSONMessage) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.status = msg.Status
+	layer, known := r.layers[msg.ID]
+	switch msg.Status {
+	case "Pulling fs layer", "Waiting":
+	case "Downloading":
+		if msg.Progress != nil {
+			layer.Current, layer.Total = msg.Progress.Current, msg.Progress.Total
+		}
+	case "Download complete", "Pull complete", "Already exists":
+		layer.Current = layer.Total
+	default:
+		if !known {
+			return
+		}
+	}
+	r.layers[msg.ID] = layer
+
+	if time.Since(r.last) >= progressInterval {
+		r.send()
+	}
+}
+
+// flush reports the last progress and returns whether the progress has been reported
+func (r *pullReporter) flush() bool {
+	r.Lock()
+	defer r.Unlock()
+
+	if len(r.status) > 0 {
+		r.send()
+	}
+	return r.reported
+}
+
+func (r *pullReporter) send() {
+	var current, total int64
+	layers := make(map[string]interface{}, len(r.layers))
+	for id, layer := range r.layers {
+		current += layer.Current
+		total += layer.Total
+		layers[id] = map[string]interface{}{
+			"Current": layer.Current,
+			"Total":   layer.Total,
+		}
+	}
+
+	r.report(map[string]interface{}{
+		"Image":   r.image,
+		"Status":  r.status,
+		"Current": current,
+		"Total":   total,
+		"Layers":  layers,
+	})
+	r.last = time.Now()
+	r.reported = true
+}
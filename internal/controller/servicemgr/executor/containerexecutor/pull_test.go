@@ -0,0 +1,172 @@
+/*******************************************************************************
+ * Copyright 2020 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+
+package containerexecutor
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/pkg/jsonmessage"
+	gomock "github.com/golang/mock/gomock"
+
+	servicemgr "github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr"
+)
+
+func TestGetPullPolicy(t *testing.T) {
+	tests := map[string]string{
+		"":             PullAlways,
+		"always":       PullAlways,
+		"missing":      PullIfNotPresent,
+		"IfNotPresent": PullIfNotPresent,
+		"never":        PullNever,
+	}
+	for value, expected := range tests {
+		paramStr := []string{"docker", "run", "alpine"}
+		if len(value) > 0 {
+			paramStr = []string{"docker", "run", "--pull", value, "alpine"}
+		}
+		if policy, err := getPullPolicy(paramStr); err != nil || policy != expected {
+			t.Error("unexpected policy of", value, ":", policy, err)
+		}
+	}
+
+	if _, err := getPullPolicy([]string{"docker", "run", "--pull", "sometimes", "alpine"}); err == nil {
+		t.Error("unexpected success")
+	}
+}
+
+func TestGetRegistryAuth(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "registry_auth.json")
+	SetRegistryAuthFile(path)
+	defer SetRegistryAuthFile("")
+
+	data := `{"auths":{"https://index.docker.io/v1/":{"auth":"dXNlcjpwYXNz"},"registry.local:5000":{"username":"admin","password":"secret"}}}`
+	if err := os.WriteFile(path, []byte(data), 0600); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	t.Run("DockerHub", func(t *testing.T) {
+		auth, err := getRegistryAuth("alpine")
+		if err != nil || auth == nil || auth.Username != "user" || auth.Password != "pass" || auth.ServerAddress != dockerHubAddress {
+			t.Error("unexpected auth", auth, err)
+		}
+	})
+	t.Run("PrivateRegistry", func(t *testing.T) {
+		auth, err := getRegistryAuth("registry.local:5000/hello:1.0")
+		if err != nil || auth == nil || auth.Username != "admin" || auth.Password != "secret" {
+			t.Error("unexpected auth", auth, err)
+		}
+	})
+	t.Run("UnknownRegistry", func(t *testing.T) {
+		if auth, err := getRegistryAuth("quay.io/hello"); err != nil || auth != nil {
+			t.Error("unexpected auth", auth, err)
+		}
+	})
+	t.Run("InsecureFile", func(t *testing.T) {
+		if err := os.Chmod(path, 0644); err != nil {
+			t.Fatal(err.Error())
+		}
+		if _, err := getRegistryAuth("alpine"); err == nil {
+			t.Error("unexpected success")
+		}
+	})
+}
+
+func TestPullImage(t *testing.T) {
+	t.Run("IfNotPresent", func(t *testing.T) {
+		cExecutor := GetInstance()
+		con, noti, _ := initializeMock(t)
+
+		con.EXPECT().ImageExists("alpine").Return(true, nil)
+
+		cExecutor.SetCEImpl(con)
+		cExecutor.SetNotiImpl(noti)
+
+		if err := cExecutor.pullImage(serviceInfo, "alpine", PullIfNotPresent); err != nil {
+			t.Error(err.Error())
+		}
+	})
+	t.Run("Never", func(t *testing.T) {
+		cExecutor := GetInstance()
+		con, noti, _ := initializeMock(t)
+
+		con.EXPECT().ImageExists("alpine").Return(false, nil)
+
+		cExecutor.SetCEImpl(con)
+		cExecutor.SetNotiImpl(noti)
+
+		if err := cExecutor.pullImage(serviceInfo, "alpine", PullNever); err == nil {
+			t.Error("unexpected success")
+		}
+	})
+	t.Run("PullFailed", func(t *testing.T) {
+		cExecutor := GetInstance()
+		con, noti, _ := initializeMock(t)
+
+		gomock.InOrder(
+			con.EXPECT().ImagePull("alpine", gomock.Any(), gomock.Any()).DoAndReturn(
+				func(image string, auth *types.AuthConfig, progress func(jsonmessage.JSONMessage)) error {
+					progress(jsonmessage.JSONMessage{ID: "layer1", Status: "Pulling fs layer"})
+					return errors.New("pull error")
+				}),
+			noti.EXPECT().InvokeProgress(gomock.Any(), float64(serviceInfo.ServiceID), gomock.Any()).AnyTimes(),
+		)
+
+		cExecutor.SetCEImpl(con)
+		cExecutor.SetNotiImpl(noti)
+
+		if err := cExecutor.pullImage(serviceInfo, "alpine", PullAlways); err == nil {
+			t.Error("unexpected success")
+		}
+	})
+	t.Run("Progress", func(t *testing.T) {
+		cExecutor := GetInstance()
+		con, noti, _ := initializeMock(t)
+
+		var last map[string]interface{}
+		gomock.InOrder(
+			con.EXPECT().ImagePull("alpine", gomock.Any(), gomock.Any()).DoAndReturn(
+				func(image string, auth *types.AuthConfig, progress func(jsonmessage.JSONMessage)) error {
+					progress(jsonmessage.JSONMessage{ID: "layer1", Status: "Pulling fs layer"})
+					progress(jsonmessage.JSONMessage{ID: "layer1", Status: "Downloading", Progress: &jsonmessage.JSONProgress{Current: 10, Total: 100}})
+					progress(jsonmessage.JSONMessage{ID: "layer1", Status: "Pull complete"})
+					progress(jsonmessage.JSONMessage{Status: "Status: Downloaded newer image for alpine:latest"})
+					return nil
+				}),
+			noti.EXPECT().InvokeProgress(gomock.Any(), float64(serviceInfo.ServiceID), gomock.Any()).DoAndReturn(
+				func(target string, serviceID float64, progress map[string]interface{}) error {
+					last = progress
+					return nil
+				}).Times(2),
+			noti.EXPECT().InvokeNotification(gomock.Any(), float64(serviceInfo.ServiceID), servicemgr.ConstServiceStatusStarted, 0),
+		)
+
+		cExecutor.SetCEImpl(con)
+		cExecutor.SetNotiImpl(noti)
+
+		if err := cExecutor.pullImage(serviceInfo, "alpine", PullAlways); err != nil {
+			t.Error(err.Error())
+		}
+		if last["Image"] != "alpine" || last["Current"] != int64(100) || last["Total"] != int64(100) {
+			t.Error("unexpected progress", last)
+		}
+	})
+}
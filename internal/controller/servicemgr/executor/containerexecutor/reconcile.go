@@ -0,0 +1,109 @@
+/*******************************************************************************
+ * Copyright 2020 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+
+package containerexecutor
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+
+	"github.com/lf-edge/edge-home-orchestration-go/internal/common/logmgr"
+	"github.com/lf-edge/edge-home-orchestration-go/internal/common/types/servicemgrtypes"
+	"github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr"
+	"github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr/executor"
+)
+
+const (
+	// ReconcileReattach waits again for the containers started before restarting and notifies their status
+	ReconcileReattach = "reattach"
+	// ReconcileRemove stops and removes the containers started before restarting, their status is notified as stopped
+	ReconcileRemove = "remove"
+)
+
+// Reconcile handles the containers started by the orchestration before restarting according to the policy,
+// the empty policy means ReconcileReattach. It has to be called before accepting the service requests,
+// since the service IDs of the containers are reserved not to be given to the new services.
+func (c *ContainerExecutor) Reconcile(policy string) error {
+	policy = strings.ToLower(policy)
+	switch policy {
+	case "":
+		policy = ReconcileReattach
+	case ReconcileReattach, ReconcileRemove:
+	default:
+		return fmt.Errorf("unsupported reconcile policy : %s", policy)
+	}
+
+	containers, err := c.ListContainers()
+	if err != nil {
+		return err
+	}
+
+	for _, item := range containers {
+		if states.has(item.ID) {
+			continue
+		}
+		s, err := getServiceExecutionInfo(item)
+		if err != nil {
+			log.Println(logPrefix, "cannot reconcile container", item.ID, ":", err.Error())
+			continue
+		}
+		log.Println(logPrefix, policy, "container", item.ID, "of", logmgr.SanitizeUserInput(s.ServiceName)) // lgtm [go/log-injection]
+
+		c.reattach(s, item.ID)
+		if policy == ReconcileRemove {
+			go func(s executor.ServiceExecutionInfo) {
+				if err := running.Stop(s); err != nil {
+					log.Println(logPrefix, err.Error())
+				}
+			}(s)
+		}
+	}
+	return nil
+}
+
+// reattach registers the container of the service as running and waits for it in the background,
+// only the service IDs given by this device are reserved, the remote requesters give their own IDs
+func (c *ContainerExecutor) reattach(s executor.ServiceExecutionInfo, containerID string) {
+	if servicemgrtypes.IsLocalTarget(s.NotificationTargetURL) {
+		servicemgr.ReserveServiceIdx(s.ServiceID)
+	}
+
+	stopTimeout := DefaultStopTimeout
+	running.Add(s, func() error {
+		return c.ceImplIns.Stop(containerID, &stopTimeout)
+	})
+	states.add(containerID, s)
+
+	go c.waitContainer(s, containerID)
+}
+
+// getServiceExecutionInfo restores the service of the container from its labels
+func getServiceExecutionInfo(item types.Container) (s executor.ServiceExecutionInfo, err error) {
+	s.ServiceID, err = strconv.ParseUint(item.Labels[LabelServiceID], 10, 64)
+	if err != nil {
+		return s, fmt.Errorf("invalid service id label : %s", err.Error())
+	}
+	s.ServiceName = item.Labels[LabelServiceName]
+	s.NotificationTargetURL = item.Labels[LabelNotificationTarget]
+	if len(s.NotificationTargetURL) == 0 {
+		return s, fmt.Errorf("no notification target label")
+	}
+	return s, nil
+}
@@ -0,0 +1,148 @@
+/*******************************************************************************
+ * Copyright 2020 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+
+package containerexecutor
+
+import (
+	"sync/atomic"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	gomock "github.com/golang/mock/gomock"
+
+	"github.com/lf-edge/edge-home-orchestration-go/internal/common/networkhelper"
+	servicemgr "github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr"
+)
+
+var orphan = types.Container{
+	ID:    "orphan1234",
+	Image: "alpine",
+	State: "running",
+	Labels: map[string]string{
+		LabelOwner:              ownerName,
+		LabelServiceID:          "7",
+		LabelServiceName:        "alpine",
+		LabelNotificationTarget: "192.168.0.2",
+	},
+}
+
+func TestReconcile(t *testing.T) {
+	t.Run("Reattach", func(t *testing.T) {
+		cExecutor := GetInstance()
+		con, noti, _ := initializeMock(t)
+
+		localAddr, _ := networkhelper.GetInstance().GetOutboundIP()
+		local := orphan
+		local.Labels = map[string]string{
+			LabelOwner:              ownerName,
+			LabelServiceID:          "7",
+			LabelServiceName:        "alpine",
+			LabelNotificationTarget: localAddr,
+		}
+		invalid := types.Container{ID: "invalid", Labels: map[string]string{LabelOwner: ownerName}}
+		statusCh := make(chan container.ContainerWaitOKBody, 1)
+		done := make(chan bool)
+		gomock.InOrder(
+			con.EXPECT().PS(map[string]string{LabelOwner: ownerName}).Return([]types.Container{local, invalid}, nil),
+			con.EXPECT().Wait(local.ID, container.WaitConditionNotRunning).Return(statusCh, nil),
+			noti.EXPECT().InvokeNotification(localAddr, float64(7), servicemgr.ConstServiceStatusFinished, 0),
+			con.EXPECT().Remove(local.ID).DoAndReturn(func(id string) error {
+				done <- true
+				return nil
+			}),
+		)
+
+		cExecutor.SetCEImpl(con)
+		cExecutor.SetNotiImpl(noti)
+
+		if err := cExecutor.Reconcile(""); err != nil {
+			t.Fatal(err.Error())
+		}
+		if id := atomic.LoadUint64(&servicemgr.ServiceIdx); id < 7 {
+			t.Error("service id of the reattached container is not reserved", id)
+		}
+		statusCh <- container.ContainerWaitOKBody{StatusCode: 0}
+		<-done
+	})
+	t.Run("RemoteRequester", func(t *testing.T) {
+		cExecutor := GetInstance()
+		con, noti, _ := initializeMock(t)
+
+		remote := orphan
+		remote.Labels = map[string]string{
+			LabelOwner:              ownerName,
+			LabelServiceID:          "100000",
+			LabelServiceName:        "alpine",
+			LabelNotificationTarget: "192.168.0.2",
+		}
+		statusCh := make(chan container.ContainerWaitOKBody, 1)
+		done := make(chan bool)
+		gomock.InOrder(
+			con.EXPECT().PS(gomock.Any()).Return([]types.Container{remote}, nil),
+			con.EXPECT().Wait(remote.ID, container.WaitConditionNotRunning).Return(statusCh, nil),
+			noti.EXPECT().InvokeNotification("192.168.0.2", float64(100000), servicemgr.ConstServiceStatusFinished, 0),
+			con.EXPECT().Remove(remote.ID).DoAndReturn(func(id string) error {
+				done <- true
+				return nil
+			}),
+		)
+
+		cExecutor.SetCEImpl(con)
+		cExecutor.SetNotiImpl(noti)
+
+		if err := cExecutor.Reconcile(""); err != nil {
+			t.Fatal(err.Error())
+		}
+		if id := atomic.LoadUint64(&servicemgr.ServiceIdx); id >= 100000 {
+			t.Error("service id of the remote requester is reserved", id)
+		}
+		statusCh <- container.ContainerWaitOKBody{StatusCode: 0}
+		<-done
+	})
+	t.Run("Remove", func(t *testing.T) {
+		cExecutor := GetInstance()
+		con, noti, _ := initializeMock(t)
+
+		statusCh := make(chan container.ContainerWaitOKBody, 1)
+		done := make(chan bool)
+		con.EXPECT().PS(gomock.Any()).Return([]types.Container{orphan}, nil)
+		con.EXPECT().Wait(orphan.ID, container.WaitConditionNotRunning).Return(statusCh, nil)
+		con.EXPECT().Stop(orphan.ID, gomock.Any()).DoAndReturn(func(id string, timeout interface{}) error {
+			statusCh <- container.ContainerWaitOKBody{StatusCode: 137}
+			return nil
+		})
+		noti.EXPECT().InvokeNotification("192.168.0.2", float64(7), servicemgr.ConstServiceStatusStopped, 137)
+		con.EXPECT().Remove(orphan.ID).DoAndReturn(func(id string) error {
+			done <- true
+			return nil
+		})
+
+		cExecutor.SetCEImpl(con)
+		cExecutor.SetNotiImpl(noti)
+
+		if err := cExecutor.Reconcile(ReconcileRemove); err != nil {
+			t.Fatal(err.Error())
+		}
+		<-done
+	})
+	t.Run("InvalidPolicy", func(t *testing.T) {
+		if err := GetInstance().Reconcile("unknown"); err == nil {
+			t.Error("unexpected success")
+		}
+	})
+}
@@ -19,13 +19,23 @@
 package executor
 
 import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/lf-edge/edge-home-orchestration-go/internal/common/errors"
 	"github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr/notification"
 	"github.com/lf-edge/edge-home-orchestration-go/internal/restinterface/client"
 )
 
+// UnprivilegedUser runs the native services without RunAsUser when the orchestrator runs as root
+const UnprivilegedUser = "nobody"
+
 // ServiceExecutor interface
 type ServiceExecutor interface {
 	Execute(ServiceExecutionInfo) (err error)
+	Stop(ServiceExecutionInfo) (err error)
 	SetNotiImpl(noti notification.Notification)
 	client.Setter
 }
@@ -36,6 +46,43 @@ type ServiceExecutionInfo struct {
 	ServiceName           string
 	ParamStr              []string
 	NotificationTargetURL string
+	Limits                ResourceLimits
+}
+
+// ResourceLimits struct holds the resources which the service is allowed to use,
+// the device executing the service caps them by its own ceiling
+type ResourceLimits struct {
+	// CPUShares is the relative cpu weight, 0 keeps the default weight
+	CPUShares int64
+	// Memory is the memory limit in bytes, 0 means the ceiling of the device
+	Memory int64
+	// HealthCheck is the health check of the service, nil keeps the health check of the image
+	HealthCheck *HealthCheck
+	// Timeout is the maximum running time of the native service, which is killed after it, 0 means no limit
+	Timeout time.Duration
+}
+
+// HealthCheck struct describes how to check the health of the service
+type HealthCheck struct {
+	// Command is run by the shell in the container, the service is unhealthy when it exits with non-zero
+	Command []string
+	// Interval is the time between the checks
+	Interval time.Duration
+	// Timeout is the time to wait for the command
+	Timeout time.Duration
+	// StartPeriod is the time of starting the service, the failures in it are not counted
+	StartPeriod time.Duration
+	// Retries is the number of consecutive failures to be unhealthy
+	Retries int
+}
+
+// RunAsUser returns the user running the native service of the .conf file. When the orchestrator runs as root,
+// the service without RunAsUser runs as UnprivilegedUser, so RunAsUser=root has to be set explicitly to run it as root.
+func RunAsUser(runAsUser string) string {
+	if len(runAsUser) == 0 && os.Geteuid() == 0 {
+		return UnprivilegedUser
+	}
+	return runAsUser
 }
 
 // HasClientNotification struct
@@ -49,3 +96,62 @@ func (c *HasClientNotification) SetClient(clientAPI client.Clienter) {
 	c.Clienter = clientAPI
 	c.NotiImplIns.SetClient(clientAPI)
 }
+
+// RunningServices keeps the stop handlers of services running on the local device
+type RunningServices struct {
+	sync.Mutex
+	items map[string]*runningService
+}
+
+type runningService struct {
+	stop    func() error
+	stopped bool
+}
+
+// NewRunningServices returns an empty RunningServices
+func NewRunningServices() *RunningServices {
+	return &RunningServices{items: make(map[string]*runningService)}
+}
+
+// Add registers the stop handler of the running service
+func (r *RunningServices) Add(s ServiceExecutionInfo, stop func() error) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.items[s.key()] = &runningService{stop: stop}
+}
+
+// Remove unregisters the running service and reports whether it was stopped by request
+func (r *RunningServices) Remove(s ServiceExecutionInfo) (stopped bool) {
+	r.Lock()
+	defer r.Unlock()
+
+	item, ok := r.items[s.key()]
+	if !ok {
+		return false
+	}
+	delete(r.items, s.key())
+
+	return item.stopped
+}
+
+// Stop calls the stop handler of the running service
+func (r *RunningServices) Stop(s ServiceExecutionInfo) error {
+	r.Lock()
+	item, ok := r.items[s.key()]
+	if ok {
+		item.stopped = true
+	}
+	r.Unlock()
+
+	if !ok {
+		return errors.NotFound{Message: fmt.Sprintf("service %d is not running", s.ServiceID)}
+	}
+
+	return item.stop()
+}
+
+// key distinguishes services with the same ID requested by different devices
+func (s ServiceExecutionInfo) key() string {
+	return fmt.Sprintf("%s/%d", s.NotificationTargetURL, s.ServiceID)
+}
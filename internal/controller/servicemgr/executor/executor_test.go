@@ -45,3 +45,48 @@ func TestSetClient(t *testing.T) {
 		}
 	})
 }
+
+func TestRunningServices(t *testing.T) {
+	s := ServiceExecutionInfo{ServiceID: 1, NotificationTargetURL: "192.0.2.1"}
+
+	t.Run("Success", func(t *testing.T) {
+		r := NewRunningServices()
+
+		called := false
+		r.Add(s, func() error {
+			called = true
+			return nil
+		})
+
+		if err := r.Stop(s); err != nil {
+			t.Error(unexpectedFail, err.Error())
+		} else if !called {
+			t.Error(unexpectedFail)
+		}
+
+		if stopped := r.Remove(s); !stopped {
+			t.Error(unexpectedFail)
+		}
+	})
+	t.Run("Fail", func(t *testing.T) {
+		t.Run("NotRunning", func(t *testing.T) {
+			r := NewRunningServices()
+			if err := r.Stop(s); err == nil {
+				t.Error(unexpectedSuccess)
+			}
+		})
+		t.Run("OtherRequester", func(t *testing.T) {
+			r := NewRunningServices()
+			r.Add(s, func() error { return nil })
+
+			other := s
+			other.NotificationTargetURL = "192.0.2.2"
+			if err := r.Stop(other); err == nil {
+				t.Error(unexpectedSuccess)
+			}
+			if stopped := r.Remove(s); stopped {
+				t.Error(unexpectedSuccess)
+			}
+		})
+	})
+}
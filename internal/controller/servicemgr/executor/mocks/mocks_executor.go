@@ -66,6 +66,20 @@ func (mr *MockServiceExecutorMockRecorder) Execute(arg0 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MockServiceExecutor)(nil).Execute), arg0)
 }
 
+// Stop mocks base method
+func (m *MockServiceExecutor) Stop(arg0 executor.ServiceExecutionInfo) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stop", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Stop indicates an expected call of Stop
+func (mr *MockServiceExecutorMockRecorder) Stop(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockServiceExecutor)(nil).Stop), arg0)
+}
+
 // SetNotiImpl mocks base method
 func (m *MockServiceExecutor) SetNotiImpl(noti notification.Notification) {
 	m.ctrl.T.Helper()
@@ -19,22 +19,31 @@
 package nativeexecutor
 
 import (
-	"bufio"
 	"errors"
-	"github.com/lf-edge/edge-home-orchestration-go/internal/common/logmgr"
-	"os"
+	"io"
 	"os/exec"
 	"strings"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"golang.org/x/sys/unix"
 
+	"github.com/lf-edge/edge-home-orchestration-go/internal/common/logmgr"
 	"github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr"
 	"github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr/executor"
 	"github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr/notification"
+	"github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr/servicelog"
 )
 
+// outputWaitDelay is the time to wait for the output of the exited service to be closed
+const outputWaitDelay = 5 * time.Second
+
 var (
 	logPrefix      = "[nativeexecutor]"
 	log            = logmgr.GetInstance()
 	nativeexecutor = &NativeExecutor{}
+	running        = executor.NewRunningServices()
 )
 
 // NativeExecutor struct
@@ -52,32 +61,57 @@ func GetInstance() *NativeExecutor {
 	return nativeexecutor
 }
 
-// Execute executes native service application
+// Execute executes native service application, it returns when the service exits
 func (t NativeExecutor) Execute(s executor.ServiceExecutionInfo) (err error) {
 	t.ServiceExecutionInfo = s
 
 	log.Println(logPrefix, logmgr.SanitizeUserInput(t.ServiceName), logmgr.SanitizeUserInput(strings.Join(t.ParamStr, " "))) // lgtm [go/log-injection]
 	log.Println(logPrefix, "parameter length :", len(t.ParamStr))
 
-	cmd, pid, err := t.setService()
+	// @Note : capture the output of the service into its log
+	key := servicelog.Key(t.NotificationTargetURL, t.ServiceID)
+	stdout := servicelog.NewWriter(key, servicelog.StreamStdout)
+	stderr := servicelog.NewWriter(key, servicelog.StreamStderr)
+	defer stdout.Close()
+	defer stderr.Close()
+
+	cmd, pid, err := t.setService(stdout, stderr)
 	if err != nil {
+		t.notifyServiceStatus(servicemgr.ConstServiceStatusFailed, -1, "")
 		return
 	}
 
 	log.Println(logPrefix, "Just ran subprocess ", pid)
+	t.notifyServiceStatus(servicemgr.ConstServiceStatusStarted, 0, "")
+
+	var timedOut int32
+	if t.Limits.Timeout > 0 {
+		timer := time.AfterFunc(t.Limits.Timeout, func() {
+			atomic.StoreInt32(&timedOut, 1)
+			cmd.Process.Kill()
+		})
+		defer timer.Stop()
+	}
 
-	executeCh := make(chan error)
-	go func() {
-		executeCh <- cmd.Wait()
-	}()
-
-	status, err := t.waitService(executeCh)
-	t.notifyServiceStatus(status)
+	err = cmd.Wait()
+	status, exitCode, signal := t.waitService(err, atomic.LoadInt32(&timedOut) == 1)
+	stdout.Close()
+	stderr.Close()
+	if running.Remove(t.ServiceExecutionInfo) {
+		status = servicemgr.ConstServiceStatusStopped
+	}
+	t.notifyServiceStatus(status, exitCode, signal)
 
 	return
 }
 
-func (t NativeExecutor) setService() (cmd *exec.Cmd, pid int, err error) {
+// Stop kills the process of the running native service application
+func (t NativeExecutor) Stop(s executor.ServiceExecutionInfo) error {
+	log.Println(logPrefix, "stop service", s.ServiceID)
+	return running.Stop(s)
+}
+
+func (t NativeExecutor) setService(stdoutLog, stderrLog io.Writer) (cmd *exec.Cmd, pid int, err error) {
 	if len(t.ParamStr) < 1 {
 		err = errors.New("error: empty parameter")
 		return
@@ -85,68 +119,69 @@ func (t NativeExecutor) setService() (cmd *exec.Cmd, pid int, err error) {
 
 	cmd = exec.Command(t.ParamStr[0], t.ParamStr[1:]...) // lgtm[go/command-injection]
 
-	// set "owner" account: need to execute user app
-	/*
-		execUser, _ := user.Lookup("owner")
-
-		uid, _ := strconv.Atoi(execUser.Uid)
-		gid, _ := strconv.Atoi(execUser.Gid)
-		groups, _ := execUser.GroupIds()
-
-		var gids = []uint32{}
-		for _, i := range groups {
-			id, _ := strconv.Atoi(i)
-			gids = append(gids, uint32(id))
-		}
-
-		log.Printf("uid(%d), gid(%d)", uid, gid)
-		log.Printf("groupIds: %v", gids)
-
-		cmd.SysProcAttr = &syscall.SysProcAttr{}
-		cmd.SysProcAttr.Credential = &syscall.Credential{
-			Uid:    uint32(uid),
-			Gid:    uint32(gid),
-			Groups: gids,
-		}
-	*/
-
-	stdout, _ := cmd.StdoutPipe()
-	err = cmd.Start()
+	// @Note : the user and the restrictions of the service are set by its .conf file on this device, not by the requester
+	sb, err := newSandbox(cmd, t.ServiceName)
 	if err != nil {
 		log.Println(logPrefix, err.Error())
 		return
 	}
 
-	scanner := bufio.NewScanner(stdout)
-	for scanner.Scan() {
-		m := scanner.Text()
-		log.Println(m)
+	cmd.Stdout = stdoutLog
+	cmd.Stderr = stderrLog
+	// @Note : the orphaned children of the service may keep the output open after it exits
+	cmd.WaitDelay = outputWaitDelay
+	err = sb.start(cmd)
+	if err != nil {
+		log.Println(logPrefix, err.Error())
+		return
 	}
+	running.Add(t.ServiceExecutionInfo, cmd.Process.Kill)
 
 	pid = cmd.Process.Pid
 
 	return
 }
 
-func (t NativeExecutor) waitService(executeCh <-chan error) (status string, e error) {
-	e = <-executeCh
-
-	status = servicemgr.ConstServiceStatusFinished
+// waitService returns the status of the exited service with its exit code,
+// and the name of the signal when the service was terminated by it
+func (t NativeExecutor) waitService(e error, timedOut bool) (status string, exitCode int, signal string) {
+	exitCode, signal = getExitStatus(e)
 
-	if e != nil {
-		if e.Error() == os.Kill.String() {
-			log.Println(logPrefix, "Success to delete service")
-		} else {
-			status = servicemgr.ConstServiceStatusFailed
-			log.Println(logPrefix, logmgr.SanitizeUserInput(t.ServiceName), "exited with error : ", e) // lgtm [go/log-injection]
-		}
-	} else {
+	switch {
+	case e == nil:
+		status = servicemgr.ConstServiceStatusFinished
 		log.Println(logPrefix, logmgr.SanitizeUserInput(t.ServiceName), "is exited with no error") // lgtm [go/log-injection]
+	case timedOut:
+		status = servicemgr.ConstServiceStatusFailed
+		log.Println(logPrefix, logmgr.SanitizeUserInput(t.ServiceName), "is killed by timeout", t.Limits.Timeout) // lgtm [go/log-injection]
+	default:
+		status = servicemgr.ConstServiceStatusFailed
+		log.Println(logPrefix, logmgr.SanitizeUserInput(t.ServiceName), "exited with error : ", e) // lgtm [go/log-injection]
 	}
 
 	return
 }
 
-func (t NativeExecutor) notifyServiceStatus(status string) {
-	t.NotiImplIns.InvokeNotification(t.NotificationTargetURL, float64(t.ServiceID), status)
+func (t NativeExecutor) notifyServiceStatus(status string, exitCode int, signal string) {
+	if len(signal) > 0 {
+		t.NotiImplIns.InvokeSignaled(t.NotificationTargetURL, float64(t.ServiceID), status, exitCode, signal)
+		return
+	}
+	t.NotiImplIns.InvokeNotification(t.NotificationTargetURL, float64(t.ServiceID), status, exitCode)
+}
+
+// getExitStatus returns the exit code of the process from the result of cmd.Wait,
+// a process terminated by a signal has the exit code 128 + the signal number like the shell
+func getExitStatus(e error) (exitCode int, signal string) {
+	if e == nil {
+		return 0, ""
+	}
+	exitErr, ok := e.(*exec.ExitError)
+	if !ok {
+		return -1, ""
+	}
+	if ws, ok := exitErr.Sys().(syscall.WaitStatus); ok && ws.Signaled() {
+		return 128 + int(ws.Signal()), unix.SignalName(ws.Signal())
+	}
+	return exitErr.ExitCode(), ""
 }
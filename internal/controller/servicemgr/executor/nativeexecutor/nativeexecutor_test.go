@@ -19,7 +19,9 @@ package nativeexecutor
 
 import (
 	"testing"
+	"time"
 
+	"github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr"
 	"github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr/executor"
 	notificationMock "github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr/notification/mocks"
 	clientApiMock "github.com/lf-edge/edge-home-orchestration-go/internal/restinterface/client/mocks"
@@ -59,7 +61,8 @@ func TestExecute(t *testing.T) {
 	noti, _ := initializeMock(t)
 
 	gomock.InOrder(
-		noti.EXPECT().InvokeNotification(gomock.Any(), gomock.Any(), gomock.Any()),
+		noti.EXPECT().InvokeNotification(gomock.Any(), gomock.Any(), gomock.Eq(servicemgr.ConstServiceStatusStarted), gomock.Any()),
+		noti.EXPECT().InvokeNotification(gomock.Any(), gomock.Any(), gomock.Eq(servicemgr.ConstServiceStatusFinished), gomock.Eq(0)),
 	)
 
 	s := executor.ServiceExecutionInfo{ServiceID: uint64(1), ServiceName: "ls_service", ParamStr: []string{"ls", "-ail"}, NotificationTargetURL: ""}
@@ -77,7 +80,7 @@ func TestExecuteFailWithEmptyServiceCmd(t *testing.T) {
 
 	noti, _ := initializeMock(t)
 	gomock.InOrder(
-		noti.EXPECT().InvokeNotification(gomock.Any(), gomock.Any(), gomock.Any()),
+		noti.EXPECT().InvokeNotification(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()),
 	)
 
 	s := executor.ServiceExecutionInfo{ServiceID: uint64(1), ServiceName: "ls_service", NotificationTargetURL: ""}
@@ -98,7 +101,7 @@ func TestExecuteFailWithInvalidServiceName(t *testing.T) {
 	noti := notificationMock.NewMockNotification(ctrl)
 
 	gomock.InOrder(
-		noti.EXPECT().InvokeNotification(gomock.Any(), gomock.Any(), gomock.Any()),
+		noti.EXPECT().InvokeNotification(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()),
 	)
 
 	s := executor.ServiceExecutionInfo{ServiceID: uint64(1), ServiceName: "InvalidService", ParamStr: []string{"invalid", "-ail"}, NotificationTargetURL: ""}
@@ -118,7 +121,8 @@ func TestExecuteFailWithInvalidServiceArgs(t *testing.T) {
 	noti := notificationMock.NewMockNotification(ctrl)
 
 	gomock.InOrder(
-		noti.EXPECT().InvokeNotification(gomock.Any(), gomock.Any(), gomock.Any()),
+		noti.EXPECT().InvokeNotification(gomock.Any(), gomock.Any(), gomock.Eq(servicemgr.ConstServiceStatusStarted), gomock.Any()),
+		noti.EXPECT().InvokeNotification(gomock.Any(), gomock.Any(), gomock.Eq(servicemgr.ConstServiceStatusFailed), gomock.Eq(2)),
 	)
 
 	s := executor.ServiceExecutionInfo{ServiceID: uint64(1), ServiceName: "ls", ParamStr: []string{"ls", "InvalidArgs"}, NotificationTargetURL: ""}
@@ -130,3 +134,89 @@ func TestExecuteFailWithInvalidServiceArgs(t *testing.T) {
 		t.Error()
 	}
 }
+
+func TestStop(t *testing.T) {
+	tExecutor := GetInstance()
+	noti, _ := initializeMock(t)
+
+	done := make(chan string, 1)
+	gomock.InOrder(
+		noti.EXPECT().InvokeNotification(gomock.Any(), gomock.Any(), gomock.Eq(servicemgr.ConstServiceStatusStarted), gomock.Any()),
+		noti.EXPECT().InvokeSignaled(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Eq(137), gomock.Eq("SIGKILL")).DoAndReturn(
+			func(target string, serviceID float64, status string, exitCode int, signal string) error {
+				done <- status
+				return nil
+			},
+		),
+	)
+
+	s := executor.ServiceExecutionInfo{ServiceID: uint64(2), ServiceName: "sleep_service", ParamStr: []string{"sleep", "10"}, NotificationTargetURL: ""}
+
+	tExecutor.SetNotiImpl(noti)
+	go tExecutor.Execute(s)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for tExecutor.Stop(s) != nil {
+		if time.Now().After(deadline) {
+			t.Fatal("service is not running")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	select {
+	case status := <-done:
+		if status != servicemgr.ConstServiceStatusStopped {
+			t.Error("unexpected status : ", status)
+		}
+	case <-time.After(5 * time.Second):
+		t.Error("service is not stopped")
+	}
+}
+
+func TestExecuteWithTimeout(t *testing.T) {
+	tExecutor := GetInstance()
+	noti, _ := initializeMock(t)
+
+	gomock.InOrder(
+		noti.EXPECT().InvokeNotification(gomock.Any(), gomock.Any(), gomock.Eq(servicemgr.ConstServiceStatusStarted), gomock.Any()),
+		noti.EXPECT().InvokeSignaled(gomock.Any(), gomock.Any(), gomock.Eq(servicemgr.ConstServiceStatusFailed), gomock.Eq(137), gomock.Eq("SIGKILL")),
+	)
+
+	s := executor.ServiceExecutionInfo{ServiceID: uint64(4), ServiceName: "sleep_service", ParamStr: []string{"sleep", "10"}, NotificationTargetURL: "",
+		Limits: executor.ResourceLimits{Timeout: 100 * time.Millisecond}}
+
+	tExecutor.SetNotiImpl(noti)
+	start := time.Now()
+	if err := tExecutor.Execute(s); err == nil {
+		t.Error("unexpected success")
+	}
+	if time.Since(start) > 5*time.Second {
+		t.Error("service is not killed by timeout")
+	}
+}
+
+func TestExecuteWithSignal(t *testing.T) {
+	tExecutor := GetInstance()
+	noti, _ := initializeMock(t)
+
+	gomock.InOrder(
+		noti.EXPECT().InvokeNotification(gomock.Any(), gomock.Any(), gomock.Eq(servicemgr.ConstServiceStatusStarted), gomock.Any()),
+		noti.EXPECT().InvokeSignaled(gomock.Any(), gomock.Any(), gomock.Eq(servicemgr.ConstServiceStatusFailed), gomock.Eq(143), gomock.Eq("SIGTERM")),
+	)
+
+	s := executor.ServiceExecutionInfo{ServiceID: uint64(5), ServiceName: "sh_service", ParamStr: []string{"sh", "-c", "kill -TERM $$"}, NotificationTargetURL: ""}
+
+	tExecutor.SetNotiImpl(noti)
+	if err := tExecutor.Execute(s); err == nil {
+		t.Error("unexpected success")
+	}
+}
+
+func TestStopFailWithNotRunningService(t *testing.T) {
+	tExecutor := GetInstance()
+
+	s := executor.ServiceExecutionInfo{ServiceID: uint64(3), ServiceName: "ls_service", NotificationTargetURL: ""}
+	if err := tExecutor.Stop(s); err == nil {
+		t.Error()
+	}
+}
@@ -0,0 +1,202 @@
+/*******************************************************************************
+ * Copyright 2020 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+
+package nativeexecutor
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/user"
+	"runtime"
+	"strconv"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr/executor"
+	appDB "github.com/lf-edge/edge-home-orchestration-go/internal/db/bolt/application"
+)
+
+var (
+	appQuery appDB.DBInterface = appDB.Query{}
+
+	namespaceFlags = map[string]uintptr{
+		"ipc":   syscall.CLONE_NEWIPC,
+		"mount": syscall.CLONE_NEWNS,
+		"net":   syscall.CLONE_NEWNET,
+		"pid":   syscall.CLONE_NEWPID,
+		"uts":   syscall.CLONE_NEWUTS,
+	}
+)
+
+type rlimit struct {
+	resource int
+	value    uint64
+}
+
+// sandbox holds the restrictions which are applied when the process of the service is started
+type sandbox struct {
+	noNewPrivs bool
+	rlimits    []rlimit
+}
+
+// newSandbox sets the user, the namespaces and the root directory of the .conf file of the service to the command.
+// When the orchestrator runs as root, the service without RunAsUser runs as executor.UnprivilegedUser,
+// so RunAsUser=root has to be set explicitly to run the service as root.
+func newSandbox(cmd *exec.Cmd, serviceName string) (*sandbox, error) {
+	info, err := appQuery.Get(serviceName)
+	if err != nil {
+		info = appDB.Info{}
+	}
+
+	runAsUser := executor.RunAsUser(info.RunAsUser)
+	if runAsUser != info.RunAsUser {
+		log.Println(logPrefix, serviceName, "has no RunAsUser, it runs as", runAsUser)
+	}
+
+	cmd.SysProcAttr = &syscall.SysProcAttr{}
+	if len(runAsUser) > 0 || len(info.RunAsGroup) > 0 {
+		if cmd.SysProcAttr.Credential, err = lookupCredential(cmd, runAsUser, info.RunAsGroup); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, name := range info.Sandbox.Namespaces {
+		flag, ok := namespaceFlags[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown namespace %s", name)
+		}
+		cmd.SysProcAttr.Cloneflags |= flag
+	}
+	// @Note : the process is confined to the working directory by chroot, the executable and its libraries
+	// have to be at the same paths under it, and the orchestrator needs CAP_SYS_CHROOT
+	if len(info.Sandbox.WorkingDir) > 0 {
+		cmd.SysProcAttr.Chroot = info.Sandbox.WorkingDir
+		cmd.Dir = "/"
+	}
+
+	sb := &sandbox{noNewPrivs: info.Sandbox.NoNewPrivileges}
+	for _, limit := range []rlimit{
+		{unix.RLIMIT_CPU, info.Sandbox.MaxCPUTime},
+		{unix.RLIMIT_AS, info.Sandbox.MaxMemory},
+		{unix.RLIMIT_NOFILE, info.Sandbox.MaxOpenFiles},
+	} {
+		if limit.value > 0 {
+			sb.rlimits = append(sb.rlimits, limit)
+		}
+	}
+	return sb, nil
+}
+
+// lookupCredential returns the credential of the user and the group, the group of the user is used when the group is empty.
+// The environment of the command gets the home directory of the user.
+func lookupCredential(cmd *exec.Cmd, userName, groupName string) (*syscall.Credential, error) {
+	credential := &syscall.Credential{Uid: uint32(os.Getuid()), Gid: uint32(os.Getgid())}
+
+	if len(userName) > 0 {
+		u, err := user.Lookup(userName)
+		if err != nil {
+			return nil, err
+		}
+		uid, _ := strconv.ParseUint(u.Uid, 10, 32)
+		gid, _ := strconv.ParseUint(u.Gid, 10, 32)
+		credential.Uid, credential.Gid = uint32(uid), uint32(gid)
+		cmd.Env = append(os.Environ(), "HOME="+u.HomeDir, "USER="+u.Username, "LOGNAME="+u.Username)
+
+		if len(groupName) == 0 {
+			groups, _ := u.GroupIds()
+			for _, group := range groups {
+				id, _ := strconv.ParseUint(group, 10, 32)
+				credential.Groups = append(credential.Groups, uint32(id))
+			}
+		}
+	}
+
+	if len(groupName) > 0 {
+		g, err := user.LookupGroup(groupName)
+		if err != nil {
+			return nil, err
+		}
+		gid, _ := strconv.ParseUint(g.Gid, 10, 32)
+		credential.Gid = uint32(gid)
+		credential.Groups = []uint32{credential.Gid}
+	}
+	return credential, nil
+}
+
+// start starts the command in the sandbox. The no_new_privs flag is inherited from the thread starting the process,
+// and the rlimits are set while the process is stopped at the exec by ptrace, so both are in place before the service runs.
+func (sb *sandbox) start(cmd *exec.Cmd) error {
+	if !sb.noNewPrivs && len(sb.rlimits) == 0 {
+		return cmd.Start()
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		// @Note : the thread is not unlocked, so it is terminated with the goroutine instead of being reused with no_new_privs
+		runtime.LockOSThread()
+		errCh <- sb.startOnThread(cmd)
+	}()
+	return <-errCh
+}
+
+func (sb *sandbox) startOnThread(cmd *exec.Cmd) error {
+	if sb.noNewPrivs {
+		if err := unix.Prctl(unix.PR_SET_NO_NEW_PRIVS, 1, 0, 0, 0); err != nil {
+			return err
+		}
+	}
+	if len(sb.rlimits) == 0 {
+		return cmd.Start()
+	}
+
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.Ptrace = true
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	pid := cmd.Process.Pid
+	var status syscall.WaitStatus
+	if _, err := syscall.Wait4(pid, &status, 0, nil); err != nil {
+		cmd.Process.Kill()
+		cmd.Wait()
+		return err
+	} else if !status.Stopped() {
+		cmd.Wait()
+		return errors.New("process is not stopped at the exec")
+	}
+
+	for _, limit := range sb.rlimits {
+		if err := unix.Prlimit(pid, limit.resource, &unix.Rlimit{Cur: limit.value, Max: limit.value}, nil); err != nil {
+			cmd.Process.Kill()
+			syscall.PtraceDetach(pid)
+			cmd.Wait()
+			return err
+		}
+	}
+	if err := syscall.PtraceDetach(pid); err != nil {
+		cmd.Process.Kill()
+		cmd.Wait()
+		return err
+	}
+	return nil
+}
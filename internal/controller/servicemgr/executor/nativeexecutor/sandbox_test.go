@@ -0,0 +1,218 @@
+/*******************************************************************************
+* Copyright 2020 Samsung Electronics All Rights Reserved.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+* http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*
+*******************************************************************************/
+
+package nativeexecutor
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"os/user"
+	"strconv"
+	"syscall"
+	"testing"
+	"time"
+
+	types "github.com/lf-edge/edge-home-orchestration-go/internal/common/types/configuremgrtypes"
+	"github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr"
+	"github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr/executor"
+	notificationMock "github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr/notification/mocks"
+	"github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr/servicelog"
+	appDB "github.com/lf-edge/edge-home-orchestration-go/internal/db/bolt/application"
+	appDBMock "github.com/lf-edge/edge-home-orchestration-go/internal/db/bolt/application/mocks"
+
+	"github.com/golang/mock/gomock"
+)
+
+func setAppQuery(t *testing.T, info appDB.Info, err error) func() {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	query := appDBMock.NewMockDBInterface(ctrl)
+	query.EXPECT().Get(gomock.Any()).Return(info, err).AnyTimes()
+
+	appQuery = query
+	return func() {
+		appQuery = appDB.Query{}
+	}
+}
+
+func TestNewSandbox(t *testing.T) {
+	t.Run("NoConf", func(t *testing.T) {
+		defer setAppQuery(t, appDB.Info{}, errors.New("not found"))()
+
+		cmd := exec.Command("ls")
+		sb, err := newSandbox(cmd, "ls")
+		if err != nil || sb.noNewPrivs || len(sb.rlimits) != 0 {
+			t.Error("unexpected sandbox", sb, err)
+		}
+		if os.Geteuid() != 0 {
+			if cmd.SysProcAttr.Credential != nil {
+				t.Error("unexpected credential", cmd.SysProcAttr.Credential)
+			}
+			return
+		}
+		nobody, err := user.Lookup(executor.UnprivilegedUser)
+		if err != nil {
+			t.Skip(err.Error())
+		}
+		if credential := cmd.SysProcAttr.Credential; credential == nil || strconv.Itoa(int(credential.Uid)) != nobody.Uid {
+			t.Error("root is not refused", credential)
+		}
+	})
+	t.Run("RunAsRoot", func(t *testing.T) {
+		if os.Geteuid() != 0 {
+			t.Skip("not root")
+		}
+		defer setAppQuery(t, appDB.Info{RunAsUser: "root"}, nil)()
+
+		cmd := exec.Command("ls")
+		if _, err := newSandbox(cmd, "ls"); err != nil {
+			t.Fatal(err.Error())
+		}
+		if credential := cmd.SysProcAttr.Credential; credential == nil || credential.Uid != 0 {
+			t.Error("unexpected credential", credential)
+		}
+	})
+	t.Run("RunAsUser", func(t *testing.T) {
+		current, err := user.Current()
+		if err != nil {
+			t.Skip(err.Error())
+		}
+		defer setAppQuery(t, appDB.Info{RunAsUser: current.Username}, nil)()
+
+		cmd := exec.Command("ls")
+		if _, err := newSandbox(cmd, "ls"); err != nil {
+			t.Fatal(err.Error())
+		}
+		credential := cmd.SysProcAttr.Credential
+		if credential == nil || credential.Uid != uint32(os.Getuid()) {
+			t.Error("unexpected credential", credential)
+		}
+	})
+	t.Run("UnknownUser", func(t *testing.T) {
+		defer setAppQuery(t, appDB.Info{RunAsUser: "unknown-edge-orchestration-user"}, nil)()
+
+		if _, err := newSandbox(exec.Command("ls"), "ls"); err == nil {
+			t.Error("unexpected success")
+		}
+	})
+	t.Run("Sandbox", func(t *testing.T) {
+		defer setAppQuery(t, appDB.Info{Sandbox: types.Sandbox{
+			Namespaces: []string{"pid", "uts"}, NoNewPrivileges: true, MaxMemory: 1024 * 1024 * 1024, MaxOpenFiles: 64, WorkingDir: "/tmp",
+		}}, nil)()
+
+		cmd := exec.Command("ls")
+		sb, err := newSandbox(cmd, "ls")
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if cmd.SysProcAttr.Cloneflags != syscall.CLONE_NEWPID|syscall.CLONE_NEWUTS || cmd.SysProcAttr.Chroot != "/tmp" || cmd.Dir != "/" {
+			t.Error("unexpected command", cmd.SysProcAttr, cmd.Dir)
+		}
+		if !sb.noNewPrivs || len(sb.rlimits) != 2 {
+			t.Error("unexpected sandbox", sb)
+		}
+	})
+	t.Run("UnknownNamespace", func(t *testing.T) {
+		defer setAppQuery(t, appDB.Info{Sandbox: types.Sandbox{Namespaces: []string{"cgroup"}}}, nil)()
+
+		if _, err := newSandbox(exec.Command("ls"), "ls"); err == nil {
+			t.Error("unexpected success")
+		}
+	})
+}
+
+func TestExecuteInSandbox(t *testing.T) {
+	dir, err := os.MkdirTemp("", "nativeexecutor")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer os.RemoveAll(dir)
+	servicelog.SetLogDir(dir)
+
+	current, err := user.Current()
+	if err != nil {
+		t.Skip(err.Error())
+	}
+	defer setAppQuery(t, appDB.Info{RunAsUser: current.Username,
+		Sandbox: types.Sandbox{NoNewPrivileges: true, MaxOpenFiles: 32}}, nil)()
+
+	tExecutor := GetInstance()
+	noti, _ := initializeMock(t)
+	gomock.InOrder(
+		noti.EXPECT().InvokeNotification(gomock.Any(), gomock.Any(), gomock.Eq(servicemgr.ConstServiceStatusStarted), gomock.Any()),
+		noti.EXPECT().InvokeNotification(gomock.Any(), gomock.Any(), gomock.Eq(servicemgr.ConstServiceStatusFinished), gomock.Eq(0)),
+	)
+
+	s := executor.ServiceExecutionInfo{ServiceID: uint64(6), ServiceName: "sh_service", NotificationTargetURL: "",
+		ParamStr: []string{"sh", "-c", "ulimit -n; grep NoNewPrivs /proc/self/status"}}
+
+	tExecutor.SetNotiImpl(noti)
+	if err := tExecutor.Execute(s); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	expected := []string{"32", "NoNewPrivs:\t1"}
+	entries, _, err := servicelog.Tail(servicelog.Key(s.NotificationTargetURL, s.ServiceID), len(expected), time.Time{})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(entries) != len(expected) {
+		t.Fatal("unexpected output", entries)
+	}
+	for i, entry := range entries {
+		if entry.Message != expected[i] {
+			t.Error("unexpected output", entry.Message)
+		}
+	}
+}
+
+func TestExecuteFailedInSandbox(t *testing.T) {
+	dir, err := os.MkdirTemp("", "nativeexecutor")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer os.RemoveAll(dir)
+	servicelog.SetLogDir(dir)
+
+	tests := map[string]appDB.Info{
+		"UnknownUser": {RunAsUser: "unknown-edge-orchestration-user"},
+		// @Note : the executable is not under the empty working directory
+		"WorkingDir": {Sandbox: types.Sandbox{WorkingDir: dir}},
+	}
+	for name, info := range tests {
+		t.Run(name, func(t *testing.T) {
+			defer setAppQuery(t, info, nil)()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			noti := notificationMock.NewMockNotification(ctrl)
+			noti.EXPECT().InvokeNotification(gomock.Any(), gomock.Any(), gomock.Eq(servicemgr.ConstServiceStatusFailed), gomock.Eq(-1))
+
+			tExecutor := GetInstance()
+
+			s := executor.ServiceExecutionInfo{ServiceID: uint64(7), ServiceName: "sh_service", NotificationTargetURL: "",
+				ParamStr: []string{"sh", "-c", "true"}}
+
+			tExecutor.SetNotiImpl(noti)
+			if err := tExecutor.Execute(s); err == nil {
+				t.Error("unexpected success")
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+/*******************************************************************************
+* Copyright 2020 Samsung Electronics All Rights Reserved.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+* http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*
+*******************************************************************************/
+
+package systemdexecutor
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"github.com/coreos/go-systemd/v22/dbus"
+	godbus "github.com/godbus/dbus/v5"
+
+	"github.com/lf-edge/edge-home-orchestration-go/internal/common/logmgr"
+	"github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr/executor"
+	appDB "github.com/lf-edge/edge-home-orchestration-go/internal/db/bolt/application"
+)
+
+const (
+	// defaultCPUShares is the cpu shares of the default weight
+	defaultCPUShares = 1024
+	// defaultCPUWeight is the CPUWeight of the default weight
+	defaultCPUWeight = 100
+	maxCPUWeight     = 10000
+)
+
+var (
+	appQuery appDB.DBInterface = appDB.Query{}
+
+	// namespaceProperties are the unit properties running the service in the new Linux namespaces of the Sandbox,
+	// the namespace without the property is not supported : PrivatePIDs needs systemd v257 or later
+	namespaceProperties = map[string]string{
+		"ipc":   "PrivateIPC",
+		"mount": "PrivateMounts",
+		"net":   "PrivateNetwork",
+		"pid":   "",
+		"uts":   "ProtectHostname",
+	}
+)
+
+// newProperties returns the properties of the transient unit executing the service.
+// The user, the restart policy and the sandbox are set by the .conf file of the service on this device,
+// the service without RunAsUser runs as executor.UnprivilegedUser when the orchestrator runs as root.
+func newProperties(s executor.ServiceExecutionInfo) ([]dbus.Property, error) {
+	if len(s.ParamStr) < 1 {
+		return nil, errors.New("error: empty parameter")
+	}
+
+	// @Note : the path of ExecStart must be absolute
+	path, err := exec.LookPath(s.ParamStr[0])
+	if err != nil {
+		return nil, err
+	}
+	if path, err = filepath.Abs(path); err != nil {
+		return nil, err
+	}
+
+	properties := []dbus.Property{
+		dbus.PropDescription(fmt.Sprintf("edge-orchestration service %s", s.ServiceName)),
+		dbus.PropType("exec"),
+		dbus.PropExecStart(append([]string{path}, s.ParamStr[1:]...), true),
+		// @Note : the exited unit is kept until its status is read
+		dbus.PropRemainAfterExit(true),
+		newProperty("SyslogIdentifier", s.ServiceName),
+		newProperty("CPUAccounting", true),
+		newProperty("MemoryAccounting", true),
+		newProperty("TasksAccounting", true),
+		newProperty("TimeoutStopUSec", usec(DefaultStopTimeout)),
+	}
+	properties = append(properties, limitProperties(s.Limits)...)
+
+	info, err := appQuery.Get(s.ServiceName)
+	if err != nil {
+		log.Println(logPrefix, logmgr.SanitizeUserInput(s.ServiceName), "has no .conf file") // lgtm [go/log-injection]
+		info = appDB.Info{}
+	}
+
+	confProperties, err := confProperties(info)
+	if err != nil {
+		return nil, err
+	}
+	return append(properties, confProperties...), nil
+}
+
+// limitProperties converts the limits of the request to the cgroup and the runtime limits of the unit
+func limitProperties(limits executor.ResourceLimits) (properties []dbus.Property) {
+	if limits.CPUShares > 0 {
+		weight := limits.CPUShares * defaultCPUWeight / defaultCPUShares
+		switch {
+		case weight < 1:
+			weight = 1
+		case weight > maxCPUWeight:
+			weight = maxCPUWeight
+		}
+		properties = append(properties, newProperty("CPUWeight", uint64(weight)))
+	}
+	if limits.Memory > 0 {
+		properties = append(properties, newProperty("MemoryMax", uint64(limits.Memory)))
+	}
+	if limits.Timeout > 0 {
+		properties = append(properties, newProperty("RuntimeMaxUSec", usec(limits.Timeout)))
+	}
+	return
+}
+
+// confProperties converts the user, the restart policy and the sandbox of the .conf file to the properties of the unit
+func confProperties(info appDB.Info) (properties []dbus.Property, err error) {
+	if runAsUser := executor.RunAsUser(info.RunAsUser); len(runAsUser) > 0 {
+		properties = append(properties, newProperty("User", runAsUser))
+	}
+	if len(info.RunAsGroup) > 0 {
+		properties = append(properties, newProperty("Group", info.RunAsGroup))
+	}
+	if len(info.Restart) > 0 {
+		properties = append(properties, newProperty("Restart", info.Restart))
+	}
+
+	for _, name := range info.Sandbox.Namespaces {
+		property, ok := namespaceProperties[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown namespace %s", name)
+		} else if len(property) == 0 {
+			return nil, fmt.Errorf("unsupported namespace %s", name)
+		}
+		properties = append(properties, newProperty(property, true))
+	}
+	if info.Sandbox.NoNewPrivileges {
+		properties = append(properties, newProperty("NoNewPrivileges", true))
+	}
+	if len(info.Sandbox.WorkingDir) > 0 {
+		properties = append(properties, newProperty("RootDirectory", info.Sandbox.WorkingDir), newProperty("WorkingDirectory", "/"))
+	}
+	for _, limit := range []struct {
+		name  string
+		value uint64
+	}{
+		{"LimitCPU", info.Sandbox.MaxCPUTime},
+		{"LimitAS", info.Sandbox.MaxMemory},
+		{"LimitNOFILE", info.Sandbox.MaxOpenFiles},
+	} {
+		if limit.value > 0 {
+			properties = append(properties, newProperty(limit.name, limit.value))
+		}
+	}
+	return
+}
+
+func newProperty(name string, value interface{}) dbus.Property {
+	return dbus.Property{Name: name, Value: godbus.MakeVariant(value)}
+}
+
+func usec(d time.Duration) uint64 {
+	return uint64(d / time.Microsecond)
+}
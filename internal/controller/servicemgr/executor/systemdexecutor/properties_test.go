@@ -0,0 +1,142 @@
+/*******************************************************************************
+* Copyright 2020 Samsung Electronics All Rights Reserved.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+* http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*
+*******************************************************************************/
+
+package systemdexecutor
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/coreos/go-systemd/v22/dbus"
+	"github.com/golang/mock/gomock"
+
+	types "github.com/lf-edge/edge-home-orchestration-go/internal/common/types/configuremgrtypes"
+	"github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr/executor"
+	appDB "github.com/lf-edge/edge-home-orchestration-go/internal/db/bolt/application"
+	appDBMock "github.com/lf-edge/edge-home-orchestration-go/internal/db/bolt/application/mocks"
+)
+
+func setAppQuery(t *testing.T, info appDB.Info, err error) func() {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	query := appDBMock.NewMockDBInterface(ctrl)
+	query.EXPECT().Get(gomock.Any()).Return(info, err).AnyTimes()
+
+	appQuery = query
+	return func() {
+		appQuery = appDB.Query{}
+	}
+}
+
+func findProperty(properties []dbus.Property, name string) (interface{}, bool) {
+	for _, property := range properties {
+		if property.Name == name {
+			return property.Value.Value(), true
+		}
+	}
+	return nil, false
+}
+
+func TestNewProperties(t *testing.T) {
+	t.Run("NoConf", func(t *testing.T) {
+		defer setAppQuery(t, appDB.Info{}, errors.New("not found"))()
+
+		properties, err := newProperties(executor.ServiceExecutionInfo{ServiceName: "ls", ParamStr: []string{"ls", "-ail"}})
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if value, _ := findProperty(properties, "RemainAfterExit"); value != true {
+			t.Error("unexpected RemainAfterExit", value)
+		}
+		if value, _ := findProperty(properties, "SyslogIdentifier"); value != "ls" {
+			t.Error("unexpected SyslogIdentifier", value)
+		}
+		if value, ok := findProperty(properties, "User"); os.Geteuid() != 0 && ok {
+			t.Error("unexpected User", value)
+		} else if os.Geteuid() == 0 && value != executor.UnprivilegedUser {
+			t.Error("root is not refused", value)
+		}
+	})
+	t.Run("Limits", func(t *testing.T) {
+		defer setAppQuery(t, appDB.Info{}, errors.New("not found"))()
+
+		properties, err := newProperties(executor.ServiceExecutionInfo{ServiceName: "ls", ParamStr: []string{"ls"},
+			Limits: executor.ResourceLimits{CPUShares: 512, Memory: 256 * 1024 * 1024, Timeout: 5 * time.Second}})
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		expected := map[string]interface{}{
+			"CPUWeight":      uint64(50),
+			"MemoryMax":      uint64(256 * 1024 * 1024),
+			"RuntimeMaxUSec": uint64(5000000),
+		}
+		for name, value := range expected {
+			if actual, _ := findProperty(properties, name); actual != value {
+				t.Error("unexpected", name, actual)
+			}
+		}
+	})
+	t.Run("Conf", func(t *testing.T) {
+		defer setAppQuery(t, appDB.Info{RunAsUser: "edge", RunAsGroup: "edge", Restart: "on-failure", Sandbox: types.Sandbox{
+			Namespaces: []string{"net"}, NoNewPrivileges: true, MaxOpenFiles: 64, WorkingDir: "/tmp",
+		}}, nil)()
+
+		properties, err := newProperties(executor.ServiceExecutionInfo{ServiceName: "ls", ParamStr: []string{"ls"}})
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		expected := map[string]interface{}{
+			"User":             "edge",
+			"Group":            "edge",
+			"Restart":          "on-failure",
+			"PrivateNetwork":   true,
+			"NoNewPrivileges":  true,
+			"LimitNOFILE":      uint64(64),
+			"RootDirectory":    "/tmp",
+			"WorkingDirectory": "/",
+		}
+		for name, value := range expected {
+			if actual, _ := findProperty(properties, name); actual != value {
+				t.Error("unexpected", name, actual)
+			}
+		}
+	})
+	t.Run("UnknownNamespace", func(t *testing.T) {
+		defer setAppQuery(t, appDB.Info{Sandbox: types.Sandbox{Namespaces: []string{"cgroup"}}}, nil)()
+
+		if _, err := newProperties(executor.ServiceExecutionInfo{ServiceName: "ls", ParamStr: []string{"ls"}}); err == nil {
+			t.Error("unexpected success")
+		}
+	})
+	t.Run("UnsupportedNamespace", func(t *testing.T) {
+		defer setAppQuery(t, appDB.Info{Sandbox: types.Sandbox{Namespaces: []string{"pid"}}}, nil)()
+
+		if _, err := newProperties(executor.ServiceExecutionInfo{ServiceName: "ls", ParamStr: []string{"ls"}}); err == nil {
+			t.Error("unexpected success")
+		}
+	})
+	t.Run("NotFoundCommand", func(t *testing.T) {
+		defer setAppQuery(t, appDB.Info{}, errors.New("not found"))()
+
+		if _, err := newProperties(executor.ServiceExecutionInfo{ServiceName: "invalid", ParamStr: []string{"invalid"}}); err == nil {
+			t.Error("unexpected success")
+		}
+	})
+}
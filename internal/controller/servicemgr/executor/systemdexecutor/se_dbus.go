@@ -0,0 +1,128 @@
+/*******************************************************************************
+* Copyright 2020 Samsung Electronics All Rights Reserved.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+* http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*
+*******************************************************************************/
+
+package systemdexecutor
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/coreos/go-systemd/v22/dbus"
+)
+
+const (
+	jobDone = "done"
+
+	stateActive    = "active"
+	stateInactive  = "inactive"
+	stateFailed    = "failed"
+	subStateExited = "exited"
+)
+
+// UnitStatus is the state of the transient unit of the service and of its main process
+type UnitStatus struct {
+	ActiveState string
+	SubState    string
+	// Result is the reason of the failure of the unit, such as exit-code, signal, timeout and oom-kill
+	Result string
+	// ExecMainCode is the si_code of the exit of the main process : CLD_EXITED, CLD_KILLED or CLD_DUMPED
+	ExecMainCode int32
+	// ExecMainStatus is the exit code or the signal number of the main process
+	ExecMainStatus int32
+}
+
+// SEImpl is the interface implemented by systemd execution functions
+type SEImpl interface {
+	Start(name string, properties []dbus.Property) error
+	Status(name string) (UnitStatus, error)
+	Stop(name string) error
+	Remove(name string) error
+}
+
+// SEDbus structure
+type SEDbus struct {
+	ctx  context.Context
+	conn *dbus.Conn
+}
+
+func newSEDbus() (SEImpl, error) {
+	ctx := context.Background()
+	conn, err := dbus.NewSystemConnectionContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &SEDbus{ctx, conn}, nil
+}
+
+// Start starts the transient unit, it returns after the main process is executed
+func (se SEDbus) Start(name string, properties []dbus.Property) error {
+	ch := make(chan string, 1)
+	if _, err := se.conn.StartTransientUnitContext(se.ctx, name, "fail", properties, ch); err != nil {
+		return err
+	}
+	if result := <-ch; result != jobDone {
+		return fmt.Errorf("start job of %s is %s", name, result)
+	}
+	return nil
+}
+
+// Status returns the state of the unit, the unit which is not loaded is inactive
+func (se SEDbus) Status(name string) (status UnitStatus, err error) {
+	unitProperties, err := se.conn.GetUnitPropertiesContext(se.ctx, name)
+	if err != nil {
+		return
+	}
+	serviceProperties, err := se.conn.GetUnitTypePropertiesContext(se.ctx, name, "Service")
+	if err != nil {
+		return
+	}
+
+	status.ActiveState, _ = unitProperties["ActiveState"].(string)
+	status.SubState, _ = unitProperties["SubState"].(string)
+	status.Result, _ = serviceProperties["Result"].(string)
+	status.ExecMainCode, _ = serviceProperties["ExecMainCode"].(int32)
+	status.ExecMainStatus, _ = serviceProperties["ExecMainStatus"].(int32)
+	return
+}
+
+// Stop stops the unit, the processes of its control group are terminated and killed after the stop timeout
+func (se SEDbus) Stop(name string) error {
+	ch := make(chan string, 1)
+	if _, err := se.conn.StopUnitContext(se.ctx, name, "replace", ch); err != nil {
+		return err
+	}
+	if result := <-ch; result != jobDone {
+		return fmt.Errorf("stop job of %s is %s", name, result)
+	}
+	return nil
+}
+
+// Remove unloads the unit which is kept by RemainAfterExit or by its failure
+func (se SEDbus) Remove(name string) error {
+	status, err := se.Status(name)
+	if err != nil {
+		return err
+	}
+
+	switch status.ActiveState {
+	case stateInactive:
+		return nil
+	case stateFailed:
+		return se.conn.ResetFailedUnitContext(se.ctx, name)
+	}
+	return se.Stop(name)
+}
@@ -0,0 +1,219 @@
+/*******************************************************************************
+ * Copyright 2020 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+
+// Package systemdexecutor provides functions to execute native service application as transient systemd unit
+package systemdexecutor
+
+import (
+	"fmt"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/coreos/go-systemd/v22/unit"
+	"golang.org/x/sys/unix"
+
+	"github.com/lf-edge/edge-home-orchestration-go/internal/common/logmgr"
+	"github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr"
+	"github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr/executor"
+	"github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr/notification"
+)
+
+const (
+	// Name is the name of the executor which is selected for the native services instead of nativeexecutor
+	Name = "systemd"
+
+	// DefaultStopTimeout is the time to wait for the processes of the unit to stop before killing them
+	DefaultStopTimeout = 10 * time.Second
+
+	unitPrefix  = "edge-orchestration-"
+	localTarget = "local"
+
+	// si_code of the exit of the main process
+	cldExited = 1
+	cldKilled = 2
+	cldDumped = 3
+
+	resultSuccess = "success"
+	resultTimeout = "timeout"
+	resultOOMKill = "oom-kill"
+)
+
+var (
+	logPrefix       = "[systemdexecutor]"
+	log             = logmgr.GetInstance()
+	systemdexecutor = &SystemdExecutor{}
+	running         = executor.NewRunningServices()
+
+	// statusInterval is the interval of reading the state of the running unit
+	statusInterval = 500 * time.Millisecond
+)
+
+// SystemdExecutor struct
+type SystemdExecutor struct {
+	executor.ServiceExecutionInfo
+
+	seImplIns SEImpl
+	executor.HasClientNotification
+}
+
+func init() {
+	systemdexecutor.SetNotiImpl(notification.GetInstance())
+}
+
+// GetInstance returns the single tone SystemdExecutor instance
+func GetInstance() *SystemdExecutor {
+	return systemdexecutor
+}
+
+// NewSEImpl returns the executor implementation connected to systemd by the system bus
+func NewSEImpl() (SEImpl, error) {
+	return newSEDbus()
+}
+
+// SetSEImpl sets executor implementation
+func (t *SystemdExecutor) SetSEImpl(se SEImpl) {
+	t.seImplIns = se
+}
+
+// Execute executes native service application as transient systemd unit, it returns when the main process of the unit exits.
+// The output of the service is written to the journal with its service name as the identifier.
+func (t SystemdExecutor) Execute(s executor.ServiceExecutionInfo) (err error) {
+	t.ServiceExecutionInfo = s
+
+	log.Println(logPrefix, logmgr.SanitizeUserInput(t.ServiceName), logmgr.SanitizeUserInput(strings.Join(t.ParamStr, " "))) // lgtm [go/log-injection]
+
+	properties, err := newProperties(t.ServiceExecutionInfo)
+	if err != nil {
+		log.Println(logPrefix, err.Error())
+		t.notifyServiceStatus(servicemgr.ConstServiceStatusFailed, -1, "")
+		return
+	}
+
+	name := unitName(t.ServiceExecutionInfo)
+	if err = t.seImplIns.Start(name, properties); err != nil {
+		log.Println(logPrefix, err.Error())
+		t.removeUnit(name)
+		t.notifyServiceStatus(servicemgr.ConstServiceStatusFailed, -1, "")
+		return
+	}
+	running.Add(t.ServiceExecutionInfo, func() error {
+		return t.seImplIns.Stop(name)
+	})
+
+	log.Println(logPrefix, "Just started unit", name)
+	t.notifyServiceStatus(servicemgr.ConstServiceStatusStarted, 0, "")
+
+	unitStatus, err := t.waitUnit(name)
+	status, exitCode, signal := t.exitStatus(unitStatus, err)
+	if running.Remove(t.ServiceExecutionInfo) {
+		status = servicemgr.ConstServiceStatusStopped
+	}
+	t.notifyServiceStatus(status, exitCode, signal)
+	t.removeUnit(name)
+
+	if err == nil && status != servicemgr.ConstServiceStatusFinished {
+		err = fmt.Errorf("%s is %s with exit code %d", name, status, exitCode)
+	}
+	return
+}
+
+// Stop stops the unit of the running native service application
+func (t SystemdExecutor) Stop(s executor.ServiceExecutionInfo) error {
+	log.Println(logPrefix, "stop service", s.ServiceID)
+	return running.Stop(s)
+}
+
+// waitUnit returns the state of the unit after its main process exits, the unit restarted by its restart policy is waited again
+func (t SystemdExecutor) waitUnit(name string) (UnitStatus, error) {
+	for {
+		status, err := t.seImplIns.Status(name)
+		if err != nil || status.exited() {
+			return status, err
+		}
+		time.Sleep(statusInterval)
+	}
+}
+
+// exitStatus returns the status of the exited unit with the exit code of its main process,
+// and the name of the signal when the process was terminated by it
+func (t SystemdExecutor) exitStatus(unitStatus UnitStatus, e error) (status string, exitCode int, signal string) {
+	if e != nil {
+		log.Println(logPrefix, logmgr.SanitizeUserInput(t.ServiceName), "is lost : ", e) // lgtm [go/log-injection]
+		return servicemgr.ConstServiceStatusFailed, -1, ""
+	}
+
+	switch unitStatus.ExecMainCode {
+	case cldExited:
+		exitCode = int(unitStatus.ExecMainStatus)
+	case cldKilled, cldDumped:
+		exitCode = 128 + int(unitStatus.ExecMainStatus)
+		signal = unix.SignalName(syscall.Signal(unitStatus.ExecMainStatus))
+	default:
+		exitCode = -1
+	}
+
+	switch {
+	case unitStatus.Result == resultSuccess && exitCode == 0:
+		status = servicemgr.ConstServiceStatusFinished
+		log.Println(logPrefix, logmgr.SanitizeUserInput(t.ServiceName), "is exited with no error") // lgtm [go/log-injection]
+	case unitStatus.Result == resultTimeout:
+		status = servicemgr.ConstServiceStatusFailed
+		log.Println(logPrefix, logmgr.SanitizeUserInput(t.ServiceName), "is stopped by timeout", t.Limits.Timeout) // lgtm [go/log-injection]
+	case unitStatus.Result == resultOOMKill:
+		status = servicemgr.ConstServiceStatusFailed
+		log.Println(logPrefix, logmgr.SanitizeUserInput(t.ServiceName), "is killed by out of memory") // lgtm [go/log-injection]
+	default:
+		status = servicemgr.ConstServiceStatusFailed
+		log.Println(logPrefix, logmgr.SanitizeUserInput(t.ServiceName), "exited with error : ", unitStatus.Result, exitCode) // lgtm [go/log-injection]
+	}
+	return
+}
+
+func (t SystemdExecutor) removeUnit(name string) {
+	if err := t.seImplIns.Remove(name); err != nil {
+		log.Println(logPrefix, "cannot remove unit", name, ":", err.Error())
+	}
+}
+
+func (t SystemdExecutor) notifyServiceStatus(status string, exitCode int, signal string) {
+	if len(signal) > 0 {
+		t.NotiImplIns.InvokeSignaled(t.NotificationTargetURL, float64(t.ServiceID), status, exitCode, signal)
+		return
+	}
+	t.NotiImplIns.InvokeNotification(t.NotificationTargetURL, float64(t.ServiceID), status, exitCode)
+}
+
+// exited reports whether the main process of the unit exited, the unit with RemainAfterExit is active after it
+func (status UnitStatus) exited() bool {
+	switch status.ActiveState {
+	case stateInactive, stateFailed:
+		return true
+	case stateActive:
+		return status.SubState == subStateExited
+	}
+	return false
+}
+
+// unitName returns the name of the unit, which distinguishes services with the same ID requested by different devices
+func unitName(s executor.ServiceExecutionInfo) string {
+	target := s.NotificationTargetURL
+	if len(target) == 0 {
+		target = localTarget
+	}
+	return fmt.Sprintf("%s%s-%d.service", unitPrefix, unit.UnitNameEscape(target), s.ServiceID)
+}
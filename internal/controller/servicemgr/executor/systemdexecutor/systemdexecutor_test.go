@@ -0,0 +1,247 @@
+/*******************************************************************************
+* Copyright 2020 Samsung Electronics All Rights Reserved.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+* http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*
+*******************************************************************************/
+
+package systemdexecutor
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/coreos/go-systemd/v22/dbus"
+	"github.com/golang/mock/gomock"
+
+	"github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr"
+	"github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr/executor"
+	notificationMock "github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr/notification/mocks"
+	appDB "github.com/lf-edge/edge-home-orchestration-go/internal/db/bolt/application"
+	appDBMock "github.com/lf-edge/edge-home-orchestration-go/internal/db/bolt/application/mocks"
+)
+
+// fakeSE is the systemd of the tests, the unit exits with its status after it is read running
+type fakeSE struct {
+	sync.Mutex
+	startErr error
+	exit     UnitStatus
+	stopped  chan struct{}
+	started  []string
+	removed  []string
+}
+
+func newFakeSE(exit UnitStatus) *fakeSE {
+	return &fakeSE{exit: exit, stopped: make(chan struct{})}
+}
+
+func (se *fakeSE) Start(name string, properties []dbus.Property) error {
+	se.Lock()
+	defer se.Unlock()
+
+	se.started = append(se.started, name)
+	return se.startErr
+}
+
+func (se *fakeSE) Status(name string) (UnitStatus, error) {
+	if se.exit.ActiveState == stateActive && se.exit.SubState != subStateExited {
+		// @Note : the unit runs until it is stopped
+		<-se.stopped
+		return UnitStatus{ActiveState: stateInactive, Result: resultSuccess, ExecMainCode: cldKilled, ExecMainStatus: int32(15)}, nil
+	}
+	return se.exit, nil
+}
+
+func (se *fakeSE) Stop(name string) error {
+	close(se.stopped)
+	return nil
+}
+
+func (se *fakeSE) Remove(name string) error {
+	se.Lock()
+	defer se.Unlock()
+
+	se.removed = append(se.removed, name)
+	return nil
+}
+
+func initializeMock(t *testing.T, se SEImpl) *notificationMock.MockNotification {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+	noti := notificationMock.NewMockNotification(ctrl)
+	query := appDBMock.NewMockDBInterface(ctrl)
+	query.EXPECT().Get(gomock.Any()).Return(appDB.Info{}, errors.New("not found")).AnyTimes()
+	appQuery = query
+
+	GetInstance().SetNotiImpl(noti)
+	GetInstance().SetSEImpl(se)
+	return noti
+}
+
+func TestExecute(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		se := newFakeSE(UnitStatus{ActiveState: stateActive, SubState: subStateExited, Result: resultSuccess, ExecMainCode: cldExited})
+		noti := initializeMock(t, se)
+		gomock.InOrder(
+			noti.EXPECT().InvokeNotification(gomock.Any(), gomock.Any(), gomock.Eq(servicemgr.ConstServiceStatusStarted), gomock.Any()),
+			noti.EXPECT().InvokeNotification(gomock.Any(), gomock.Any(), gomock.Eq(servicemgr.ConstServiceStatusFinished), gomock.Eq(0)),
+		)
+
+		s := executor.ServiceExecutionInfo{ServiceID: uint64(1), ServiceName: "ls_service", ParamStr: []string{"ls", "-ail"}}
+		if err := GetInstance().Execute(s); err != nil {
+			t.Fatal(err.Error())
+		}
+		if len(se.started) != 1 || se.started[0] != "edge-orchestration-local-1.service" || len(se.removed) != 1 {
+			t.Error("unexpected unit", se.started, se.removed)
+		}
+	})
+	t.Run("ExitCode", func(t *testing.T) {
+		se := newFakeSE(UnitStatus{ActiveState: stateFailed, SubState: stateFailed, Result: "exit-code", ExecMainCode: cldExited, ExecMainStatus: 2})
+		noti := initializeMock(t, se)
+		gomock.InOrder(
+			noti.EXPECT().InvokeNotification(gomock.Any(), gomock.Any(), gomock.Eq(servicemgr.ConstServiceStatusStarted), gomock.Any()),
+			noti.EXPECT().InvokeNotification(gomock.Any(), gomock.Any(), gomock.Eq(servicemgr.ConstServiceStatusFailed), gomock.Eq(2)),
+		)
+
+		s := executor.ServiceExecutionInfo{ServiceID: uint64(2), ServiceName: "ls", ParamStr: []string{"ls", "InvalidArgs"}}
+		if err := GetInstance().Execute(s); err == nil {
+			t.Error("unexpected success")
+		}
+	})
+	t.Run("Timeout", func(t *testing.T) {
+		se := newFakeSE(UnitStatus{ActiveState: stateFailed, SubState: stateFailed, Result: resultTimeout, ExecMainCode: cldKilled, ExecMainStatus: 15})
+		noti := initializeMock(t, se)
+		gomock.InOrder(
+			noti.EXPECT().InvokeNotification(gomock.Any(), gomock.Any(), gomock.Eq(servicemgr.ConstServiceStatusStarted), gomock.Any()),
+			noti.EXPECT().InvokeSignaled(gomock.Any(), gomock.Any(), gomock.Eq(servicemgr.ConstServiceStatusFailed), gomock.Eq(143), gomock.Eq("SIGTERM")),
+		)
+
+		s := executor.ServiceExecutionInfo{ServiceID: uint64(3), ServiceName: "sleep_service", ParamStr: []string{"sleep", "10"},
+			Limits: executor.ResourceLimits{Timeout: 100 * time.Millisecond}}
+		if err := GetInstance().Execute(s); err == nil {
+			t.Error("unexpected success")
+		}
+	})
+	t.Run("StartFail", func(t *testing.T) {
+		se := newFakeSE(UnitStatus{})
+		se.startErr = errors.New("start job is failed")
+		noti := initializeMock(t, se)
+		noti.EXPECT().InvokeNotification(gomock.Any(), gomock.Any(), gomock.Eq(servicemgr.ConstServiceStatusFailed), gomock.Eq(-1))
+
+		s := executor.ServiceExecutionInfo{ServiceID: uint64(4), ServiceName: "ls_service", ParamStr: []string{"ls"}}
+		if err := GetInstance().Execute(s); err == nil {
+			t.Error("unexpected success")
+		}
+		if len(se.removed) != 1 {
+			t.Error("failed unit is not removed")
+		}
+	})
+	t.Run("EmptyServiceCmd", func(t *testing.T) {
+		se := newFakeSE(UnitStatus{})
+		noti := initializeMock(t, se)
+		noti.EXPECT().InvokeNotification(gomock.Any(), gomock.Any(), gomock.Eq(servicemgr.ConstServiceStatusFailed), gomock.Eq(-1))
+
+		s := executor.ServiceExecutionInfo{ServiceID: uint64(5), ServiceName: "ls_service"}
+		if err := GetInstance().Execute(s); err == nil {
+			t.Error("unexpected success")
+		}
+		if len(se.started) != 0 {
+			t.Error("unexpected unit", se.started)
+		}
+	})
+	t.Run("InvalidServiceName", func(t *testing.T) {
+		se := newFakeSE(UnitStatus{})
+		noti := initializeMock(t, se)
+		noti.EXPECT().InvokeNotification(gomock.Any(), gomock.Any(), gomock.Eq(servicemgr.ConstServiceStatusFailed), gomock.Eq(-1))
+
+		s := executor.ServiceExecutionInfo{ServiceID: uint64(6), ServiceName: "InvalidService", ParamStr: []string{"invalid", "-ail"}}
+		if err := GetInstance().Execute(s); err == nil {
+			t.Error("unexpected success")
+		}
+	})
+}
+
+func TestStop(t *testing.T) {
+	se := newFakeSE(UnitStatus{ActiveState: stateActive, SubState: "running"})
+	noti := initializeMock(t, se)
+
+	done := make(chan string, 1)
+	gomock.InOrder(
+		noti.EXPECT().InvokeNotification(gomock.Any(), gomock.Any(), gomock.Eq(servicemgr.ConstServiceStatusStarted), gomock.Any()),
+		noti.EXPECT().InvokeSignaled(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Eq(143), gomock.Eq("SIGTERM")).DoAndReturn(
+			func(target string, serviceID float64, status string, exitCode int, signal string) error {
+				done <- status
+				return nil
+			},
+		),
+	)
+
+	s := executor.ServiceExecutionInfo{ServiceID: uint64(7), ServiceName: "sleep_service", ParamStr: []string{"sleep", "10"}}
+	go GetInstance().Execute(s)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for GetInstance().Stop(s) != nil {
+		if time.Now().After(deadline) {
+			t.Fatal("service is not running")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	select {
+	case status := <-done:
+		if status != servicemgr.ConstServiceStatusStopped {
+			t.Error("unexpected status : ", status)
+		}
+	case <-time.After(5 * time.Second):
+		t.Error("service is not stopped")
+	}
+}
+
+func TestStopFailWithNotRunningService(t *testing.T) {
+	s := executor.ServiceExecutionInfo{ServiceID: uint64(8), ServiceName: "ls_service"}
+	if err := GetInstance().Stop(s); err == nil {
+		t.Error("unexpected success")
+	}
+}
+
+func TestUnitName(t *testing.T) {
+	s := executor.ServiceExecutionInfo{ServiceID: uint64(9), NotificationTargetURL: "192.168.1.2"}
+	if name := unitName(s); name != "edge-orchestration-192.168.1.2-9.service" {
+		t.Error("unexpected name : ", name)
+	}
+}
+
+func TestExited(t *testing.T) {
+	tests := map[string]struct {
+		status   UnitStatus
+		expected bool
+	}{
+		"Running":      {UnitStatus{ActiveState: stateActive, SubState: "running"}, false},
+		"Exited":       {UnitStatus{ActiveState: stateActive, SubState: subStateExited}, true},
+		"Failed":       {UnitStatus{ActiveState: stateFailed, SubState: stateFailed}, true},
+		"Restarting":   {UnitStatus{ActiveState: "activating", SubState: "auto-restart"}, false},
+		"NotLoaded":    {UnitStatus{ActiveState: stateInactive, SubState: "dead"}, true},
+		"Deactivating": {UnitStatus{ActiveState: "deactivating", SubState: "stop-sigterm"}, false},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if test.status.exited() != test.expected {
+				t.Error("unexpected result")
+			}
+		})
+	}
+}
@@ -0,0 +1,5 @@
+;; loop.wasm : runs until it is stopped
+(module
+  (memory (export "memory") 1)
+  (func (export "_start")
+    (loop (br 0))))
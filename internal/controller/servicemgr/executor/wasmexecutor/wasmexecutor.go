@@ -0,0 +1,219 @@
+/*******************************************************************************
+ * Copyright 2020 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+
+// Package wasmexecutor provides functions to execute WebAssembly (WASI) service application
+package wasmexecutor
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/imports/wasi_snapshot_preview1"
+	"github.com/tetratelabs/wazero/sys"
+
+	"github.com/lf-edge/edge-home-orchestration-go/internal/common/logmgr"
+	"github.com/lf-edge/edge-home-orchestration-go/internal/controller/securemgr/verifier"
+	"github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr"
+	"github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr/executor"
+	"github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr/notification"
+	"github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr/servicelog"
+	appDB "github.com/lf-edge/edge-home-orchestration-go/internal/db/bolt/application"
+)
+
+const (
+	// ExecutionType is the execution type of the WebAssembly service application
+	ExecutionType = "wasm"
+
+	// wasmPageSize is the size of a page of the memory of the module
+	wasmPageSize = 64 * 1024
+	// maxMemoryPages is the number of the pages of the 4GiB memory, the maximum of the module
+	maxMemoryPages = 65536
+	// guestAppDir is the path of the read-only directory of the service in the module
+	guestAppDir = "/app"
+)
+
+var (
+	logPrefix    = "[wasmexecutor]"
+	log          = logmgr.GetInstance()
+	wasmexecutor = &WasmExecutor{}
+	running      = executor.NewRunningServices()
+
+	appQuery appDB.DBInterface = appDB.Query{}
+
+	// compilationCache keeps the compiled modules in memory, the module executed again starts without compiling
+	compilationCache = wazero.NewCompilationCache()
+)
+
+// WasmExecutor struct
+type WasmExecutor struct {
+	executor.ServiceExecutionInfo
+	executor.HasClientNotification
+}
+
+func init() {
+	wasmexecutor.SetNotiImpl(notification.GetInstance())
+}
+
+// GetInstance returns the single tone WasmExecutor instance
+func GetInstance() *WasmExecutor {
+	return wasmexecutor
+}
+
+// Execute runs the WASI module of the service application in the pure Go runtime, it returns when the module exits.
+// The first parameter is the module file in the directory of the .conf file of the service, the others are its arguments.
+func (t WasmExecutor) Execute(s executor.ServiceExecutionInfo) (err error) {
+	t.ServiceExecutionInfo = s
+
+	log.Println(logPrefix, logmgr.SanitizeUserInput(t.ServiceName), logmgr.SanitizeUserInput(strings.Join(t.ParamStr, " "))) // lgtm [go/log-injection]
+
+	appDir, module, err := t.loadModule()
+	if err != nil {
+		log.Println(logPrefix, err.Error())
+		t.notifyServiceStatus(servicemgr.ConstServiceStatusFailed, -1)
+		return
+	}
+
+	runtimeConfig := wazero.NewRuntimeConfig().WithCloseOnContextDone(true).WithCompilationCache(compilationCache)
+	if pages := t.Limits.Memory / wasmPageSize; pages > 0 && pages <= maxMemoryPages {
+		runtimeConfig = runtimeConfig.WithMemoryLimitPages(uint32(pages))
+	}
+	r := wazero.NewRuntimeWithConfig(context.Background(), runtimeConfig)
+	defer r.Close(context.Background())
+
+	if _, err = wasi_snapshot_preview1.Instantiate(context.Background(), r); err != nil {
+		log.Println(logPrefix, err.Error())
+		t.notifyServiceStatus(servicemgr.ConstServiceStatusFailed, -1)
+		return
+	}
+	compiled, err := r.CompileModule(context.Background(), module)
+	if err != nil {
+		log.Println(logPrefix, "cannot compile", logmgr.SanitizeUserInput(t.ParamStr[0]), ":", err.Error()) // lgtm [go/log-injection]
+		t.notifyServiceStatus(servicemgr.ConstServiceStatusFailed, -1)
+		return
+	}
+
+	// @Note : capture the output of the service into its log
+	key := servicelog.Key(t.NotificationTargetURL, t.ServiceID)
+	stdout := servicelog.NewWriter(key, servicelog.StreamStdout)
+	stderr := servicelog.NewWriter(key, servicelog.StreamStderr)
+	defer stdout.Close()
+	defer stderr.Close()
+
+	moduleConfig := wazero.NewModuleConfig().
+		WithName("").
+		WithArgs(t.ParamStr...).
+		WithStdout(stdout).
+		WithStderr(stderr).
+		WithSysWalltime().
+		WithSysNanotime().
+		WithSysNanosleep().
+		WithFSConfig(wazero.NewFSConfig().WithReadOnlyDirMount(appDir, guestAppDir))
+
+	// @Note : the module is closed when the context is done by the timeout or Stop
+	var ctx context.Context
+	var cancel context.CancelFunc
+	if t.Limits.Timeout > 0 {
+		ctx, cancel = context.WithTimeout(context.Background(), t.Limits.Timeout)
+	} else {
+		ctx, cancel = context.WithCancel(context.Background())
+	}
+	defer cancel()
+
+	running.Add(t.ServiceExecutionInfo, func() error {
+		cancel()
+		return nil
+	})
+	t.notifyServiceStatus(servicemgr.ConstServiceStatusStarted, 0)
+
+	instance, err := r.InstantiateModule(ctx, compiled, moduleConfig)
+	if instance != nil {
+		instance.Close(context.Background())
+	}
+	status, exitCode := t.waitService(err)
+	stdout.Close()
+	stderr.Close()
+	if running.Remove(t.ServiceExecutionInfo) {
+		status = servicemgr.ConstServiceStatusStopped
+	}
+	t.notifyServiceStatus(status, exitCode)
+
+	return
+}
+
+// Stop closes the module of the running WebAssembly service application
+func (t WasmExecutor) Stop(s executor.ServiceExecutionInfo) error {
+	log.Println(logPrefix, "stop service", s.ServiceID)
+	return running.Stop(s)
+}
+
+// loadModule reads the module of the service registered by its .conf file on this device,
+// which must be whitelisted by its hash when the verifier is enabled
+func (t WasmExecutor) loadModule() (appDir string, module []byte, err error) {
+	if len(t.ParamStr) < 1 {
+		return "", nil, errors.New("error: empty parameter")
+	}
+
+	info, err := appQuery.Get(t.ServiceName)
+	if err != nil {
+		return "", nil, err
+	}
+	if filepath.Base(t.ParamStr[0]) != filepath.Base(info.ExecutableFileName) {
+		return "", nil, errors.New("not matched service with module")
+	}
+
+	appDir = info.AppDir
+	module, err = os.ReadFile(filepath.Join(appDir, filepath.Base(info.ExecutableFileName)))
+	if err != nil {
+		return "", nil, err
+	}
+	if err = verifier.GetInstance().ModuleIsInWhiteList(module); err != nil {
+		return "", nil, err
+	}
+	return appDir, module, nil
+}
+
+// waitService returns the status of the exited module with its exit code
+func (t WasmExecutor) waitService(e error) (status string, exitCode int) {
+	if e == nil {
+		log.Println(logPrefix, logmgr.SanitizeUserInput(t.ServiceName), "is exited with no error") // lgtm [go/log-injection]
+		return servicemgr.ConstServiceStatusFinished, 0
+	}
+
+	exitCode = -1
+	var exitErr *sys.ExitError
+	if errors.As(e, &exitErr) {
+		switch exitErr.ExitCode() {
+		case sys.ExitCodeDeadlineExceeded:
+			log.Println(logPrefix, logmgr.SanitizeUserInput(t.ServiceName), "is closed by timeout", t.Limits.Timeout) // lgtm [go/log-injection]
+			return servicemgr.ConstServiceStatusFailed, exitCode
+		case sys.ExitCodeContextCanceled:
+			// @Note : the module is closed by Stop, the status is replaced with Stopped
+		default:
+			exitCode = int(exitErr.ExitCode())
+		}
+	}
+	log.Println(logPrefix, logmgr.SanitizeUserInput(t.ServiceName), "exited with error : ", e) // lgtm [go/log-injection]
+	return servicemgr.ConstServiceStatusFailed, exitCode
+}
+
+func (t WasmExecutor) notifyServiceStatus(status string, exitCode int) {
+	t.NotiImplIns.InvokeNotification(t.NotificationTargetURL, float64(t.ServiceID), status, exitCode)
+}
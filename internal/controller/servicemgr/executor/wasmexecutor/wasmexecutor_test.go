@@ -0,0 +1,190 @@
+/*******************************************************************************
+* Copyright 2020 Samsung Electronics All Rights Reserved.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+* http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*
+*******************************************************************************/
+
+package wasmexecutor
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr"
+	"github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr/executor"
+	notificationMock "github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr/notification/mocks"
+	"github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr/servicelog"
+	appDB "github.com/lf-edge/edge-home-orchestration-go/internal/db/bolt/application"
+	appDBMock "github.com/lf-edge/edge-home-orchestration-go/internal/db/bolt/application/mocks"
+
+	"github.com/golang/mock/gomock"
+)
+
+func initializeMock(t *testing.T, module string) *notificationMock.MockNotification {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+	noti := notificationMock.NewMockNotification(ctrl)
+	query := appDBMock.NewMockDBInterface(ctrl)
+
+	appDir, _ := filepath.Abs("testdata")
+	query.EXPECT().Get(gomock.Any()).DoAndReturn(func(name string) (appDB.Info, error) {
+		if name != module {
+			return appDB.Info{}, errors.New("not found")
+		}
+		return appDB.Info{ServiceName: module, ExecutableFileName: module + ".wasm", ExecType: ExecutionType, AppDir: appDir}, nil
+	}).AnyTimes()
+	appQuery = query
+
+	GetInstance().SetNotiImpl(noti)
+	return noti
+}
+
+func setLogDir(t *testing.T) func() {
+	t.Helper()
+
+	dir, err := os.MkdirTemp("", "wasmexecutor")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	servicelog.SetLogDir(dir)
+	return func() {
+		os.RemoveAll(dir)
+	}
+}
+
+func TestExecute(t *testing.T) {
+	defer setLogDir(t)()
+
+	t.Run("Success", func(t *testing.T) {
+		noti := initializeMock(t, "hello")
+		gomock.InOrder(
+			noti.EXPECT().InvokeNotification(gomock.Any(), gomock.Any(), gomock.Eq(servicemgr.ConstServiceStatusStarted), gomock.Any()),
+			noti.EXPECT().InvokeNotification(gomock.Any(), gomock.Any(), gomock.Eq(servicemgr.ConstServiceStatusFinished), gomock.Eq(0)),
+		)
+
+		s := executor.ServiceExecutionInfo{ServiceID: uint64(1), ServiceName: "hello", ParamStr: []string{"hello.wasm"}}
+		if err := GetInstance().Execute(s); err != nil {
+			t.Fatal(err.Error())
+		}
+
+		entries, _, err := servicelog.Tail(servicelog.Key(s.NotificationTargetURL, s.ServiceID), 0, time.Time{})
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if len(entries) != 2 || entries[0].Stream != servicelog.StreamStdout || entries[0].Message != "hello" ||
+			entries[1].Stream != servicelog.StreamStderr || entries[1].Message != "error" {
+			t.Error("unexpected output", entries)
+		}
+	})
+	t.Run("ExitCode", func(t *testing.T) {
+		noti := initializeMock(t, "exit")
+		gomock.InOrder(
+			noti.EXPECT().InvokeNotification(gomock.Any(), gomock.Any(), gomock.Eq(servicemgr.ConstServiceStatusStarted), gomock.Any()),
+			noti.EXPECT().InvokeNotification(gomock.Any(), gomock.Any(), gomock.Eq(servicemgr.ConstServiceStatusFailed), gomock.Eq(3)),
+		)
+
+		s := executor.ServiceExecutionInfo{ServiceID: uint64(2), ServiceName: "exit", ParamStr: []string{"exit.wasm"}}
+		if err := GetInstance().Execute(s); err == nil {
+			t.Error("unexpected success")
+		}
+	})
+	t.Run("Timeout", func(t *testing.T) {
+		noti := initializeMock(t, "loop")
+		gomock.InOrder(
+			noti.EXPECT().InvokeNotification(gomock.Any(), gomock.Any(), gomock.Eq(servicemgr.ConstServiceStatusStarted), gomock.Any()),
+			noti.EXPECT().InvokeNotification(gomock.Any(), gomock.Any(), gomock.Eq(servicemgr.ConstServiceStatusFailed), gomock.Eq(-1)),
+		)
+
+		s := executor.ServiceExecutionInfo{ServiceID: uint64(3), ServiceName: "loop", ParamStr: []string{"loop.wasm"},
+			Limits: executor.ResourceLimits{Timeout: 100 * time.Millisecond}}
+		if err := GetInstance().Execute(s); err == nil {
+			t.Error("unexpected success")
+		}
+	})
+	t.Run("NotRegistered", func(t *testing.T) {
+		noti := initializeMock(t, "hello")
+		noti.EXPECT().InvokeNotification(gomock.Any(), gomock.Any(), gomock.Eq(servicemgr.ConstServiceStatusFailed), gomock.Eq(-1))
+
+		s := executor.ServiceExecutionInfo{ServiceID: uint64(4), ServiceName: "unknown", ParamStr: []string{"unknown.wasm"}}
+		if err := GetInstance().Execute(s); err == nil {
+			t.Error("unexpected success")
+		}
+	})
+	t.Run("NotMatchedModule", func(t *testing.T) {
+		noti := initializeMock(t, "hello")
+		noti.EXPECT().InvokeNotification(gomock.Any(), gomock.Any(), gomock.Eq(servicemgr.ConstServiceStatusFailed), gomock.Eq(-1))
+
+		s := executor.ServiceExecutionInfo{ServiceID: uint64(5), ServiceName: "hello", ParamStr: []string{"loop.wasm"}}
+		if err := GetInstance().Execute(s); err == nil {
+			t.Error("unexpected success")
+		}
+	})
+	t.Run("InvalidModule", func(t *testing.T) {
+		noti := initializeMock(t, "invalid")
+		noti.EXPECT().InvokeNotification(gomock.Any(), gomock.Any(), gomock.Eq(servicemgr.ConstServiceStatusFailed), gomock.Eq(-1))
+
+		s := executor.ServiceExecutionInfo{ServiceID: uint64(8), ServiceName: "invalid", ParamStr: []string{"invalid.wasm"}}
+		if err := GetInstance().Execute(s); err == nil {
+			t.Error("unexpected success")
+		}
+	})
+}
+
+func TestStop(t *testing.T) {
+	defer setLogDir(t)()
+
+	noti := initializeMock(t, "loop")
+	done := make(chan string, 1)
+	gomock.InOrder(
+		noti.EXPECT().InvokeNotification(gomock.Any(), gomock.Any(), gomock.Eq(servicemgr.ConstServiceStatusStarted), gomock.Any()),
+		noti.EXPECT().InvokeNotification(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(target string, serviceID float64, status string, exitCode int) error {
+				done <- status
+				return nil
+			},
+		),
+	)
+
+	s := executor.ServiceExecutionInfo{ServiceID: uint64(6), ServiceName: "loop", ParamStr: []string{"loop.wasm"}}
+	go GetInstance().Execute(s)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for GetInstance().Stop(s) != nil {
+		if time.Now().After(deadline) {
+			t.Fatal("service is not running")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	select {
+	case status := <-done:
+		if status != servicemgr.ConstServiceStatusStopped {
+			t.Error("unexpected status : ", status)
+		}
+	case <-time.After(5 * time.Second):
+		t.Error("service is not stopped")
+	}
+}
+
+func TestStopFailWithNotRunningService(t *testing.T) {
+	s := executor.ServiceExecutionInfo{ServiceID: uint64(7), ServiceName: "loop"}
+	if err := GetInstance().Stop(s); err == nil {
+		t.Error("unexpected success")
+	}
+}
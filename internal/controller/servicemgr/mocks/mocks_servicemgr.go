@@ -24,8 +24,10 @@ package mocks
 import (
 	gomock "github.com/golang/mock/gomock"
 	executor "github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr/executor"
+	servicelog "github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr/servicelog"
 	client "github.com/lf-edge/edge-home-orchestration-go/internal/restinterface/client"
 	reflect "reflect"
+	time "time"
 )
 
 // MockServiceMgr is a mock of ServiceMgr interface
@@ -52,17 +54,93 @@ func (m *MockServiceMgr) EXPECT() *MockServiceMgrMockRecorder {
 }
 
 // Execute mocks base method
-func (m *MockServiceMgr) Execute(target, name, requester string, args []interface{}, notiChan chan string) error {
+func (m *MockServiceMgr) Execute(target, name, requester string, args []interface{}, limits executor.ResourceLimits, notiChan chan string) (uint64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Execute", target, name, requester, args, notiChan)
+	ret := m.ctrl.Call(m, "Execute", target, name, requester, args, limits, notiChan)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Execute indicates an expected call of Execute
+func (mr *MockServiceMgrMockRecorder) Execute(target, name, requester, args, limits, notiChan interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MockServiceMgr)(nil).Execute), target, name, requester, args, limits, notiChan)
+}
+
+// Stop mocks base method
+func (m *MockServiceMgr) Stop(serviceID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stop", serviceID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// Execute indicates an expected call of Execute
-func (mr *MockServiceMgrMockRecorder) Execute(target, name, requester, args, notiChan interface{}) *gomock.Call {
+// Stop indicates an expected call of Stop
+func (mr *MockServiceMgrMockRecorder) Stop(serviceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockServiceMgr)(nil).Stop), serviceID)
+}
+
+// List mocks base method
+func (m *MockServiceMgr) List() []map[string]interface{} {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List")
+	ret0, _ := ret[0].([]map[string]interface{})
+	return ret0
+}
+
+// List indicates an expected call of List
+func (mr *MockServiceMgrMockRecorder) List() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockServiceMgr)(nil).List))
+}
+
+// Get mocks base method
+func (m *MockServiceMgr) Get(serviceID uint64) (map[string]interface{}, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", serviceID)
+	ret0, _ := ret[0].(map[string]interface{})
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get
+func (mr *MockServiceMgrMockRecorder) Get(serviceID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MockServiceMgr)(nil).Execute), target, name, requester, args, notiChan)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockServiceMgr)(nil).Get), serviceID)
+}
+
+// Watch mocks base method
+func (m *MockServiceMgr) Watch(serviceID uint64) (<-chan map[string]interface{}, func(), error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Watch", serviceID)
+	ret0, _ := ret[0].(<-chan map[string]interface{})
+	ret1, _ := ret[1].(func())
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Watch indicates an expected call of Watch
+func (mr *MockServiceMgrMockRecorder) Watch(serviceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Watch", reflect.TypeOf((*MockServiceMgr)(nil).Watch), serviceID)
+}
+
+// ReadLogs mocks base method
+func (m *MockServiceMgr) ReadLogs(serviceID uint64, tail int, follow bool) (<-chan servicelog.Entry, func(), error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadLogs", serviceID, tail, follow)
+	ret0, _ := ret[0].(<-chan servicelog.Entry)
+	ret1, _ := ret[1].(func())
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ReadLogs indicates an expected call of ReadLogs
+func (mr *MockServiceMgrMockRecorder) ReadLogs(serviceID, tail, follow interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadLogs", reflect.TypeOf((*MockServiceMgr)(nil).ReadLogs), serviceID, tail, follow)
 }
 
 // SetLocalServiceExecutor mocks base method
@@ -89,6 +167,36 @@ func (mr *MockServiceMgrMockRecorder) ExecuteAppOnLocal(appInfo interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecuteAppOnLocal", reflect.TypeOf((*MockServiceMgr)(nil).ExecuteAppOnLocal), appInfo)
 }
 
+// StopAppOnLocal mocks base method
+func (m *MockServiceMgr) StopAppOnLocal(serviceID uint64, notificationTargetURL string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StopAppOnLocal", serviceID, notificationTargetURL)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StopAppOnLocal indicates an expected call of StopAppOnLocal
+func (mr *MockServiceMgrMockRecorder) StopAppOnLocal(serviceID, notificationTargetURL interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopAppOnLocal", reflect.TypeOf((*MockServiceMgr)(nil).StopAppOnLocal), serviceID, notificationTargetURL)
+}
+
+// ReadLogsOnLocal mocks base method
+func (m *MockServiceMgr) ReadLogsOnLocal(serviceID uint64, notificationTargetURL string, tail int, since time.Time) ([]servicelog.Entry, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadLogsOnLocal", serviceID, notificationTargetURL, tail, since)
+	ret0, _ := ret[0].([]servicelog.Entry)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ReadLogsOnLocal indicates an expected call of ReadLogsOnLocal
+func (mr *MockServiceMgrMockRecorder) ReadLogsOnLocal(serviceID, notificationTargetURL, tail, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadLogsOnLocal", reflect.TypeOf((*MockServiceMgr)(nil).ReadLogsOnLocal), serviceID, notificationTargetURL, tail, since)
+}
+
 // SetClient mocks base method
 func (m *MockServiceMgr) SetClient(clientAPI client.Clienter) {
 	m.ctrl.T.Helper()
@@ -23,6 +23,7 @@ package mocks
 
 import (
 	gomock "github.com/golang/mock/gomock"
+	notification "github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr/notification"
 	client "github.com/lf-edge/edge-home-orchestration-go/internal/restinterface/client"
 	reflect "reflect"
 )
@@ -51,21 +52,21 @@ func (m *MockNotification) EXPECT() *MockNotificationMockRecorder {
 }
 
 // InvokeNotification mocks base method
-func (m *MockNotification) InvokeNotification(target string, serviceID float64, status string) error {
+func (m *MockNotification) InvokeNotification(target string, serviceID float64, status string, exitCode int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "InvokeNotification", target, serviceID, status)
+	ret := m.ctrl.Call(m, "InvokeNotification", target, serviceID, status, exitCode)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // InvokeNotification indicates an expected call of InvokeNotification
-func (mr *MockNotificationMockRecorder) InvokeNotification(target, serviceID, status interface{}) *gomock.Call {
+func (mr *MockNotificationMockRecorder) InvokeNotification(target, serviceID, status, exitCode interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvokeNotification", reflect.TypeOf((*MockNotification)(nil).InvokeNotification), target, serviceID, status)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvokeNotification", reflect.TypeOf((*MockNotification)(nil).InvokeNotification), target, serviceID, status, exitCode)
 }
 
 // AddNotificationChan mocks base method
-func (m *MockNotification) AddNotificationChan(serviceID uint64, notiChan chan string) {
+func (m *MockNotification) AddNotificationChan(serviceID uint64, notiChan chan notification.ServiceStatus) {
 	m.ctrl.T.Helper()
 	m.ctrl.Call(m, "AddNotificationChan", serviceID, notiChan)
 }
@@ -76,18 +77,74 @@ func (mr *MockNotificationMockRecorder) AddNotificationChan(serviceID, notiChan
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddNotificationChan", reflect.TypeOf((*MockNotification)(nil).AddNotificationChan), serviceID, notiChan)
 }
 
+// InvokeProgress mocks base method
+func (m *MockNotification) InvokeProgress(target string, serviceID float64, progress map[string]interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InvokeProgress", target, serviceID, progress)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InvokeProgress indicates an expected call of InvokeProgress
+func (mr *MockNotificationMockRecorder) InvokeProgress(target, serviceID, progress interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvokeProgress", reflect.TypeOf((*MockNotification)(nil).InvokeProgress), target, serviceID, progress)
+}
+
+// HandleProgressOnLocal mocks base method
+func (m *MockNotification) HandleProgressOnLocal(serviceID float64, progress map[string]interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleProgressOnLocal", serviceID, progress)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HandleProgressOnLocal indicates an expected call of HandleProgressOnLocal
+func (mr *MockNotificationMockRecorder) HandleProgressOnLocal(serviceID, progress interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleProgressOnLocal", reflect.TypeOf((*MockNotification)(nil).HandleProgressOnLocal), serviceID, progress)
+}
+
 // HandleNotificationOnLocal mocks base method
-func (m *MockNotification) HandleNotificationOnLocal(serviceID float64, status string) error {
+func (m *MockNotification) HandleNotificationOnLocal(serviceID float64, status string, exitCode int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "HandleNotificationOnLocal", serviceID, status)
+	ret := m.ctrl.Call(m, "HandleNotificationOnLocal", serviceID, status, exitCode)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // HandleNotificationOnLocal indicates an expected call of HandleNotificationOnLocal
-func (mr *MockNotificationMockRecorder) HandleNotificationOnLocal(serviceID, status interface{}) *gomock.Call {
+func (mr *MockNotificationMockRecorder) HandleNotificationOnLocal(serviceID, status, exitCode interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleNotificationOnLocal", reflect.TypeOf((*MockNotification)(nil).HandleNotificationOnLocal), serviceID, status, exitCode)
+}
+
+// InvokeSignaled mocks base method
+func (m *MockNotification) InvokeSignaled(target string, serviceID float64, status string, exitCode int, signal string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InvokeSignaled", target, serviceID, status, exitCode, signal)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InvokeSignaled indicates an expected call of InvokeSignaled
+func (mr *MockNotificationMockRecorder) InvokeSignaled(target, serviceID, status, exitCode, signal interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvokeSignaled", reflect.TypeOf((*MockNotification)(nil).InvokeSignaled), target, serviceID, status, exitCode, signal)
+}
+
+// HandleSignaledOnLocal mocks base method
+func (m *MockNotification) HandleSignaledOnLocal(serviceID float64, status string, exitCode int, signal string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleSignaledOnLocal", serviceID, status, exitCode, signal)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HandleSignaledOnLocal indicates an expected call of HandleSignaledOnLocal
+func (mr *MockNotificationMockRecorder) HandleSignaledOnLocal(serviceID, status, exitCode, signal interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleNotificationOnLocal", reflect.TypeOf((*MockNotification)(nil).HandleNotificationOnLocal), serviceID, status)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleSignaledOnLocal", reflect.TypeOf((*MockNotification)(nil).HandleSignaledOnLocal), serviceID, status, exitCode, signal)
 }
 
 // SetClient mocks base method
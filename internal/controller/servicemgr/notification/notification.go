@@ -21,17 +21,20 @@ package notification
 import (
 	"errors"
 	"github.com/lf-edge/edge-home-orchestration-go/internal/common/logmgr"
-	"strings"
 
-	"github.com/lf-edge/edge-home-orchestration-go/internal/common/networkhelper"
+	"github.com/lf-edge/edge-home-orchestration-go/internal/common/types/servicemgrtypes"
 	"github.com/lf-edge/edge-home-orchestration-go/internal/restinterface/client"
 )
 
 // Notification is the interface for notification
 type Notification interface {
-	InvokeNotification(target string, serviceID float64, status string) error
-	AddNotificationChan(serviceID uint64, notiChan chan string)
-	HandleNotificationOnLocal(serviceID float64, status string) (err error)
+	InvokeNotification(target string, serviceID float64, status string, exitCode int) error
+	AddNotificationChan(serviceID uint64, notiChan chan ServiceStatus)
+	HandleNotificationOnLocal(serviceID float64, status string, exitCode int) (err error)
+	InvokeSignaled(target string, serviceID float64, status string, exitCode int, signal string) error
+	HandleSignaledOnLocal(serviceID float64, status string, exitCode int, signal string) (err error)
+	InvokeProgress(target string, serviceID float64, progress map[string]interface{}) error
+	HandleProgressOnLocal(serviceID float64, progress map[string]interface{}) (err error)
 
 	// for client
 	client.Setter
@@ -67,7 +70,7 @@ func GetInstance() *NotiImpl {
 }
 
 // AddNotificationChan is adding notification channel value with service ID key
-func (NotiImpl) AddNotificationChan(serviceID uint64, notiChan chan string) {
+func (NotiImpl) AddNotificationChan(serviceID uint64, notiChan chan ServiceStatus) {
 	value := make(map[string]interface{})
 
 	value[ConstKeyNotiChan] = notiChan
@@ -76,36 +79,83 @@ func (NotiImpl) AddNotificationChan(serviceID uint64, notiChan chan string) {
 }
 
 // InvokeNotification is processing notification
-func (n NotiImpl) InvokeNotification(target string, serviceID float64, status string) (err error) {
-	outboundIP, outboundIPErr := networkhelper.GetInstance().GetOutboundIP()
-	if outboundIPErr != nil {
-		outboundIP = ""
+func (n NotiImpl) InvokeNotification(target string, serviceID float64, status string, exitCode int) (err error) {
+	if servicemgrtypes.IsLocalTarget(target) {
+		return n.HandleNotificationOnLocal(serviceID, status, exitCode)
 	}
+	return n.handleNotificationOnRemote(target, serviceID, ServiceStatus{Status: status, ExitCode: exitCode})
+}
+
+// HandleNotificationOnLocal is invoking notification on local
+func (NotiImpl) HandleNotificationOnLocal(serviceID float64, status string, exitCode int) (err error) {
+	return handleStatusOnLocal(serviceID, ServiceStatus{Status: status, ExitCode: exitCode})
+}
 
-	if strings.Compare(target, outboundIP) == 0 {
-		return n.HandleNotificationOnLocal(serviceID, status)
+// InvokeSignaled is processing the notification of the service terminated by the signal
+func (n NotiImpl) InvokeSignaled(target string, serviceID float64, status string, exitCode int, signal string) (err error) {
+	if servicemgrtypes.IsLocalTarget(target) {
+		return n.HandleSignaledOnLocal(serviceID, status, exitCode, signal)
 	}
-	return n.handleNotificationOnRemote(target, serviceID, status)
+	return n.handleNotificationOnRemote(target, serviceID, ServiceStatus{Status: status, ExitCode: exitCode, Signal: signal})
 }
 
-// HandleNotificationOnLocal is invoking notification on local
-func (NotiImpl) HandleNotificationOnLocal(serviceID float64, status string) (err error) {
+// HandleSignaledOnLocal is invoking the notification of the service terminated by the signal on local
+func (NotiImpl) HandleSignaledOnLocal(serviceID float64, status string, exitCode int, signal string) (err error) {
+	return handleStatusOnLocal(serviceID, ServiceStatus{Status: status, ExitCode: exitCode, Signal: signal})
+}
+
+func handleStatusOnLocal(serviceID float64, serviceStatus ServiceStatus) (err error) {
 	id := uint64(serviceID)
 	notiChan, err := getNotiChan(id)
 	if notiChan == nil {
 		return
 	}
 
-	notiChan <- status
-	notificationMap.Remove(id)
+	notiChan <- serviceStatus
+	if servicemgrtypes.IsTerminalStatus(serviceStatus.Status) {
+		notificationMap.Remove(id)
+	}
+
+	return
+}
+
+// InvokeProgress is invoking the image pull progress of the service, the status of the service becomes Pulling
+func (n NotiImpl) InvokeProgress(target string, serviceID float64, progress map[string]interface{}) (err error) {
+	if servicemgrtypes.IsLocalTarget(target) {
+		return n.HandleProgressOnLocal(serviceID, progress)
+	}
+
+	statusNotificationInfo := make(map[string]interface{})
+	statusNotificationInfo["ServiceID"] = serviceID
+	statusNotificationInfo["Status"] = servicemgrtypes.ConstServiceStatusPulling
+	statusNotificationInfo["Progress"] = progress
+
+	err = n.Clienter.DoNotifyAppStatusRemoteDevice(statusNotificationInfo, uint64(serviceID), target)
+	if err != nil {
+		log.Println(logPrefix, err.Error())
+	}
+	return
+}
 
+// HandleProgressOnLocal is invoking the image pull progress on local
+func (NotiImpl) HandleProgressOnLocal(serviceID float64, progress map[string]interface{}) (err error) {
+	notiChan, err := getNotiChan(uint64(serviceID))
+	if notiChan == nil {
+		return
+	}
+
+	notiChan <- ServiceStatus{Status: servicemgrtypes.ConstServiceStatusPulling, Progress: progress}
 	return
 }
 
-func (n NotiImpl) handleNotificationOnRemote(target string, serviceID float64, status string) (err error) {
+func (n NotiImpl) handleNotificationOnRemote(target string, serviceID float64, serviceStatus ServiceStatus) (err error) {
 	statusNotificationInfo := make(map[string]interface{})
 	statusNotificationInfo["ServiceID"] = serviceID
-	statusNotificationInfo["Status"] = status
+	statusNotificationInfo["Status"] = serviceStatus.Status
+	statusNotificationInfo["ExitCode"] = serviceStatus.ExitCode
+	if len(serviceStatus.Signal) > 0 {
+		statusNotificationInfo["Signal"] = serviceStatus.Signal
+	}
 
 	err = n.Clienter.DoNotifyAppStatusRemoteDevice(statusNotificationInfo, uint64(serviceID), target)
 
@@ -115,12 +165,12 @@ func (n NotiImpl) handleNotificationOnRemote(target string, serviceID float64, s
 	return
 }
 
-func getNotiChan(serviceID uint64) (notiChan chan string, err error) {
+func getNotiChan(serviceID uint64) (notiChan chan ServiceStatus, err error) {
 	value, _ := notificationMap.Get(serviceID)
 	if value == nil {
 		return nil, errors.New("invalid serviceID")
 	}
 
 	valueList := value.(map[string]interface{})
-	return valueList[ConstKeyNotiChan].(chan string), nil
+	return valueList[ConstKeyNotiChan].(chan ServiceStatus), nil
 }
@@ -40,10 +40,10 @@ var (
 )
 
 func TestInvokeNotificationOnLocal(t *testing.T) {
-	notiChan := make(chan string, 1)
+	notiChan := make(chan ServiceStatus, 1)
 
 	GetInstance().AddNotificationChan(id, notiChan)
-	err := GetInstance().InvokeNotification(targetLocalAddr, float64(id), status)
+	err := GetInstance().InvokeNotification(targetLocalAddr, float64(id), status, 0)
 
 	if err != nil {
 		t.Fail()
@@ -51,7 +51,7 @@ func TestInvokeNotificationOnLocal(t *testing.T) {
 }
 
 func TestInvokeNotificationFailedWithInvalidChan(t *testing.T) {
-	err := GetInstance().InvokeNotification(targetLocalAddr, float64(id), status)
+	err := GetInstance().InvokeNotification(targetLocalAddr, float64(id), status, 0)
 	if err == nil {
 		t.Fail()
 	}
@@ -62,7 +62,7 @@ func TestInvokeNotificationOnRemote(t *testing.T) {
 	defer ctrl.Finish()
 	mockClient := clientMocks.NewMockClienter(ctrl)
 
-	notiChan := make(chan string, 1)
+	notiChan := make(chan ServiceStatus, 1)
 
 	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		log.Println("TestInvokeNotificationOnRemote Handler")
@@ -80,7 +80,7 @@ func TestInvokeNotificationOnRemote(t *testing.T) {
 	GetInstance().AddNotificationChan(id, notiChan)
 	GetInstance().Clienter = mockClient
 	mockClient.EXPECT().DoNotifyAppStatusRemoteDevice(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
-	err := GetInstance().InvokeNotification(targetRemoteAddr, float64(id), status)
+	err := GetInstance().InvokeNotification(targetRemoteAddr, float64(id), status, 0)
 
 	if err != nil {
 		t.Fail()
@@ -88,3 +88,75 @@ func TestInvokeNotificationOnRemote(t *testing.T) {
 
 	server.Close()
 }
+
+func TestHandleNotificationOnLocalKeepsChanUntilTerminalStatus(t *testing.T) {
+	notiChan := make(chan ServiceStatus, 2)
+
+	GetInstance().AddNotificationChan(id, notiChan)
+	if err := GetInstance().HandleNotificationOnLocal(float64(id), "Unhealthy", 0); err != nil {
+		t.Error(err.Error())
+	}
+	if err := GetInstance().HandleNotificationOnLocal(float64(id), "Failed", 1); err != nil {
+		t.Error(err.Error())
+	}
+	if _, err := getNotiChan(id); err == nil {
+		t.Error("notification channel is not removed")
+	}
+
+	if noti := <-notiChan; noti.Status != "Unhealthy" {
+		t.Error("unexpected status : ", noti.Status)
+	}
+	if noti := <-notiChan; noti.Status != "Failed" || noti.ExitCode != 1 {
+		t.Error("unexpected status : ", noti)
+	}
+}
+
+func TestHandleProgressOnLocal(t *testing.T) {
+	notiChan := make(chan ServiceStatus, 1)
+
+	GetInstance().AddNotificationChan(id, notiChan)
+	progress := map[string]interface{}{"Image": "alpine", "Current": int64(10), "Total": int64(100)}
+	if err := GetInstance().HandleProgressOnLocal(float64(id), progress); err != nil {
+		t.Error(err.Error())
+	}
+	if _, err := getNotiChan(id); err != nil {
+		t.Error("notification channel is removed")
+	}
+
+	if noti := <-notiChan; noti.Status != "Pulling" || noti.Progress["Image"] != "alpine" {
+		t.Error("unexpected status : ", noti)
+	}
+}
+
+func TestInvokeSignaled(t *testing.T) {
+	t.Run("Local", func(t *testing.T) {
+		notiChan := make(chan ServiceStatus, 1)
+
+		GetInstance().AddNotificationChan(id, notiChan)
+		if err := GetInstance().InvokeSignaled(targetLocalAddr, float64(id), "Failed", 137, "SIGKILL"); err != nil {
+			t.Error(err.Error())
+		}
+
+		if noti := <-notiChan; noti.Status != "Failed" || noti.ExitCode != 137 || noti.Signal != "SIGKILL" {
+			t.Error("unexpected status : ", noti)
+		}
+	})
+	t.Run("Remote", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockClient := clientMocks.NewMockClienter(ctrl)
+
+		GetInstance().Clienter = mockClient
+		mockClient.EXPECT().DoNotifyAppStatusRemoteDevice(gomock.Any(), id, targetRemoteAddr).DoAndReturn(
+			func(info map[string]interface{}, appID uint64, target string) error {
+				if info["Status"] != "Failed" || info["ExitCode"] != 137 || info["Signal"] != "SIGKILL" {
+					t.Error("unexpected notification : ", info)
+				}
+				return nil
+			})
+
+		if err := GetInstance().InvokeSignaled(targetRemoteAddr, float64(id), "Failed", 137, "SIGKILL"); err != nil {
+			t.Error(err.Error())
+		}
+	})
+}
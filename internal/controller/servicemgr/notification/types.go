@@ -26,6 +26,16 @@ const (
 
 const logPrefix = "[notification]"
 
+// ServiceStatus is the status of the service delivered to the notification channel
+type ServiceStatus struct {
+	Status   string
+	ExitCode int
+	// Signal is the name of the signal terminating the service, empty when it exited by itself
+	Signal string
+	// Progress is the image pull progress delivered with the Pulling status
+	Progress map[string]interface{}
+}
+
 // ConcurrentMap type
 type ConcurrentMap struct {
 	sync.RWMutex
@@ -0,0 +1,509 @@
+/*******************************************************************************
+ * Copyright 2020 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+
+// Package servicelog captures the output of the services into bounded ring files
+package servicelog
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	errormsg "github.com/lf-edge/edge-home-orchestration-go/internal/common/errors"
+	"github.com/lf-edge/edge-home-orchestration-go/internal/common/logmgr"
+)
+
+const (
+	// StreamStdout is the stream of the standard output
+	StreamStdout = "stdout"
+	// StreamStderr is the stream of the standard error
+	StreamStderr = "stderr"
+
+	// DefaultMaxFileSize is the default size of a log file, the output of a service is kept in two files at most
+	DefaultMaxFileSize = 1024 * 1024
+	// DefaultMaxDirSize is the default total size of the log files, the finished logs are removed from the oldest beyond it
+	DefaultMaxDirSize = 64 * 1024 * 1024
+	// DefaultRetention is the default time to keep the log of the finished service, the same as the terminated service
+	DefaultRetention = 10 * time.Minute
+
+	maxLineLength = 16 * 1024
+	followBuffer  = 256
+	backupSuffix  = ".1"
+	logPrefix     = "[servicelog]"
+)
+
+// Entry is a line of the output of the service
+type Entry struct {
+	Time    time.Time
+	Stream  string
+	Message string
+}
+
+// serviceLog is the log files of a service and the followers waiting for its next lines
+type serviceLog struct {
+	sync.Mutex
+	key       string
+	path      string
+	file      *os.File
+	size      int64
+	closed    bool
+	finished  int
+	writers   int
+	followers map[chan Entry]struct{}
+}
+
+var (
+	log = logmgr.GetInstance()
+
+	store = struct {
+		sync.Mutex
+		dir        string
+		maxDirSize int64
+		retention  time.Duration
+		logs       map[string]*serviceLog
+	}{
+		dir:        filepath.Join(os.TempDir(), "edge-orchestration", "services"),
+		maxDirSize: DefaultMaxDirSize,
+		retention:  DefaultRetention,
+		logs:       make(map[string]*serviceLog),
+	}
+
+	// maxFileSize is read while writing the log, without the lock of the store
+	maxFileSize = int64(DefaultMaxFileSize)
+)
+
+// SetLogDir sets the directory of the log files
+func SetLogDir(dir string) {
+	store.Lock()
+	defer store.Unlock()
+
+	store.dir = dir
+}
+
+// SetMaxFileSize sets the size of a log file, the output of a service is kept in two files at most
+func SetMaxFileSize(size int64) {
+	if size <= 0 {
+		size = DefaultMaxFileSize
+	}
+	atomic.StoreInt64(&maxFileSize, size)
+}
+
+// SetMaxDirSize sets the total size of the log files, the finished logs are removed from the oldest beyond it
+func SetMaxDirSize(size int64) {
+	store.Lock()
+	defer store.Unlock()
+
+	if size <= 0 {
+		size = DefaultMaxDirSize
+	}
+	store.maxDirSize = size
+}
+
+// SetRetention sets the time to keep the log of the finished service, 0 keeps it until the total size is exceeded
+func SetRetention(retention time.Duration) {
+	store.Lock()
+	defer store.Unlock()
+
+	store.retention = retention
+}
+
+// Key returns the key of the log of the service requested by the requester,
+// the service IDs are unique only for each requester
+func Key(requester string, serviceID uint64) string {
+	replacer := strings.NewReplacer(":", "_", "/", "_", "%", "_")
+	return fmt.Sprintf("%s-%d", replacer.Replace(requester), serviceID)
+}
+
+// NewWriter returns the writer of the stream of the service, every line written is an entry of the log.
+// The log is finished and its followers are released when every writer of the service is closed.
+func NewWriter(key, stream string) io.WriteCloser {
+	l := getLog(key, true)
+
+	l.Lock()
+	l.closed = false
+	l.writers++
+	l.Unlock()
+
+	return &lineWriter{log: l, stream: stream}
+}
+
+// Tail returns the last tail entries of the log written after since, 0 or less means every entry,
+// and whether the log is finished, which means no more entries will be written.
+func Tail(key string, tail int, since time.Time) (entries []Entry, finished bool, err error) {
+	l := getLog(key, false)
+	if l == nil {
+		return nil, false, errormsg.NotFound{Message: "no log of " + key}
+	}
+
+	l.Lock()
+	defer l.Unlock()
+
+	entries, err = l.tail(tail, since)
+	return entries, l.closed, err
+}
+
+// Read returns the channel receiving the last tail entries of the log, 0 or less means every entry.
+// When follow is true, the channel keeps receiving the next entries until the log is finished or cancel is called.
+func Read(key string, tail int, follow bool) (<-chan Entry, func(), error) {
+	l := getLog(key, false)
+	if l == nil {
+		return nil, nil, errormsg.NotFound{Message: "no log of " + key}
+	}
+
+	l.Lock()
+	entries, err := l.tail(tail, time.Time{})
+	var followCh chan Entry
+	if err == nil && follow && !l.closed {
+		followCh = make(chan Entry, followBuffer)
+		l.followers[followCh] = struct{}{}
+	}
+	l.Unlock()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	out := make(chan Entry)
+	done := make(chan struct{})
+	var once sync.Once
+	cancel := func() {
+		once.Do(func() {
+			close(done)
+			if followCh != nil {
+				l.unfollow(followCh)
+			}
+		})
+	}
+
+	go func() {
+		defer close(out)
+		for _, entry := range entries {
+			select {
+			case out <- entry:
+			case <-done:
+				return
+			}
+		}
+		if followCh == nil {
+			return
+		}
+		for {
+			select {
+			case entry, ok := <-followCh:
+				if !ok {
+					return
+				}
+				select {
+				case out <- entry:
+				case <-done:
+					return
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+	return out, cancel, nil
+}
+
+func getLog(key string, create bool) *serviceLog {
+	store.Lock()
+	defer store.Unlock()
+
+	if l, ok := store.logs[key]; ok {
+		return l
+	}
+
+	path := filepath.Join(store.dir, key+".log")
+	if !create {
+		if _, err := os.Stat(path); err != nil {
+			return nil
+		}
+	}
+	l := &serviceLog{key: key, path: path, closed: !create, followers: make(map[chan Entry]struct{})}
+	store.logs[key] = l
+	return l
+}
+
+// removeLog removes the files of the log if it is not written again since it finished,
+// the lock of the store is held before the lock of the log
+func removeLog(l *serviceLog, finished int) {
+	store.Lock()
+	defer store.Unlock()
+
+	if store.logs[l.key] != l {
+		return
+	}
+	l.Lock()
+	defer l.Unlock()
+
+	if !l.closed || l.finished != finished {
+		return
+	}
+	removeFiles(l.path)
+	delete(store.logs, l.key)
+}
+
+// pruneDir removes the finished logs from the oldest while the log files exceed the total size
+func pruneDir() {
+	store.Lock()
+	defer store.Unlock()
+
+	entries, err := os.ReadDir(store.dir)
+	if err != nil {
+		return
+	}
+
+	type logFiles struct {
+		key     string
+		size    int64
+		modTime time.Time
+	}
+	files := make(map[string]*logFiles)
+	var total int64
+	for _, entry := range entries {
+		key := strings.TrimSuffix(strings.TrimSuffix(entry.Name(), backupSuffix), ".log")
+		info, err := entry.Info()
+		if err != nil || entry.IsDir() || key == entry.Name() {
+			continue
+		}
+		f, ok := files[key]
+		if !ok {
+			f = &logFiles{key: key}
+			files[key] = f
+		}
+		f.size += info.Size()
+		if info.ModTime().After(f.modTime) {
+			f.modTime = info.ModTime()
+		}
+		total += info.Size()
+	}
+	if total <= store.maxDirSize {
+		return
+	}
+
+	oldest := make([]*logFiles, 0, len(files))
+	for _, f := range files {
+		oldest = append(oldest, f)
+	}
+	sort.Slice(oldest, func(i, j int) bool {
+		return oldest[i].modTime.Before(oldest[j].modTime)
+	})
+	for _, f := range oldest {
+		if total <= store.maxDirSize {
+			break
+		}
+		if l, ok := store.logs[f.key]; ok {
+			l.Lock()
+			finished := l.closed
+			l.Unlock()
+			if !finished {
+				continue
+			}
+			delete(store.logs, f.key)
+		}
+		removeFiles(filepath.Join(store.dir, f.key+".log"))
+		total -= f.size
+	}
+}
+
+func removeFiles(path string) {
+	for _, name := range []string{path, path + backupSuffix} {
+		if err := os.Remove(name); err != nil && !os.IsNotExist(err) {
+			log.Println(logPrefix, "cannot remove log :", err.Error())
+		}
+	}
+}
+
+func (l *serviceLog) append(entry Entry) {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	data = append(data, '\n')
+
+	l.Lock()
+	defer l.Unlock()
+
+	if err := l.write(data); err != nil {
+		log.Println(logPrefix, "cannot write log :", err.Error())
+	}
+	for ch := range l.followers {
+		select {
+		case ch <- entry:
+		default:
+			// @Note : the slow follower loses the entry instead of blocking the service
+		}
+	}
+}
+
+// write appends the data to the log file, the file is rotated to the backup file when it is full
+func (l *serviceLog) write(data []byte) (err error) {
+	if l.file != nil && l.size+int64(len(data)) > atomic.LoadInt64(&maxFileSize) {
+		l.file.Close()
+		l.file = nil
+		if err = os.Rename(l.path, l.path+backupSuffix); err != nil {
+			return
+		}
+	}
+	if l.file == nil {
+		if err = os.MkdirAll(filepath.Dir(l.path), 0700); err != nil {
+			return
+		}
+		if l.file, err = os.OpenFile(l.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600); err != nil {
+			return
+		}
+		var info os.FileInfo
+		if info, err = l.file.Stat(); err != nil {
+			return
+		}
+		l.size = info.Size()
+	}
+
+	n, err := l.file.Write(data)
+	l.size += int64(n)
+	return
+}
+
+// tail reads the last n entries written after since from the backup file and the current file
+func (l *serviceLog) tail(n int, since time.Time) ([]Entry, error) {
+	entries := make([]Entry, 0)
+	for _, path := range []string{l.path + backupSuffix, l.path} {
+		file, err := os.Open(path)
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		scanner := bufio.NewScanner(file)
+		scanner.Buffer(make([]byte, 0, 4096), 4*maxLineLength)
+		for scanner.Scan() {
+			var entry Entry
+			if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil || !entry.Time.After(since) {
+				continue
+			}
+			entries = append(entries, entry)
+			if n > 0 && len(entries) > n {
+				entries = entries[1:]
+			}
+		}
+		file.Close()
+	}
+	return entries, nil
+}
+
+func (l *serviceLog) unfollow(ch chan Entry) {
+	l.Lock()
+	defer l.Unlock()
+
+	if _, ok := l.followers[ch]; ok {
+		delete(l.followers, ch)
+		close(ch)
+	}
+}
+
+// release closes the log when its last writer is closed, the finished log is removed after the retention
+func (l *serviceLog) release() {
+	finished, ok := l.finish()
+	if !ok {
+		return
+	}
+
+	store.Lock()
+	retention := store.retention
+	store.Unlock()
+	if retention > 0 {
+		time.AfterFunc(retention, func() {
+			removeLog(l, finished)
+		})
+	}
+	pruneDir()
+}
+
+// finish closes the log if the last writer is closed and returns how many times the log is finished
+func (l *serviceLog) finish() (int, bool) {
+	l.Lock()
+	defer l.Unlock()
+
+	if l.writers--; l.writers > 0 {
+		return 0, false
+	}
+	l.closed = true
+	l.finished++
+	if l.file != nil {
+		l.file.Close()
+		l.file = nil
+	}
+	for ch := range l.followers {
+		delete(l.followers, ch)
+		close(ch)
+	}
+	return l.finished, true
+}
+
+// lineWriter splits the output of the stream into the entries of the log
+type lineWriter struct {
+	log    *serviceLog
+	stream string
+	buf    []byte
+	once   sync.Once
+}
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+		w.flush(w.buf[:i])
+		w.buf = w.buf[i+1:]
+	}
+	if len(w.buf) >= maxLineLength {
+		w.flush(w.buf)
+		w.buf = nil
+	}
+	return len(p), nil
+}
+
+func (w *lineWriter) Close() error {
+	w.once.Do(func() {
+		if len(w.buf) > 0 {
+			w.flush(w.buf)
+			w.buf = nil
+		}
+		w.log.release()
+	})
+	return nil
+}
+
+func (w *lineWriter) flush(line []byte) {
+	w.log.append(Entry{
+		Time:    time.Now().UTC(),
+		Stream:  w.stream,
+		Message: strings.TrimSuffix(string(line), "\r"),
+	})
+}
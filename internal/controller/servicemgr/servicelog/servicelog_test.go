@@ -0,0 +1,237 @@
+/*******************************************************************************
+ * Copyright 2020 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+
+package servicelog
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func setup(t *testing.T) func() {
+	dir, err := ioutil.TempDir("", "servicelog")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	SetLogDir(dir)
+	return func() {
+		os.RemoveAll(dir)
+	}
+}
+
+func collect(ch <-chan Entry) (entries []Entry) {
+	for entry := range ch {
+		entries = append(entries, entry)
+	}
+	return
+}
+
+func TestKey(t *testing.T) {
+	if key := Key("192.168.1.2:56001", 3); key != "192.168.1.2_56001-3" {
+		t.Error("unexpected key", key)
+	}
+}
+
+func TestRead(t *testing.T) {
+	defer setup(t)()
+
+	key := Key("192.168.1.2", 1)
+	stdout := NewWriter(key, StreamStdout)
+	stderr := NewWriter(key, StreamStderr)
+	fmt.Fprint(stdout, "first\nsec")
+	fmt.Fprint(stdout, "ond\n")
+	fmt.Fprintln(stderr, "error")
+	fmt.Fprint(stdout, "last")
+	stdout.Close()
+	stderr.Close()
+
+	t.Run("All", func(t *testing.T) {
+		ch, cancel, err := Read(key, 0, false)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		defer cancel()
+
+		entries := collect(ch)
+		expected := []Entry{{Stream: StreamStdout, Message: "first"}, {Stream: StreamStdout, Message: "second"},
+			{Stream: StreamStderr, Message: "error"}, {Stream: StreamStdout, Message: "last"}}
+		if len(entries) != len(expected) {
+			t.Fatal("unexpected entries", entries)
+		}
+		for i, entry := range entries {
+			if entry.Stream != expected[i].Stream || entry.Message != expected[i].Message {
+				t.Error("unexpected entry", entry)
+			}
+		}
+	})
+	t.Run("Tail", func(t *testing.T) {
+		ch, cancel, err := Read(key, 2, true)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		defer cancel()
+
+		entries := collect(ch)
+		if len(entries) != 2 || entries[0].Message != "error" || entries[1].Message != "last" {
+			t.Error("unexpected entries", entries)
+		}
+	})
+	t.Run("Since", func(t *testing.T) {
+		entries, finished, err := Tail(key, 0, time.Time{})
+		if err != nil || !finished || len(entries) != 4 {
+			t.Fatal("unexpected entries", entries, finished, err)
+		}
+
+		since := entries[1].Time
+		entries, _, _ = Tail(key, 0, since)
+		for _, entry := range entries {
+			if !entry.Time.After(since) {
+				t.Error("unexpected entry", entry)
+			}
+		}
+	})
+	t.Run("NoLog", func(t *testing.T) {
+		if _, _, err := Read(Key("192.168.1.2", 2), 0, false); err == nil {
+			t.Error("expected error")
+		}
+		if _, _, err := Tail(Key("192.168.1.2", 2), 0, time.Time{}); err == nil {
+			t.Error("expected error")
+		}
+	})
+}
+
+func TestFollow(t *testing.T) {
+	defer setup(t)()
+
+	key := Key("192.168.1.3", 1)
+	stdout := NewWriter(key, StreamStdout)
+	fmt.Fprintln(stdout, "before")
+
+	ch, cancel, err := Read(key, 0, true)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer cancel()
+
+	fmt.Fprintln(stdout, "after")
+	for _, expected := range []string{"before", "after"} {
+		select {
+		case entry := <-ch:
+			if entry.Message != expected {
+				t.Error("unexpected entry", entry)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("timeout")
+		}
+	}
+
+	stdout.Close()
+	select {
+	case _, ok := <-ch:
+		if ok {
+			t.Error("expected the end of the log")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timeout")
+	}
+}
+
+func TestRotate(t *testing.T) {
+	defer setup(t)()
+	SetMaxFileSize(512)
+	defer SetMaxFileSize(DefaultMaxFileSize)
+
+	key := Key("192.168.1.4", 1)
+	stdout := NewWriter(key, StreamStdout)
+	for i := 0; i < 100; i++ {
+		fmt.Fprintln(stdout, "line", i)
+	}
+	stdout.Close()
+
+	ch, cancel, err := Read(key, 0, false)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer cancel()
+
+	entries := collect(ch)
+	if len(entries) == 0 || len(entries) >= 100 {
+		t.Fatal("unexpected number of entries", len(entries))
+	}
+	if last := entries[len(entries)-1]; last.Message != "line 99" {
+		t.Error("unexpected last entry", last)
+	}
+}
+
+func TestRetention(t *testing.T) {
+	defer setup(t)()
+	SetRetention(10 * time.Millisecond)
+	defer SetRetention(DefaultRetention)
+
+	key := Key("192.168.1.5", 1)
+	stdout := NewWriter(key, StreamStdout)
+	fmt.Fprintln(stdout, "line")
+	stdout.Close()
+
+	for i := 0; i < 100; i++ {
+		if _, _, err := Tail(key, 0, time.Time{}); err != nil {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Error("finished log is not removed")
+}
+
+func TestMaxDirSize(t *testing.T) {
+	defer setup(t)()
+	SetMaxDirSize(64)
+	defer SetMaxDirSize(DefaultMaxDirSize)
+
+	running := NewWriter(Key("192.168.1.6", 1), StreamStdout)
+	defer running.Close()
+	fmt.Fprintln(running, "running service")
+
+	old := Key("192.168.1.6", 2)
+	stdout := NewWriter(old, StreamStdout)
+	fmt.Fprintln(stdout, "old service")
+	store.Lock()
+	path := filepath.Join(store.dir, old+".log")
+	store.Unlock()
+	if err := os.Chtimes(path, time.Now().Add(-time.Hour), time.Now().Add(-time.Hour)); err != nil {
+		t.Fatal(err.Error())
+	}
+	stdout.Close()
+
+	recent := Key("192.168.1.6", 3)
+	stdout = NewWriter(recent, StreamStdout)
+	fmt.Fprintln(stdout, "recent service")
+	stdout.Close()
+
+	if _, _, err := Tail(old, 0, time.Time{}); err == nil {
+		t.Error("oldest finished log is not removed")
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("oldest log file is not removed", err)
+	}
+	if entries, _, err := Tail(Key("192.168.1.6", 1), 0, time.Time{}); err != nil || len(entries) != 1 {
+		t.Error("running log is removed", err)
+	}
+}
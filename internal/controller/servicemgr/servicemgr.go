@@ -19,23 +19,34 @@
 package servicemgr
 
 import (
-	"strings"
+	"encoding/json"
+	"sort"
+	"sync"
+	"time"
 
 	"github.com/lf-edge/edge-home-orchestration-go/internal/common/logmgr"
-	"github.com/lf-edge/edge-home-orchestration-go/internal/common/networkhelper"
+	"github.com/lf-edge/edge-home-orchestration-go/internal/common/types/servicemgrtypes"
 	"github.com/lf-edge/edge-home-orchestration-go/internal/controller/configuremgr"
 	"github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr/executor"
 	"github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr/notification"
+	"github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr/servicelog"
 	"github.com/lf-edge/edge-home-orchestration-go/internal/restinterface/client"
 )
 
 // ServiceMgr is the interface to execute service application
 type ServiceMgr interface {
-	Execute(target, name, requester string, args []interface{}, notiChan chan string) (err error)
+	Execute(target, name, requester string, args []interface{}, limits executor.ResourceLimits, notiChan chan string) (serviceID uint64, err error)
+	Stop(serviceID uint64) (err error)
+	List() []map[string]interface{}
+	Get(serviceID uint64) (map[string]interface{}, error)
+	Watch(serviceID uint64) (<-chan map[string]interface{}, func(), error)
+	ReadLogs(serviceID uint64, tail int, follow bool) (<-chan servicelog.Entry, func(), error)
 	SetLocalServiceExecutor(s executor.ServiceExecutor)
 
 	// for internal api
 	ExecuteAppOnLocal(appInfo map[string]interface{})
+	StopAppOnLocal(serviceID uint64, notificationTargetURL string) (err error)
+	ReadLogsOnLocal(serviceID uint64, notificationTargetURL string, tail int, since time.Time) (entries []servicelog.Entry, finished bool, err error)
 
 	// for client
 	client.Setter
@@ -69,26 +80,137 @@ func (sm *SMMgrImpl) SetLocalServiceExecutor(s executor.ServiceExecutor) {
 }
 
 // Execute selects local execution and remote execution
-func (sm SMMgrImpl) Execute(target, name, requester string, args []interface{}, notiChan chan string) (err error) {
-	serviceID := createServiceMap(name)
-	appInfo := makeAppInfo(target, name, requester, args, float64(serviceID))
+func (sm SMMgrImpl) Execute(target, name, requester string, args []interface{}, limits executor.ResourceLimits, notiChan chan string) (serviceID uint64, err error) {
+	serviceID = createServiceMap(target, name, requester)
+	appInfo := makeAppInfo(target, name, requester, args, limits, float64(serviceID))
 
-	notification.GetInstance().AddNotificationChan(serviceID, notiChan)
+	statusChan := make(chan notification.ServiceStatus, 1)
+	notification.GetInstance().AddNotificationChan(serviceID, statusChan)
+	go listenServiceStatus(serviceID, statusChan, notiChan)
 
-	outboundIP, outboundIPErr := networkhelper.GetInstance().GetOutboundIP()
-	if outboundIPErr != nil {
-		outboundIP = ""
-	}
-
-	if strings.Compare(target, outboundIP) == 0 {
+	if servicemgrtypes.IsLocalTarget(target) {
 		sm.ExecuteAppOnLocal(appInfo)
 	} else {
 		err = sm.executeAppOnRemote(target, appInfo)
+		if err != nil {
+			notification.GetInstance().HandleNotificationOnLocal(float64(serviceID), ConstServiceStatusFailed, -1)
+		}
 	}
 
 	return
 }
 
+// Stop stops the running service on the device executing it
+func (sm SMMgrImpl) Stop(serviceID uint64) (err error) {
+	info, ok := getServiceInfo(serviceID)
+	if !ok || servicemgrtypes.IsTerminalStatus(info[ConstKeyStatus].(string)) {
+		return ErrInvalidService
+	}
+
+	target := info[ConstKeyTarget].(string)
+	if servicemgrtypes.IsLocalTarget(target) {
+		return sm.StopAppOnLocal(serviceID, target)
+	}
+	return sm.Clienter.DoStopRemoteDevice(serviceID, target)
+}
+
+// List returns the information of the services which are running or recently terminated
+func (sm SMMgrImpl) List() []map[string]interface{} {
+	ids := make([]uint64, 0)
+	for item := range ServiceMap.Iter() {
+		ids = append(ids, item.Key)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	services := make([]map[string]interface{}, 0, len(ids))
+	for _, id := range ids {
+		if info, ok := getServiceInfo(id); ok {
+			services = append(services, info)
+		}
+	}
+
+	return services
+}
+
+// Get returns the information of the service which is running or recently terminated
+func (sm SMMgrImpl) Get(serviceID uint64) (map[string]interface{}, error) {
+	info, ok := getServiceInfo(serviceID)
+	if !ok {
+		return nil, ErrInvalidService
+	}
+	return info, nil
+}
+
+// Watch returns a channel which receives the current information of the service and every status change after it.
+// The channel is closed when the service is terminated or the returned cancel function is called.
+func (sm SMMgrImpl) Watch(serviceID uint64) (<-chan map[string]interface{}, func(), error) {
+	return watchers.add(serviceID)
+}
+
+// ReadLogs returns a channel which receives the last tail lines of the output of the service, 0 or less means every line.
+// When follow is true, the channel keeps receiving the next lines until the service is terminated or the returned cancel function is called.
+func (sm SMMgrImpl) ReadLogs(serviceID uint64, tail int, follow bool) (<-chan servicelog.Entry, func(), error) {
+	info, ok := getServiceInfo(serviceID)
+	if !ok {
+		return nil, nil, ErrInvalidService
+	}
+
+	target := info[ConstKeyTarget].(string)
+	if servicemgrtypes.IsLocalTarget(target) {
+		return servicelog.Read(servicelog.Key(target, serviceID), tail, follow)
+	}
+
+	entries, finished, err := sm.Clienter.DoGetLogsRemoteDevice(serviceID, target, tail, time.Time{})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	logChan := make(chan servicelog.Entry)
+	done := make(chan struct{})
+	var once sync.Once
+	cancel := func() {
+		once.Do(func() { close(done) })
+	}
+
+	go func() {
+		defer close(logChan)
+		var since time.Time
+		for {
+			for _, entry := range entries {
+				select {
+				case logChan <- entry:
+				case <-done:
+					return
+				}
+				since = entry.Time
+			}
+			if !follow || finished {
+				return
+			}
+
+			select {
+			case <-time.After(logPollInterval):
+			case <-done:
+				return
+			}
+
+			entries, finished, err = sm.Clienter.DoGetLogsRemoteDevice(serviceID, target, 0, since)
+			if err != nil {
+				// @Note : keep polling from the same line while the remote device is not reachable
+				log.Println(logPrefix, "cannot poll the log of service", serviceID, ":", err.Error())
+				entries, finished = nil, false
+			}
+		}
+	}()
+	return logChan, cancel, nil
+}
+
+// ReadLogsOnLocal returns the last tail lines written after since of the output of the service executed on local device by the requester,
+// and whether the service is terminated
+func (sm SMMgrImpl) ReadLogsOnLocal(serviceID uint64, notificationTargetURL string, tail int, since time.Time) ([]servicelog.Entry, bool, error) {
+	return servicelog.Tail(servicelog.Key(notificationTargetURL, serviceID), tail, since)
+}
+
 // ExecuteAppOnLocal fills out service execution info and deliver it to executor
 func (sm SMMgrImpl) ExecuteAppOnLocal(appInfo map[string]interface{}) {
 	var serviceExecutionInfo executor.ServiceExecutionInfo
@@ -107,17 +229,58 @@ func (sm SMMgrImpl) ExecuteAppOnLocal(appInfo map[string]interface{}) {
 		ServiceID:             serviceID,
 		ServiceName:           serviceName,
 		ParamStr:              args,
-		NotificationTargetURL: notitargetURL}
+		NotificationTargetURL: notitargetURL,
+		Limits:                parseLimits(appInfo[ConstKeyLimits])}
 
 	go sm.serviceExecutor.Execute(serviceExecutionInfo)
 }
 
+// StopAppOnLocal delivers the stop request of the service to executor
+func (sm SMMgrImpl) StopAppOnLocal(serviceID uint64, notificationTargetURL string) (err error) {
+	serviceExecutionInfo := executor.ServiceExecutionInfo{
+		ServiceID:             serviceID,
+		NotificationTargetURL: notificationTargetURL}
+
+	return sm.serviceExecutor.Stop(serviceExecutionInfo)
+}
+
 func (sm SMMgrImpl) executeAppOnRemote(target string, appInfo map[string]interface{}) (err error) {
 	err = sm.Clienter.DoExecuteRemoteDevice(appInfo, target)
 	return
 }
 
-func makeAppInfo(target, name, requester string, args []interface{}, serviceID float64) (appInfo map[string]interface{}) {
+// listenServiceStatus records the status changes of the service until it is terminated,
+// and passes the last status to the requester
+func listenServiceStatus(serviceID uint64, statusChan <-chan notification.ServiceStatus, notiChan chan string) {
+	for {
+		serviceStatus := <-statusChan
+		log.Println(logPrefix, "service", serviceID, "is", serviceStatus.Status, "with exit code", serviceStatus.ExitCode, serviceStatus.Signal)
+
+		if serviceStatus.Progress != nil {
+			if info, ok := updateServiceProgress(serviceID, serviceStatus.Progress); ok {
+				watchers.publish(serviceID, info)
+			}
+			continue
+		}
+
+		if info, ok := updateServiceMap(serviceID, serviceStatus.Status, serviceStatus.ExitCode, serviceStatus.Signal); ok {
+			watchers.publish(serviceID, info)
+		}
+
+		if servicemgrtypes.IsTerminalStatus(serviceStatus.Status) {
+			time.AfterFunc(serviceRetention, func() {
+				deleteServiceMap(serviceID)
+			})
+
+			if notiChan != nil {
+				notiChan <- serviceStatus.Status
+			}
+			return
+		}
+	}
+}
+
+func makeAppInfo(target, name, requester string, args []interface{}, limits executor.ResourceLimits, serviceID float64) (appInfo map[string]interface{}) {
 	appInfo = make(map[string]interface{})
 
 	appInfo[ConstKeyServiceID] = serviceID
@@ -128,7 +291,25 @@ func makeAppInfo(target, name, requester string, args []interface{}, serviceID f
 	if args != nil {
 		appInfo[ConstKeyUserArgs] = args
 	}
+	if limits != (executor.ResourceLimits{}) {
+		appInfo[ConstKeyLimits] = limits
+	}
+
+	return
+}
 
+// parseLimits converts the limits of the app info, which is a map when it is received from the remote device
+func parseLimits(value interface{}) (limits executor.ResourceLimits) {
+	if value == nil {
+		return
+	}
+	data, err := json.Marshal(value)
+	if err == nil {
+		err = json.Unmarshal(data, &limits)
+	}
+	if err != nil {
+		log.Println(logPrefix, "invalid limits :", err.Error())
+	}
 	return
 }
 
@@ -18,13 +18,21 @@
 package servicemgr
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io/ioutil"
+	"os"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/lf-edge/edge-home-orchestration-go/internal/common/networkhelper"
+	"github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr/executor"
 	executorMock "github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr/executor/mocks"
+	"github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr/notification"
+	"github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr/servicelog"
 	clientApiMock "github.com/lf-edge/edge-home-orchestration-go/internal/restinterface/client/mocks"
 
 	"github.com/golang/mock/gomock"
@@ -67,7 +75,7 @@ func TestExecuteAppOnLocal(t *testing.T) {
 	ifArgs := make([]interface{}, len(paramStrWithArgs))
 	copy(ifArgs, paramStrWithArgs)
 
-	err := serviceIns.Execute(targetLocalAddr, serviceName, requester, ifArgs, notiChan)
+	_, err := serviceIns.Execute(targetLocalAddr, serviceName, requester, ifArgs, executor.ResourceLimits{}, notiChan)
 	checkError(t, err)
 
 	time.Sleep(time.Millisecond * 10)
@@ -95,10 +103,269 @@ func TestExecuteAppOnRemote(t *testing.T) {
 	serviceIns.SetLocalServiceExecutor(exec)
 	notiChan := make(chan string)
 
-	err := serviceIns.Execute(targetRemoteAddr, serviceName, requester, paramStrWithArgs, notiChan)
+	_, err := serviceIns.Execute(targetRemoteAddr, serviceName, requester, paramStrWithArgs, executor.ResourceLimits{}, notiChan)
 	checkError(t, err)
 }
 
+func TestExecuteAppOnRemoteFailed(t *testing.T) {
+	serviceIns := GetInstance()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := clientApiMock.NewMockClienter(ctrl)
+	client.EXPECT().DoExecuteRemoteDevice(gomock.Any(), gomock.Any()).Return(errors.New("invoked error"))
+
+	serviceIns.Clienter = client
+	notiChan := make(chan string, 1)
+
+	serviceID, err := serviceIns.Execute(targetRemoteAddr, serviceName, requester, paramStrWithArgs, executor.ResourceLimits{}, notiChan)
+	defer deleteServiceMap(serviceID)
+	if err == nil {
+		t.Error("unexpected success")
+	}
+
+	assertEqualStr(t, <-notiChan, ConstServiceStatusFailed)
+	info, err := serviceIns.Get(serviceID)
+	checkError(t, err)
+	assertEqualStr(t, info[ConstKeyStatus].(string), ConstServiceStatusFailed)
+	if info[ConstKeyExitCode].(int) != -1 {
+		t.Error("unexpected exit code :", info[ConstKeyExitCode])
+	}
+	if _, ok := info[ConstKeyEndTime].(time.Time); !ok {
+		t.Error("end time is not recorded")
+	}
+
+	if err := serviceIns.Stop(serviceID); err != ErrInvalidService {
+		t.Error("unexpected success to stop the terminated service")
+	}
+}
+
+func TestParseLimitsOfRemoteAppInfo(t *testing.T) {
+	limits := executor.ResourceLimits{
+		CPUShares:   512,
+		Memory:      64 * 1024 * 1024,
+		HealthCheck: &executor.HealthCheck{Command: []string{"true"}, Interval: time.Second, Retries: 3},
+	}
+	appInfo := makeAppInfo(targetRemoteAddr, serviceName, requester, paramStr, limits, 1)
+
+	data, err := json.Marshal(appInfo)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	var remoteAppInfo map[string]interface{}
+	if err := json.Unmarshal(data, &remoteAppInfo); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	if parsed := parseLimits(remoteAppInfo[ConstKeyLimits]); !reflect.DeepEqual(parsed, limits) {
+		t.Error("unexpected limits", parsed)
+	}
+	if parsed := parseLimits(nil); parsed != (executor.ResourceLimits{}) {
+		t.Error("unexpected limits", parsed)
+	}
+}
+
+func TestStop(t *testing.T) {
+	serviceIns := GetInstance()
+
+	t.Run("Local", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		exec := executorMock.NewMockServiceExecutor(ctrl)
+
+		serviceID := createServiceMap(targetLocalAddr, serviceName, requester)
+		defer deleteServiceMap(serviceID)
+
+		gomock.InOrder(
+			exec.EXPECT().SetClient(gomock.Any()),
+			exec.EXPECT().Stop(executor.ServiceExecutionInfo{ServiceID: serviceID, NotificationTargetURL: targetLocalAddr}).Return(nil),
+		)
+
+		serviceIns.SetLocalServiceExecutor(exec)
+		checkError(t, serviceIns.Stop(serviceID))
+	})
+	t.Run("Remote", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		client := clientApiMock.NewMockClienter(ctrl)
+
+		serviceID := createServiceMap(targetRemoteAddr, serviceName, requester)
+		defer deleteServiceMap(serviceID)
+
+		client.EXPECT().DoStopRemoteDevice(serviceID, targetRemoteAddr).Return(nil)
+
+		serviceIns.Clienter = client
+		checkError(t, serviceIns.Stop(serviceID))
+	})
+	t.Run("InvalidService", func(t *testing.T) {
+		if err := serviceIns.Stop(uint64(0)); err != ErrInvalidService {
+			t.Error("unexpected success")
+		}
+	})
+}
+
+func TestListAndGet(t *testing.T) {
+	serviceIns := GetInstance()
+
+	serviceID := createServiceMap(targetRemoteAddr, serviceName, requester)
+	defer deleteServiceMap(serviceID)
+
+	info, err := serviceIns.Get(serviceID)
+	checkError(t, err)
+	assertEqualStr(t, info[ConstKeyServiceName].(string), serviceName)
+	assertEqualStr(t, info[ConstKeyTarget].(string), targetRemoteAddr)
+	assertEqualStr(t, info[ConstKeyStatus].(string), ConstServiceStatusStarted)
+
+	found := false
+	for _, service := range serviceIns.List() {
+		if service[ConstKeyServiceID].(uint64) == serviceID {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("service is not listed")
+	}
+
+	if _, err := serviceIns.Get(uint64(0)); err != ErrInvalidService {
+		t.Error("unexpected success")
+	}
+}
+
+func TestWatch(t *testing.T) {
+	serviceIns := GetInstance()
+
+	t.Run("Success", func(t *testing.T) {
+		serviceID := createServiceMap(targetRemoteAddr, serviceName, requester)
+		defer deleteServiceMap(serviceID)
+
+		statusChan := make(chan notification.ServiceStatus, 1)
+		notiChan := make(chan string, 1)
+		go listenServiceStatus(serviceID, statusChan, notiChan)
+
+		watchChan, cancel, err := serviceIns.Watch(serviceID)
+		checkError(t, err)
+		defer cancel()
+
+		assertEqualStr(t, (<-watchChan)[ConstKeyStatus].(string), ConstServiceStatusStarted)
+
+		statusChan <- notification.ServiceStatus{Status: "Unhealthy"}
+		assertEqualStr(t, (<-watchChan)[ConstKeyStatus].(string), "Unhealthy")
+
+		statusChan <- notification.ServiceStatus{Status: ConstServiceStatusFailed, ExitCode: 2}
+		info := <-watchChan
+		assertEqualStr(t, info[ConstKeyStatus].(string), ConstServiceStatusFailed)
+		if info[ConstKeyExitCode].(int) != 2 {
+			t.Error("unexpected exit code :", info[ConstKeyExitCode])
+		}
+		if _, ok := <-watchChan; ok {
+			t.Error("watch channel is not closed")
+		}
+		assertEqualStr(t, <-notiChan, ConstServiceStatusFailed)
+	})
+	t.Run("TerminatedService", func(t *testing.T) {
+		serviceID := createServiceMap(targetRemoteAddr, serviceName, requester)
+		defer deleteServiceMap(serviceID)
+		updateServiceMap(serviceID, ConstServiceStatusFinished, 0, "")
+
+		watchChan, _, err := serviceIns.Watch(serviceID)
+		checkError(t, err)
+
+		assertEqualStr(t, (<-watchChan)[ConstKeyStatus].(string), ConstServiceStatusFinished)
+		if _, ok := <-watchChan; ok {
+			t.Error("watch channel is not closed")
+		}
+	})
+	t.Run("Cancel", func(t *testing.T) {
+		serviceID := createServiceMap(targetRemoteAddr, serviceName, requester)
+		defer deleteServiceMap(serviceID)
+
+		watchChan, cancel, err := serviceIns.Watch(serviceID)
+		checkError(t, err)
+		<-watchChan
+
+		cancel()
+		if _, ok := <-watchChan; ok {
+			t.Error("watch channel is not closed")
+		}
+	})
+	t.Run("InvalidService", func(t *testing.T) {
+		if _, _, err := serviceIns.Watch(uint64(0)); err != ErrInvalidService {
+			t.Error("unexpected success")
+		}
+	})
+}
+
+func TestReadLogs(t *testing.T) {
+	serviceIns := GetInstance()
+
+	t.Run("Local", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "servicelog")
+		checkError(t, err)
+		defer os.RemoveAll(dir)
+		servicelog.SetLogDir(dir)
+
+		serviceID := createServiceMap(targetLocalAddr, serviceName, requester)
+		defer deleteServiceMap(serviceID)
+
+		stdout := servicelog.NewWriter(servicelog.Key(targetLocalAddr, serviceID), servicelog.StreamStdout)
+		fmt.Fprintln(stdout, "hello")
+		stdout.Close()
+
+		logChan, cancel, err := serviceIns.ReadLogs(serviceID, 0, true)
+		checkError(t, err)
+		defer cancel()
+
+		if entry := <-logChan; entry.Message != "hello" {
+			t.Error("unexpected entry", entry)
+		}
+		if _, ok := <-logChan; ok {
+			t.Error("log channel is not closed")
+		}
+	})
+	t.Run("Remote", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		logPollInterval = time.Millisecond
+		defer func() { logPollInterval = time.Second }()
+
+		client := clientApiMock.NewMockClienter(ctrl)
+
+		serviceID := createServiceMap(targetRemoteAddr, serviceName, requester)
+		defer deleteServiceMap(serviceID)
+
+		first := servicelog.Entry{Time: time.Now(), Stream: servicelog.StreamStdout, Message: "first"}
+		second := servicelog.Entry{Time: first.Time.Add(time.Second), Stream: servicelog.StreamStderr, Message: "second"}
+		gomock.InOrder(
+			client.EXPECT().DoGetLogsRemoteDevice(serviceID, targetRemoteAddr, 10, time.Time{}).Return([]servicelog.Entry{first}, false, nil),
+			client.EXPECT().DoGetLogsRemoteDevice(serviceID, targetRemoteAddr, 0, first.Time).Return(nil, false, errors.New("unreachable")),
+			client.EXPECT().DoGetLogsRemoteDevice(serviceID, targetRemoteAddr, 0, first.Time).Return([]servicelog.Entry{second}, true, nil),
+		)
+
+		serviceIns.Clienter = client
+		logChan, cancel, err := serviceIns.ReadLogs(serviceID, 10, true)
+		checkError(t, err)
+		defer cancel()
+
+		for _, expected := range []servicelog.Entry{first, second} {
+			if entry := <-logChan; entry != expected {
+				t.Error("unexpected entry", entry)
+			}
+		}
+		if _, ok := <-logChan; ok {
+			t.Error("log channel is not closed")
+		}
+	})
+	t.Run("InvalidService", func(t *testing.T) {
+		if _, _, err := serviceIns.ReadLogs(uint64(0), 0, false); err != ErrInvalidService {
+			t.Error("unexpected success")
+		}
+	})
+}
+
 /**************** SERVICEMGR REST INIT TEST ***********************/
 //func TestRestInit(t *testing.T) {
 //	//for coverage
@@ -21,6 +21,10 @@ import (
 	"errors"
 	"sync"
 	"sync/atomic"
+	"time"
+
+	"github.com/lf-edge/edge-home-orchestration-go/internal/common/types/servicemgrtypes"
+	"github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr/servicelog"
 )
 
 const logPrefix = "[servicemgr]"
@@ -44,9 +48,27 @@ const (
 	// ConstKeyRequester is key for requester
 	ConstKeyRequester = "Requester"
 
+	// ConstKeyLimits is key of the resource limits of the service
+	ConstKeyLimits = "Limits"
+
 	// ConstKeyNotiTargetURL is key of notification target URL
 	ConstKeyNotiTargetURL = "NotificationTargetURL"
 
+	// ConstKeyTarget is key of target device executing the service
+	ConstKeyTarget = "Target"
+
+	// ConstKeyExitCode is key of exit code
+	ConstKeyExitCode = "ExitCode"
+
+	// ConstKeySignal is key of the signal terminating the service
+	ConstKeySignal = "Signal"
+
+	// ConstKeyStartTime is key of the time the service is requested
+	ConstKeyStartTime = "StartTime"
+
+	// ConstKeyEndTime is key of the time the service is terminated
+	ConstKeyEndTime = "EndTime"
+
 	// ConstServiceStatusFailed is service status is failed
 	ConstServiceStatusFailed = "Failed"
 
@@ -56,6 +78,18 @@ const (
 	// ConstServiceStatusFinished is service status is finished
 	ConstServiceStatusFinished = "Finished"
 
+	// ConstServiceStatusStopped is service status is stopped by request
+	ConstServiceStatusStopped = "Stopped"
+
+	// ConstServiceStatusPulling is service status is pulling the image
+	ConstServiceStatusPulling = "Pulling"
+
+	// ConstServiceStatusUnhealthy is service status is running but its health check fails
+	ConstServiceStatusUnhealthy = "Unhealthy"
+
+	// ConstKeyPullProgress is key of the image pull progress
+	ConstKeyPullProgress = "PullProgress"
+
 	// ConstServiceFound is service status is found
 	ConstServiceFound = "Found"
 
@@ -80,6 +114,7 @@ type ServiceDestroyResponse struct {
 type StatusNotification struct {
 	ServiceID uint64 `json:"ServiceID"`
 	Status    string `json:"Status"`
+	ExitCode  int    `json:"ExitCode"`
 }
 
 // ConcurrentMap struct
@@ -103,8 +138,22 @@ var (
 
 	// ServiceIdx is for unique service ID (process id)
 	ServiceIdx uint64
+
+	// serviceRetention is how long the terminated service is kept to be queried, as long as its log
+	serviceRetention = servicelog.DefaultRetention
+
+	// logPollInterval is how often the log of the service executed on the remote device is polled while it is followed
+	logPollInterval = time.Second
+
+	watchers = serviceWatchers{items: make(map[uint64]map[chan map[string]interface{}]bool)}
 )
 
+// serviceWatchers holds the channels watching the status of the services
+type serviceWatchers struct {
+	sync.Mutex
+	items map[uint64]map[chan map[string]interface{}]bool
+}
+
 // Set is for setting map item
 func (cm *ConcurrentMap) Set(key uint64, value interface{}) {
 	cm.Lock()
@@ -148,22 +197,173 @@ func (cm *ConcurrentMap) Iter() <-chan ConcurrentMapItem {
 	return c
 }
 
-func createServiceMap(name string) uint64 {
+func createServiceMap(target, name, requester string) uint64 {
 	serviceID := getServiceIdx()
 
 	value := make(map[string]interface{})
 
+	value[ConstKeyServiceID] = serviceID
 	value[ConstKeyServiceName] = name
+	value[ConstKeyTarget] = target
+	value[ConstKeyRequester] = requester
+	value[ConstKeyStatus] = ConstServiceStatusStarted
+	value[ConstKeyExitCode] = 0
+	value[ConstKeyStartTime] = time.Now()
 
 	ServiceMap.Set(serviceID, value)
 
 	return serviceID
 }
 
+// updateServiceMap applies the status of the service and returns a copy of the updated information
+func updateServiceMap(serviceID uint64, status string, exitCode int, signal string) (map[string]interface{}, bool) {
+	ServiceMap.Lock()
+	defer ServiceMap.Unlock()
+
+	value, ok := ServiceMap.items[serviceID]
+	if !ok {
+		return nil, false
+	}
+
+	item, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+
+	item[ConstKeyStatus] = status
+	item[ConstKeyExitCode] = exitCode
+	if len(signal) > 0 {
+		item[ConstKeySignal] = signal
+	} else {
+		delete(item, ConstKeySignal)
+	}
+	delete(item, ConstKeyPullProgress)
+	if servicemgrtypes.IsTerminalStatus(status) {
+		item[ConstKeyEndTime] = time.Now()
+	}
+
+	return copyServiceInfo(item), true
+}
+
+// updateServiceProgress sets the service to the Pulling status with the image pull progress
+func updateServiceProgress(serviceID uint64, progress map[string]interface{}) (map[string]interface{}, bool) {
+	ServiceMap.Lock()
+	defer ServiceMap.Unlock()
+
+	value, ok := ServiceMap.items[serviceID]
+	if !ok {
+		return nil, false
+	}
+
+	item, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+
+	item[ConstKeyStatus] = ConstServiceStatusPulling
+	item[ConstKeyPullProgress] = progress
+
+	return copyServiceInfo(item), true
+}
+
 func deleteServiceMap(serviceID uint64) {
 	ServiceMap.Remove(serviceID)
 }
 
+// getServiceInfo returns a copy of the service information to keep the map item unchanged
+func getServiceInfo(serviceID uint64) (map[string]interface{}, bool) {
+	value, ok := ServiceMap.Get(serviceID)
+	if !ok {
+		return nil, false
+	}
+
+	item, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+
+	ServiceMap.Lock()
+	defer ServiceMap.Unlock()
+
+	return copyServiceInfo(item), true
+}
+
+func copyServiceInfo(item map[string]interface{}) map[string]interface{} {
+	info := make(map[string]interface{}, len(item))
+	for k, v := range item {
+		info[k] = v
+	}
+	return info
+}
+
+// add registers a channel to watch the service, or gives the last status if the service is terminated
+func (sw *serviceWatchers) add(serviceID uint64) (<-chan map[string]interface{}, func(), error) {
+	sw.Lock()
+	defer sw.Unlock()
+
+	info, ok := getServiceInfo(serviceID)
+	if !ok {
+		return nil, nil, ErrInvalidService
+	}
+
+	watchChan := make(chan map[string]interface{}, 8)
+	watchChan <- info
+	if servicemgrtypes.IsTerminalStatus(info[ConstKeyStatus].(string)) {
+		close(watchChan)
+		return watchChan, func() {}, nil
+	}
+
+	if sw.items[serviceID] == nil {
+		sw.items[serviceID] = make(map[chan map[string]interface{}]bool)
+	}
+	sw.items[serviceID][watchChan] = true
+
+	cancel := func() {
+		sw.Lock()
+		defer sw.Unlock()
+
+		if sw.items[serviceID][watchChan] {
+			delete(sw.items[serviceID], watchChan)
+			close(watchChan)
+		}
+	}
+
+	return watchChan, cancel, nil
+}
+
+// publish passes the service information to the watchers without blocking
+func (sw *serviceWatchers) publish(serviceID uint64, info map[string]interface{}) {
+	sw.Lock()
+	defer sw.Unlock()
+
+	terminated := servicemgrtypes.IsTerminalStatus(info[ConstKeyStatus].(string))
+	for watchChan := range sw.items[serviceID] {
+		select {
+		case watchChan <- copyServiceInfo(info):
+		default:
+			log.Println(logPrefix, "watcher of service", serviceID, "is too slow, the status is dropped")
+		}
+		if terminated {
+			close(watchChan)
+		}
+	}
+
+	if terminated {
+		delete(sw.items, serviceID)
+	}
+}
+
+// ReserveServiceIdx raises the global serviceID to id at least, so that the next services
+// do not reuse the IDs of the services still running since before restarting
+func ReserveServiceIdx(id uint64) {
+	for {
+		current := atomic.LoadUint64(&ServiceIdx)
+		if current >= id || atomic.CompareAndSwapUint64(&ServiceIdx, current, id) {
+			return
+		}
+	}
+}
+
 // getServiceIdx() is for getting global serviceID
 func getServiceIdx() uint64 {
 	atomic.AddUint64(&ServiceIdx, 1)
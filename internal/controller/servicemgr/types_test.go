@@ -78,3 +78,13 @@ func TestMapIter(t *testing.T) {
 		}
 	}
 }
+
+func TestReserveServiceIdx(t *testing.T) {
+	ReserveServiceIdx(getServiceIdx() + 10)
+	reserved := ServiceIdx
+
+	ReserveServiceIdx(1)
+	if id := getServiceIdx(); id != reserved+1 {
+		t.Error("unexpected service id", id)
+	}
+}
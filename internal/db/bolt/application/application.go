@@ -21,6 +21,7 @@ import (
 	"encoding/json"
 
 	"github.com/lf-edge/edge-home-orchestration-go/internal/common/errors"
+	types "github.com/lf-edge/edge-home-orchestration-go/internal/common/types/configuremgrtypes"
 	bolt "github.com/lf-edge/edge-home-orchestration-go/internal/db/bolt/wrapper"
 )
 
@@ -28,11 +29,18 @@ const bucketName = "application"
 
 // Info struct
 type Info struct {
-	ServiceName        string   `json:"serviceName"`
-	ExecutableFileName string   `json:"executableFileName"`
-	AllowedRequester   []string `json:"allowedRequester"`
-	ExecType           string   `json:"execType"`
-	ExecCmd            []string `json:"execCmd"`
+	ServiceName        string             `json:"serviceName"`
+	ExecutableFileName string             `json:"executableFileName"`
+	AllowedRequester   []string           `json:"allowedRequester"`
+	ExecType           string             `json:"execType"`
+	ExecCmd            []string           `json:"execCmd"`
+	ScoringType        string             `json:"scoringType,omitempty"`
+	ScoringWeights     map[string]float64 `json:"scoringWeights,omitempty"`
+	RunAsUser          string             `json:"runAsUser,omitempty"`
+	RunAsGroup         string             `json:"runAsGroup,omitempty"`
+	Restart            string             `json:"restart,omitempty"`
+	Sandbox            types.Sandbox      `json:"sandbox,omitempty"`
+	AppDir             string             `json:"appDir,omitempty"`
 }
 
 // DBInterface interface
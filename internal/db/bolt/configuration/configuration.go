@@ -28,9 +28,10 @@ const bucketName = "configuration"
 
 // Configuration struct
 type Configuration struct {
-	ID       string `json:"id"`
-	Platform string `json:"platform"`
-	ExecType string `json:"executionType"`
+	ID       string            `json:"id"`
+	Platform string            `json:"platform"`
+	ExecType string            `json:"executionType"`
+	Labels   map[string]string `json:"labels,omitempty"`
 }
 
 // DBInterface interface
@@ -115,6 +116,7 @@ func (Query) Update(conf Configuration) error {
 
 	stored.Platform = conf.Platform
 	stored.ExecType = conf.ExecType
+	stored.Labels = conf.Labels
 
 	encoded, err := stored.encode()
 	if err != nil {
@@ -134,6 +136,7 @@ func (conf Configuration) convertToMap() map[string]interface{} {
 		"id":            conf.ID,
 		"platform":      conf.Platform,
 		"executionType": conf.ExecType,
+		"labels":        conf.Labels,
 	}
 }
 
@@ -0,0 +1,259 @@
+/*******************************************************************************
+ * Copyright 2020 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+
+// Package execution keeps the history of the service executions requested by this device
+package execution
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/lf-edge/edge-home-orchestration-go/internal/common/errors"
+	bolt "github.com/lf-edge/edge-home-orchestration-go/internal/db/bolt/wrapper"
+)
+
+const (
+	bucketName = "execution"
+
+	// DefaultMaxRecords is the default maximum number of the kept executions
+	DefaultMaxRecords = 1000
+	// DefaultMaxAge is the default time to keep an execution from its start time
+	DefaultMaxAge = 7 * 24 * time.Hour
+
+	// pruneInterval is the number of the executions set between two prunings
+	pruneInterval = 100
+)
+
+// Info struct
+type Info struct {
+	ID            string                 `json:"id"`
+	ServiceID     uint64                 `json:"serviceId"`
+	ServiceName   string                 `json:"serviceName"`
+	Requester     string                 `json:"requester"`
+	Target        string                 `json:"target"`
+	ExecutionType string                 `json:"executionType"`
+	Score         float64                `json:"score"`
+	Resource      map[string]interface{} `json:"resource,omitempty"`
+	Args          []string               `json:"args"`
+	StartTime     time.Time              `json:"startTime"`
+	EndTime       time.Time              `json:"endTime"`
+	Status        string                 `json:"status"`
+}
+
+// DBInterface interface
+type DBInterface interface {
+	Get(id string) (Info, error)
+	GetList() ([]Info, error)
+	GetByService(name string) ([]Info, error)
+	GetByDevice(target string) ([]Info, error)
+	GetByTimeRange(from, to time.Time) ([]Info, error)
+	Set(info Info) error
+	Update(info Info) error
+	Delete(id string) error
+}
+
+// Query struct
+type Query struct {
+}
+
+type retentionPolicy struct {
+	sync.Mutex
+	maxRecords int
+	maxAge     time.Duration
+	sets       int
+}
+
+var (
+	db        bolt.Database
+	retention = retentionPolicy{maxRecords: DefaultMaxRecords, maxAge: DefaultMaxAge}
+)
+
+func init() {
+	db = bolt.NewBoltDB(bucketName)
+}
+
+// NewID returns the ID of the execution, the IDs are ordered by the start time
+// since the service IDs start again from the beginning after restarting
+func NewID(serviceID uint64, startTime time.Time) string {
+	return fmt.Sprintf("%020d-%d", startTime.UnixNano(), serviceID)
+}
+
+// SetRetention sets the maximum number and the maximum age of the kept executions,
+// 0 means no limit. The oldest ones are deleted when the next execution is set and
+// then once every pruneInterval executions, so the limits can be briefly exceeded
+func SetRetention(maxRecords int, maxAge time.Duration) {
+	retention.Lock()
+	defer retention.Unlock()
+
+	retention.maxRecords = maxRecords
+	retention.maxAge = maxAge
+	retention.sets = 0
+}
+
+// Get returns the execution info that matches id
+func (Query) Get(id string) (Info, error) {
+	value, err := db.Get([]byte(id))
+	if err != nil {
+		return Info{}, err
+	}
+	return decode(value)
+}
+
+// GetList returns the list of the execution info ordered by the start time
+func (Query) GetList() ([]Info, error) {
+	return getList(func(Info) bool { return true })
+}
+
+// GetByService returns the executions of the service
+func (Query) GetByService(name string) ([]Info, error) {
+	return getList(func(info Info) bool { return info.ServiceName == name })
+}
+
+// GetByDevice returns the executions on the target device
+func (Query) GetByDevice(target string) ([]Info, error) {
+	return getList(func(info Info) bool { return info.Target == target })
+}
+
+// GetByTimeRange returns the executions started between from and to, zero to means now
+func (Query) GetByTimeRange(from, to time.Time) ([]Info, error) {
+	if to.IsZero() {
+		to = time.Now()
+	}
+	return getList(func(info Info) bool {
+		return !info.StartTime.Before(from) && !info.StartTime.After(to)
+	})
+}
+
+// Set sets the execution info and deletes the executions out of the retention when pruning is due
+func (Query) Set(info Info) error {
+	if len(info.ID) == 0 {
+		info.ID = NewID(info.ServiceID, info.StartTime)
+	}
+
+	encoded, err := info.encode()
+	if err != nil {
+		return err
+	}
+
+	err = db.Put([]byte(info.ID), encoded)
+	if err != nil {
+		return err
+	}
+	return prune()
+}
+
+// Update updates the status and the end time of the execution that matches id
+func (Query) Update(info Info) error {
+	data, err := db.Get([]byte(info.ID))
+	if err != nil {
+		return errors.DBOperationError{Message: err.Error()}
+	}
+
+	stored, err := decode(data)
+	if err != nil {
+		return err
+	}
+
+	stored.Status = info.Status
+	stored.EndTime = info.EndTime
+
+	encoded, err := stored.encode()
+	if err != nil {
+		return err
+	}
+	return db.Put([]byte(info.ID), encoded)
+}
+
+// Delete deletes the execution info that matches id
+func (Query) Delete(id string) error {
+	return db.Delete([]byte(id))
+}
+
+func getList(match func(Info) bool) ([]Info, error) {
+	infos, err := db.List()
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]Info, 0)
+	for _, data := range infos {
+		info, err := decode([]byte(data.(string)))
+		if err != nil || !match(info) {
+			continue
+		}
+		list = append(list, info)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].ID < list[j].ID
+	})
+	return list, nil
+}
+
+func prune() error {
+	retention.Lock()
+	due := retention.sets == 0
+	retention.sets = (retention.sets + 1) % pruneInterval
+	maxRecords, maxAge := retention.maxRecords, retention.maxAge
+	retention.Unlock()
+
+	if !due || (maxRecords <= 0 && maxAge <= 0) {
+		return nil
+	}
+
+	list, err := getList(func(Info) bool { return true })
+	if err != nil {
+		return err
+	}
+
+	expired := 0
+	if maxRecords > 0 && len(list) > maxRecords {
+		expired = len(list) - maxRecords
+	}
+	if maxAge > 0 {
+		deadline := time.Now().Add(-maxAge)
+		for expired < len(list) && list[expired].StartTime.Before(deadline) {
+			expired++
+		}
+	}
+
+	for _, info := range list[:expired] {
+		if err := db.Delete([]byte(info.ID)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (info Info) encode() ([]byte, error) {
+	encoded, err := json.Marshal(info)
+	if err != nil {
+		return nil, errors.InvalidJSON{Message: err.Error()}
+	}
+	return encoded, nil
+}
+
+func decode(data []byte) (Info, error) {
+	var info Info
+	err := json.Unmarshal(data, &info)
+	if err != nil {
+		return info, errors.InvalidJSON{Message: err.Error()}
+	}
+	return info, nil
+}
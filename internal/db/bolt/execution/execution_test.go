@@ -0,0 +1,269 @@
+/*******************************************************************************
+ * Copyright 2020 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+
+package execution
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/lf-edge/edge-home-orchestration-go/internal/common/errors"
+	wrapperMock "github.com/lf-edge/edge-home-orchestration-go/internal/db/bolt/wrapper/mocks"
+
+	"github.com/golang/mock/gomock"
+)
+
+var (
+	startTime = time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	oldInfo = Info{
+		ID:          NewID(1, startTime),
+		ServiceID:   1,
+		ServiceName: "hello",
+		Requester:   "requester",
+		Target:      "192.168.1.2",
+		Score:       0.5,
+		Args:        []string{"hello"},
+		StartTime:   startTime,
+		Status:      "Started",
+	}
+	newInfo = Info{
+		ID:          NewID(2, startTime.Add(time.Hour)),
+		ServiceID:   2,
+		ServiceName: "world",
+		Requester:   "requester",
+		Target:      "192.168.1.3",
+		Score:       0.7,
+		Args:        []string{"world"},
+		StartTime:   startTime.Add(time.Hour),
+		EndTime:     startTime.Add(2 * time.Hour),
+		Status:      "Finished",
+	}
+)
+
+func getStoredList(t *testing.T, infos ...Info) map[string]interface{} {
+	stored := make(map[string]interface{})
+	for _, info := range infos {
+		encoded, err := info.encode()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		stored[info.ID] = string(encoded)
+	}
+	return stored
+}
+
+func TestNewID(t *testing.T) {
+	if NewID(10, startTime) >= NewID(1, startTime.Add(time.Nanosecond)) {
+		t.Error("IDs are not ordered by the start time")
+	}
+}
+
+func TestGet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	wrapperMockObj := wrapperMock.NewMockDatabase(ctrl)
+	db = wrapperMockObj
+
+	t.Run("Success", func(t *testing.T) {
+		encoded, _ := newInfo.encode()
+		wrapperMockObj.EXPECT().Get([]byte(newInfo.ID)).Return(encoded, nil)
+
+		info, err := Query{}.Get(newInfo.ID)
+		if err != nil {
+			t.Error("unexpected error", err.Error())
+		} else if !reflect.DeepEqual(info, newInfo) {
+			t.Error("expected", newInfo, "actual", info)
+		}
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		wrapperMockObj.EXPECT().Get([]byte("invalid")).Return(nil, errors.NotFound{})
+
+		if _, err := (Query{}).Get("invalid"); err == nil {
+			t.Error("unexpected success")
+		}
+	})
+}
+
+func TestQueries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	wrapperMockObj := wrapperMock.NewMockDatabase(ctrl)
+	db = wrapperMockObj
+
+	stored := getStoredList(t, newInfo, oldInfo)
+	stored["broken"] = "{"
+	wrapperMockObj.EXPECT().List().Return(stored, nil).AnyTimes()
+
+	query := Query{}
+	t.Run("GetList", func(t *testing.T) {
+		list, err := query.GetList()
+		if err != nil {
+			t.Error("unexpected error", err.Error())
+		} else if !reflect.DeepEqual(list, []Info{oldInfo, newInfo}) {
+			t.Error("unexpected list", list)
+		}
+	})
+	t.Run("GetByService", func(t *testing.T) {
+		list, err := query.GetByService("world")
+		if err != nil || len(list) != 1 || list[0].ID != newInfo.ID {
+			t.Error("unexpected list", list)
+		}
+	})
+	t.Run("GetByDevice", func(t *testing.T) {
+		list, err := query.GetByDevice("192.168.1.2")
+		if err != nil || len(list) != 1 || list[0].ID != oldInfo.ID {
+			t.Error("unexpected list", list)
+		}
+	})
+	t.Run("GetByTimeRange", func(t *testing.T) {
+		list, err := query.GetByTimeRange(startTime.Add(time.Minute), time.Time{})
+		if err != nil || len(list) != 1 || list[0].ID != newInfo.ID {
+			t.Error("unexpected list", list)
+		}
+
+		list, err = query.GetByTimeRange(startTime, startTime.Add(time.Minute))
+		if err != nil || len(list) != 1 || list[0].ID != oldInfo.ID {
+			t.Error("unexpected list", list)
+		}
+	})
+}
+
+func TestSet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	wrapperMockObj := wrapperMock.NewMockDatabase(ctrl)
+	db = wrapperMockObj
+	defer SetRetention(DefaultMaxRecords, DefaultMaxAge)
+
+	t.Run("Success", func(t *testing.T) {
+		SetRetention(0, 0)
+
+		info := newInfo
+		info.ID = ""
+		wrapperMockObj.EXPECT().Put([]byte(newInfo.ID), gomock.Any()).Return(nil)
+
+		if err := (Query{}).Set(info); err != nil {
+			t.Error("unexpected error", err.Error())
+		}
+	})
+	t.Run("MaxRecords", func(t *testing.T) {
+		SetRetention(1, 0)
+
+		gomock.InOrder(
+			wrapperMockObj.EXPECT().Put([]byte(newInfo.ID), gomock.Any()).Return(nil),
+			wrapperMockObj.EXPECT().List().Return(getStoredList(t, oldInfo, newInfo), nil),
+			wrapperMockObj.EXPECT().Delete([]byte(oldInfo.ID)).Return(nil),
+		)
+
+		if err := (Query{}).Set(newInfo); err != nil {
+			t.Error("unexpected error", err.Error())
+		}
+	})
+	t.Run("MaxAge", func(t *testing.T) {
+		SetRetention(0, time.Hour)
+
+		recent := newInfo
+		recent.StartTime = time.Now()
+		recent.ID = NewID(recent.ServiceID, recent.StartTime)
+		gomock.InOrder(
+			wrapperMockObj.EXPECT().Put([]byte(recent.ID), gomock.Any()).Return(nil),
+			wrapperMockObj.EXPECT().List().Return(getStoredList(t, oldInfo, newInfo, recent), nil),
+			wrapperMockObj.EXPECT().Delete([]byte(oldInfo.ID)).Return(nil),
+			wrapperMockObj.EXPECT().Delete([]byte(newInfo.ID)).Return(nil),
+		)
+
+		if err := (Query{}).Set(recent); err != nil {
+			t.Error("unexpected error", err.Error())
+		}
+	})
+	t.Run("PruneInterval", func(t *testing.T) {
+		SetRetention(1, 0)
+
+		gomock.InOrder(
+			wrapperMockObj.EXPECT().Put([]byte(newInfo.ID), gomock.Any()).Return(nil),
+			wrapperMockObj.EXPECT().List().Return(getStoredList(t, oldInfo, newInfo), nil),
+			wrapperMockObj.EXPECT().Delete([]byte(oldInfo.ID)).Return(nil),
+			wrapperMockObj.EXPECT().Put([]byte(newInfo.ID), gomock.Any()).Return(nil).Times(pruneInterval-1),
+			wrapperMockObj.EXPECT().Put([]byte(newInfo.ID), gomock.Any()).Return(nil),
+			wrapperMockObj.EXPECT().List().Return(getStoredList(t, newInfo), nil),
+		)
+
+		for i := 0; i <= pruneInterval; i++ {
+			if err := (Query{}).Set(newInfo); err != nil {
+				t.Error("unexpected error", err.Error())
+			}
+		}
+	})
+	t.Run("Error", func(t *testing.T) {
+		wrapperMockObj.EXPECT().Put(gomock.Any(), gomock.Any()).Return(errors.DBOperationError{})
+
+		if err := (Query{}).Set(newInfo); err == nil {
+			t.Error("unexpected success")
+		}
+	})
+}
+
+func TestUpdate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	wrapperMockObj := wrapperMock.NewMockDatabase(ctrl)
+	db = wrapperMockObj
+
+	t.Run("Success", func(t *testing.T) {
+		encoded, _ := oldInfo.encode()
+		finished := oldInfo
+		finished.Status = "Finished"
+		finished.EndTime = startTime.Add(time.Minute)
+		expected, _ := finished.encode()
+
+		gomock.InOrder(
+			wrapperMockObj.EXPECT().Get([]byte(oldInfo.ID)).Return(encoded, nil),
+			wrapperMockObj.EXPECT().Put([]byte(oldInfo.ID), expected).Return(nil),
+		)
+
+		err := Query{}.Update(Info{ID: oldInfo.ID, Status: finished.Status, EndTime: finished.EndTime})
+		if err != nil {
+			t.Error("unexpected error", err.Error())
+		}
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		wrapperMockObj.EXPECT().Get([]byte("invalid")).Return(nil, errors.NotFound{})
+
+		if err := (Query{}).Update(Info{ID: "invalid"}); err == nil {
+			t.Error("unexpected success")
+		}
+	})
+}
+
+func TestDelete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	wrapperMockObj := wrapperMock.NewMockDatabase(ctrl)
+	db = wrapperMockObj
+
+	wrapperMockObj.EXPECT().Delete([]byte(oldInfo.ID)).Return(nil)
+	if err := (Query{}).Delete(oldInfo.ID); err != nil {
+		t.Error("unexpected error", err.Error())
+	}
+}
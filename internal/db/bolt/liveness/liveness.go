@@ -0,0 +1,134 @@
+/*******************************************************************************
+ * Copyright 2020 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+
+// Package liveness keeps the liveness state of the other orchestration devices
+package liveness
+
+import (
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/lf-edge/edge-home-orchestration-go/internal/common/errors"
+	bolt "github.com/lf-edge/edge-home-orchestration-go/internal/db/bolt/wrapper"
+)
+
+const (
+	bucketName = "liveness"
+
+	// StateOnline is the state of the device answering the heartbeats
+	StateOnline = "online"
+	// StateDegraded is the state of the device which missed a heartbeat
+	StateDegraded = "degraded"
+	// StateUnreachable is the state of the device which missed the heartbeats longer than the grace period,
+	// it is not selected to execute services
+	StateUnreachable = "unreachable"
+	// StateRemoved is the state of the device deleted from the device lists
+	StateRemoved = "removed"
+)
+
+// Info struct
+type Info struct {
+	ID       string    `json:"id"`
+	State    string    `json:"state"`
+	LastSeen time.Time `json:"lastSeen"`
+	Since    time.Time `json:"since"`
+}
+
+// DBInterface interface
+type DBInterface interface {
+	Get(id string) (Info, error)
+	GetList() ([]Info, error)
+	Set(info Info) error
+	Delete(id string) error
+}
+
+// Query struct
+type Query struct {
+}
+
+var db bolt.Database
+
+func init() {
+	db = bolt.NewBoltDB(bucketName)
+}
+
+// Get returns the liveness info that matches id
+func (Query) Get(id string) (Info, error) {
+	value, err := db.Get([]byte(id))
+	if err != nil {
+		return Info{}, err
+	}
+	return decode(value)
+}
+
+// GetList returns the list of the liveness info ordered by id
+func (Query) GetList() ([]Info, error) {
+	infos, err := db.List()
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]Info, 0)
+	for _, data := range infos {
+		info, err := decode([]byte(data.(string)))
+		if err != nil {
+			continue
+		}
+		list = append(list, info)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].ID < list[j].ID
+	})
+	return list, nil
+}
+
+// Set sets the liveness info for id
+func (Query) Set(info Info) error {
+	encoded, err := info.encode()
+	if err != nil {
+		return err
+	}
+	return db.Put([]byte(info.ID), encoded)
+}
+
+// Delete deletes the liveness info for id
+func (Query) Delete(id string) error {
+	return db.Delete([]byte(id))
+}
+
+// IsSelectable checks if the device in the state can be selected to execute services
+func IsSelectable(state string) bool {
+	return state != StateUnreachable && state != StateRemoved
+}
+
+func (info Info) encode() ([]byte, error) {
+	encoded, err := json.Marshal(info)
+	if err != nil {
+		return nil, errors.InvalidJSON{Message: err.Error()}
+	}
+	return encoded, nil
+}
+
+func decode(data []byte) (Info, error) {
+	var info Info
+	err := json.Unmarshal(data, &info)
+	if err != nil {
+		return info, errors.InvalidJSON{Message: err.Error()}
+	}
+	return info, nil
+}
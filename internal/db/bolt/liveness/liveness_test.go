@@ -0,0 +1,146 @@
+/*******************************************************************************
+ * Copyright 2020 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+
+package liveness
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/lf-edge/edge-home-orchestration-go/internal/common/errors"
+	wrapperMock "github.com/lf-edge/edge-home-orchestration-go/internal/db/bolt/wrapper/mocks"
+
+	"github.com/golang/mock/gomock"
+)
+
+var (
+	lastSeen = time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	onlineInfo = Info{
+		ID:       "edge-orchestration-device1",
+		State:    StateOnline,
+		LastSeen: lastSeen,
+		Since:    lastSeen.Add(-time.Hour),
+	}
+	unreachableInfo = Info{
+		ID:       "edge-orchestration-device2",
+		State:    StateUnreachable,
+		LastSeen: lastSeen,
+		Since:    lastSeen.Add(30 * time.Second),
+	}
+)
+
+func TestGet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	wrapperMockObj := wrapperMock.NewMockDatabase(ctrl)
+	db = wrapperMockObj
+
+	t.Run("Success", func(t *testing.T) {
+		encoded, _ := onlineInfo.encode()
+		wrapperMockObj.EXPECT().Get([]byte(onlineInfo.ID)).Return(encoded, nil)
+
+		info, err := Query{}.Get(onlineInfo.ID)
+		if err != nil {
+			t.Error("unexpected error", err.Error())
+		} else if !reflect.DeepEqual(info, onlineInfo) {
+			t.Error("expected", onlineInfo, "actual", info)
+		}
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		wrapperMockObj.EXPECT().Get([]byte("invalid")).Return(nil, errors.NotFound{})
+
+		if _, err := (Query{}).Get("invalid"); err == nil {
+			t.Error("unexpected success")
+		}
+	})
+}
+
+func TestGetList(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	wrapperMockObj := wrapperMock.NewMockDatabase(ctrl)
+	db = wrapperMockObj
+
+	t.Run("Success", func(t *testing.T) {
+		stored := make(map[string]interface{})
+		for _, info := range []Info{unreachableInfo, onlineInfo} {
+			encoded, _ := info.encode()
+			stored[info.ID] = string(encoded)
+		}
+		stored["broken"] = "{"
+		wrapperMockObj.EXPECT().List().Return(stored, nil)
+
+		list, err := Query{}.GetList()
+		if err != nil {
+			t.Error("unexpected error", err.Error())
+		} else if !reflect.DeepEqual(list, []Info{onlineInfo, unreachableInfo}) {
+			t.Error("unexpected list", list)
+		}
+	})
+	t.Run("Error", func(t *testing.T) {
+		wrapperMockObj.EXPECT().List().Return(nil, errors.DBOperationError{})
+
+		if _, err := (Query{}).GetList(); err == nil {
+			t.Error("unexpected success")
+		}
+	})
+}
+
+func TestSet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	wrapperMockObj := wrapperMock.NewMockDatabase(ctrl)
+	db = wrapperMockObj
+
+	encoded, _ := onlineInfo.encode()
+	wrapperMockObj.EXPECT().Put([]byte(onlineInfo.ID), encoded).Return(nil)
+	if err := (Query{}).Set(onlineInfo); err != nil {
+		t.Error("unexpected error", err.Error())
+	}
+}
+
+func TestDelete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	wrapperMockObj := wrapperMock.NewMockDatabase(ctrl)
+	db = wrapperMockObj
+
+	wrapperMockObj.EXPECT().Delete([]byte(onlineInfo.ID)).Return(nil)
+	if err := (Query{}).Delete(onlineInfo.ID); err != nil {
+		t.Error("unexpected error", err.Error())
+	}
+}
+
+func TestIsSelectable(t *testing.T) {
+	tests := map[string]bool{
+		StateOnline:      true,
+		StateDegraded:    true,
+		StateUnreachable: false,
+		StateRemoved:     false,
+	}
+	for state, expected := range tests {
+		if IsSelectable(state) != expected {
+			t.Error("unexpected result of", state)
+		}
+	}
+}
@@ -27,18 +27,27 @@ import (
 
 const bucketName = "network"
 
+// Address families of Info
+const (
+	FamilyIPv4 = "IPv4"
+	FamilyIPv6 = "IPv6"
+)
+
 // Info struct
 type Info struct {
 	ID   string   `json:"id"`
 	IPv4 []string `json:"IPv4"`
+	IPv6 []string `json:"IPv6,omitempty"`
 	RTT  float64  `json:"RTT"`
+	// Family is the address family with the best RTT, IPv4 is preferred without it
+	Family string `json:"Family,omitempty"`
 }
 
 // DBInterface interface
 type DBInterface interface {
 	Get(id string) (Info, error)
 	GetList() ([]Info, error)
-	GetIDWithIP(IPv4 string) (string, error)
+	GetIDWithIP(IP string) (string, error)
 	Set(conf Info) error
 	Update(conf Info) error
 	Delete(id string) error
@@ -97,7 +106,7 @@ func (q Query) GetIDWithIP(IP string) (string, error) {
 	}
 
 	for _, info := range netInfo {
-		if common.HasElem(info.IPv4, IP) {
+		if common.HasElem(info.IPv4, IP) || common.HasElem(info.IPv6, IP) {
 			return info.ID, nil
 		}
 	}
@@ -136,9 +145,17 @@ func (Query) Update(info Info) error {
 			stored.IPv4 = append(stored.IPv4, ip)
 		}
 	}
+	for _, ip := range info.IPv6 {
+		if !common.HasElem(stored.IPv6, ip) {
+			stored.IPv6 = append(stored.IPv6, ip)
+		}
+	}
 	if info.RTT != 0.0 {
 		stored.RTT = info.RTT
 	}
+	if len(info.Family) != 0 {
+		stored.Family = info.Family
+	}
 
 	encoded, err := stored.encode()
 	if err != nil {
@@ -153,11 +170,24 @@ func (Query) Delete(id string) error {
 	return db.Delete([]byte(id))
 }
 
+// GetIPs returns the addresses of both families, the addresses of the preferred family come first
+func (info Info) GetIPs() []string {
+	ips := make([]string, 0, len(info.IPv4)+len(info.IPv6))
+	if info.Family == FamilyIPv6 {
+		ips = append(ips, info.IPv6...)
+		return append(ips, info.IPv4...)
+	}
+	ips = append(ips, info.IPv4...)
+	return append(ips, info.IPv6...)
+}
+
 func (info Info) convertToMap() map[string]interface{} {
 	return map[string]interface{}{
-		"id":   info.ID,
-		"IPv4": info.IPv4,
-		"RTT":  info.RTT,
+		"id":     info.ID,
+		"IPv4":   info.IPv4,
+		"IPv6":   info.IPv6,
+		"RTT":    info.RTT,
+		"Family": info.Family,
 	}
 }
 
@@ -40,6 +40,7 @@ const (
 var (
 	ipv4List    = []string{"192.168.0.1"}
 	ipv4List2   = []string{"192.168.0.1", "192.168.0.2"}
+	ipv6List    = []string{"fd00::1"}
 	notFoundErr = errors.NotFound{Message: invalidID + " does not exist"}
 	dbOPErr     = errors.DBOperationError{}
 
@@ -231,6 +232,42 @@ func TestUpdate_ExpectedSuccess(t *testing.T) {
 	}
 }
 
+func TestUpdate_WithIPv6_ExpectedSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	wrapperMockObj := wrapperMock.NewMockDatabase(ctrl)
+
+	netStructIPv6 := Info{ID: validID, IPv6: ipv6List, RTT: rtt2, Family: FamilyIPv6}
+	expected := Info{ID: validID, IPv4: ipv4List, IPv6: ipv6List, RTT: rtt2, Family: FamilyIPv6}
+	updatedNetInfoByte, _ := json.Marshal(expected)
+
+	gomock.InOrder(
+		wrapperMockObj.EXPECT().Get([]byte(validID)).Return([]byte(netJSON), nil),
+		wrapperMockObj.EXPECT().Put([]byte(validID), updatedNetInfoByte).Return(nil),
+	)
+
+	db = wrapperMockObj
+	query := Query{}
+
+	err := query.Update(netStructIPv6)
+	if err != nil {
+		t.Errorf("Unexpected err: %s", err.Error())
+	}
+}
+
+func TestGetIPs(t *testing.T) {
+	info := Info{ID: validID, IPv4: ipv4List, IPv6: ipv6List}
+	if ips := info.GetIPs(); !reflect.DeepEqual(ips, append(ipv4List, ipv6List...)) {
+		t.Errorf("Unexpected IPs: %v", ips)
+	}
+
+	info.Family = FamilyIPv6
+	if ips := info.GetIPs(); !reflect.DeepEqual(ips, append(ipv6List, ipv4List...)) {
+		t.Errorf("Unexpected IPs: %v", ips)
+	}
+}
+
 func TestUpdate_WhenNotfoundMatchedConfWithID_ExpectedErrorReturn(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -0,0 +1,92 @@
+/*******************************************************************************
+ * Copyright 2020 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+// Package snapshot keeps the last resource values given by each device answering the heartbeats
+package snapshot
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/lf-edge/edge-home-orchestration-go/internal/common/errors"
+	bolt "github.com/lf-edge/edge-home-orchestration-go/internal/db/bolt/wrapper"
+)
+
+const bucketName = "snapshot"
+
+// Info struct
+type Info struct {
+	ID       string                 `json:"id"`
+	Resource map[string]interface{} `json:"resource"`
+	Time     time.Time              `json:"time"`
+}
+
+// DBInterface interface
+type DBInterface interface {
+	Get(id string) (Info, error)
+	Set(info Info) error
+	Delete(id string) error
+}
+
+// Query struct
+type Query struct {
+}
+
+var db bolt.Database
+
+func init() {
+	db = bolt.NewBoltDB(bucketName)
+}
+
+// Get returns the resource snapshot that matches id
+func (Query) Get(id string) (Info, error) {
+	value, err := db.Get([]byte(id))
+	if err != nil {
+		return Info{}, err
+	}
+	return decode(value)
+}
+
+// Set sets the resource snapshot for id
+func (Query) Set(info Info) error {
+	encoded, err := info.encode()
+	if err != nil {
+		return err
+	}
+	return db.Put([]byte(info.ID), encoded)
+}
+
+// Delete deletes the resource snapshot for id
+func (Query) Delete(id string) error {
+	return db.Delete([]byte(id))
+}
+
+func (info Info) encode() ([]byte, error) {
+	encoded, err := json.Marshal(info)
+	if err != nil {
+		return nil, errors.InvalidJSON{Message: err.Error()}
+	}
+	return encoded, nil
+}
+
+func decode(data []byte) (Info, error) {
+	var info Info
+	err := json.Unmarshal(data, &info)
+	if err != nil {
+		return info, errors.InvalidJSON{Message: err.Error()}
+	}
+	return info, nil
+}
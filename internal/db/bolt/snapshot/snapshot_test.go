@@ -0,0 +1,95 @@
+/*******************************************************************************
+ * Copyright 2020 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+package snapshot
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/lf-edge/edge-home-orchestration-go/internal/common/errors"
+	wrapperMock "github.com/lf-edge/edge-home-orchestration-go/internal/db/bolt/wrapper/mocks"
+
+	"github.com/golang/mock/gomock"
+)
+
+var testInfo = Info{
+	ID:       "edge-orchestration-device1",
+	Resource: map[string]interface{}{"cpuUsage": 12.5, "memFree": float64(1024)},
+	Time:     time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC),
+}
+
+func TestGet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	wrapperMockObj := wrapperMock.NewMockDatabase(ctrl)
+	db = wrapperMockObj
+
+	t.Run("Success", func(t *testing.T) {
+		encoded, _ := testInfo.encode()
+		wrapperMockObj.EXPECT().Get([]byte(testInfo.ID)).Return(encoded, nil)
+
+		info, err := Query{}.Get(testInfo.ID)
+		if err != nil {
+			t.Error("unexpected error", err.Error())
+		} else if !reflect.DeepEqual(info, testInfo) {
+			t.Error("expected", testInfo, "actual", info)
+		}
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		wrapperMockObj.EXPECT().Get([]byte("invalid")).Return(nil, errors.NotFound{})
+
+		if _, err := (Query{}).Get("invalid"); err == nil {
+			t.Error("unexpected success")
+		}
+	})
+	t.Run("InvalidData", func(t *testing.T) {
+		wrapperMockObj.EXPECT().Get([]byte("broken")).Return([]byte("{"), nil)
+
+		if _, err := (Query{}).Get("broken"); err == nil {
+			t.Error("unexpected success")
+		}
+	})
+}
+
+func TestSet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	wrapperMockObj := wrapperMock.NewMockDatabase(ctrl)
+	db = wrapperMockObj
+
+	encoded, _ := testInfo.encode()
+	wrapperMockObj.EXPECT().Put([]byte(testInfo.ID), encoded).Return(nil)
+	if err := (Query{}).Set(testInfo); err != nil {
+		t.Error("unexpected error", err.Error())
+	}
+}
+
+func TestDelete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	wrapperMockObj := wrapperMock.NewMockDatabase(ctrl)
+	db = wrapperMockObj
+
+	wrapperMockObj.EXPECT().Delete([]byte(testInfo.ID)).Return(nil)
+	if err := (Query{}).Delete(testInfo.ID); err != nil {
+		t.Error("unexpected error", err.Error())
+	}
+}
@@ -1,22 +1,28 @@
 package helper
 
 import (
+	"sort"
 	"strings"
+	"time"
 
 	errormsg "github.com/lf-edge/edge-home-orchestration-go/internal/common/errormsg"
 	errors "github.com/lf-edge/edge-home-orchestration-go/internal/common/errors"
 	"github.com/lf-edge/edge-home-orchestration-go/internal/db/bolt/common"
 	configurationdb "github.com/lf-edge/edge-home-orchestration-go/internal/db/bolt/configuration"
+	livenessdb "github.com/lf-edge/edge-home-orchestration-go/internal/db/bolt/liveness"
 	networkdb "github.com/lf-edge/edge-home-orchestration-go/internal/db/bolt/network"
 	servicedb "github.com/lf-edge/edge-home-orchestration-go/internal/db/bolt/service"
+	snapshotdb "github.com/lf-edge/edge-home-orchestration-go/internal/db/bolt/snapshot"
 	systemdb "github.com/lf-edge/edge-home-orchestration-go/internal/db/bolt/system"
 )
 
 var (
-	confQuery    configurationdb.DBInterface
-	netQuery     networkdb.DBInterface
-	serviceQuery servicedb.DBInterface
-	sysQuery     systemdb.DBInterface
+	confQuery     configurationdb.DBInterface
+	netQuery      networkdb.DBInterface
+	serviceQuery  servicedb.DBInterface
+	sysQuery      systemdb.DBInterface
+	livenessQuery livenessdb.DBInterface
+	snapshotQuery snapshotdb.DBInterface
 )
 
 func init() {
@@ -24,12 +30,17 @@ func init() {
 	confQuery = configurationdb.Query{}
 	serviceQuery = servicedb.Query{}
 	sysQuery = systemdb.Query{}
+	livenessQuery = livenessdb.Query{}
+	snapshotQuery = snapshotdb.Query{}
 }
 
 // MultipleBucketQuery provides interfaces for the helper
 type MultipleBucketQuery interface {
 	GetDeviceID() (string, error)
 	GetDeviceInfoWithService(serviceName string, executionTypes []string, installed bool) ([]ExecutionCandidate, error)
+	GetDevicesWithLabels(selector map[string]string) (map[string]map[string]string, error)
+	GetDevices() ([]DeviceInfo, error)
+	GetDevice(id string) (DeviceInfo, error)
 }
 
 // ExecutionCandidate structure
@@ -37,6 +48,27 @@ type ExecutionCandidate struct {
 	ID       string
 	ExecType string
 	Endpoint []string
+	Platform string
+	Labels   map[string]string
+}
+
+// DeviceInfo is the information of a device known by the orchestration
+type DeviceInfo struct {
+	ID       string
+	Platform string
+	ExecType string
+	IPv4     []string
+	IPv6     []string
+	RTT      float64
+	// Family is the address family preferred to reach the device
+	Family   string
+	Services []string
+	Labels   map[string]string
+	State    string
+	LastSeen time.Time
+	// Resource is the last resource values given by the device for its heartbeat
+	Resource     map[string]interface{}
+	ResourceTime time.Time
 }
 
 type multipleBucketQuery struct{}
@@ -71,6 +103,10 @@ func (multipleBucketQuery) GetDeviceInfoWithService(serviceName string, executio
 			continue
 		}
 
+		if !isSelectable(confItem.ID) {
+			continue
+		}
+
 		if confItem.ExecType == "container" && !installed {
 			endpoints, err := getEndpoints(confItem.ID)
 			if err != nil {
@@ -81,6 +117,8 @@ func (multipleBucketQuery) GetDeviceInfoWithService(serviceName string, executio
 				ID:       confItem.ID,
 				ExecType: confItem.ExecType,
 				Endpoint: endpoints,
+				Platform: confItem.Platform,
+				Labels:   confItem.Labels,
 			}
 
 			ret = append(ret, info)
@@ -103,6 +141,8 @@ func (multipleBucketQuery) GetDeviceInfoWithService(serviceName string, executio
 					ID:       serviceItem.ID,
 					ExecType: confItem.ExecType,
 					Endpoint: endpoints,
+					Platform: confItem.Platform,
+					Labels:   confItem.Labels,
 				}
 
 				ret = append(ret, info)
@@ -119,14 +159,116 @@ func (multipleBucketQuery) GetDeviceInfoWithService(serviceName string, executio
 	return ret, nil
 }
 
+// GetDevicesWithLabels returns the labels of the devices keyed by device ID which have every label of the selector
+func (multipleBucketQuery) GetDevicesWithLabels(selector map[string]string) (map[string]map[string]string, error) {
+	confItems, err := confQuery.GetList()
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make(map[string]map[string]string)
+	for _, confItem := range confItems {
+		if !MatchLabels(confItem.Labels, selector) {
+			continue
+		}
+		labels := confItem.Labels
+		if labels == nil {
+			labels = make(map[string]string)
+		}
+		ret[confItem.ID] = labels
+	}
+
+	return ret, nil
+}
+
+// GetDevices returns the information of every device ordered by device ID
+func (multipleBucketQuery) GetDevices() ([]DeviceInfo, error) {
+	confItems, err := confQuery.GetList()
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(confItems, func(i, j int) bool {
+		return confItems[i].ID < confItems[j].ID
+	})
+
+	myID, _ := query.GetDeviceID()
+	ret := make([]DeviceInfo, 0, len(confItems))
+	for _, confItem := range confItems {
+		ret = append(ret, getDeviceInfo(confItem, myID))
+	}
+
+	return ret, nil
+}
+
+// GetDevice returns the information of the device
+func (multipleBucketQuery) GetDevice(id string) (DeviceInfo, error) {
+	confItem, err := confQuery.Get(id)
+	if err != nil {
+		return DeviceInfo{}, err
+	}
+
+	myID, _ := query.GetDeviceID()
+	return getDeviceInfo(confItem, myID), nil
+}
+
+// MatchLabels checks whether the labels have every label of the selector with the same value
+func MatchLabels(labels map[string]string, selector map[string]string) bool {
+	for key, value := range selector {
+		if label, ok := labels[key]; !ok || label != value {
+			return false
+		}
+	}
+	return true
+}
+
+// isSelectable checks whether the device is not unreachable, the device without the liveness is selectable
+func isSelectable(id string) bool {
+	info, err := livenessQuery.Get(id)
+	if err != nil {
+		return true
+	}
+	return livenessdb.IsSelectable(info.State)
+}
+
+// getDeviceInfo gathers the information of the device from the buckets, the missing parts are left empty
+func getDeviceInfo(confItem configurationdb.Configuration, myID string) DeviceInfo {
+	info := DeviceInfo{
+		ID:       confItem.ID,
+		Platform: confItem.Platform,
+		ExecType: confItem.ExecType,
+		Labels:   confItem.Labels,
+	}
+
+	if netItem, err := netQuery.Get(confItem.ID); err == nil {
+		info.IPv4 = netItem.IPv4
+		info.IPv6 = netItem.IPv6
+		info.RTT = netItem.RTT
+		info.Family = netItem.Family
+	}
+	if serviceItem, err := serviceQuery.Get(confItem.ID); err == nil {
+		info.Services = serviceItem.Services
+	}
+	if snapshotItem, err := snapshotQuery.Get(confItem.ID); err == nil {
+		info.Resource = snapshotItem.Resource
+		info.ResourceTime = snapshotItem.Time
+	}
+
+	if confItem.ID == myID {
+		info.State = livenessdb.StateOnline
+		info.LastSeen = time.Now()
+	} else if livenessItem, err := livenessQuery.Get(confItem.ID); err == nil {
+		info.State = livenessItem.State
+		info.LastSeen = livenessItem.LastSeen
+	}
+
+	return info
+}
+
 func getEndpoints(id string) ([]string, error) {
 	netItems, err := netQuery.Get(id)
 	if err != nil {
 		return nil, err
 	}
 
-	endpoints := make([]string, len(netItems.IPv4))
-	copy(endpoints, netItems.IPv4)
-
-	return endpoints, nil
+	return netItems.GetIPs(), nil
 }
@@ -20,24 +20,31 @@ package helper
 import (
 	"github.com/golang/mock/gomock"
 	"testing"
+	"time"
 
 	"errors"
 
 	configuration "github.com/lf-edge/edge-home-orchestration-go/internal/db/bolt/configuration"
 	dbConfigurationMocks "github.com/lf-edge/edge-home-orchestration-go/internal/db/bolt/configuration/mocks"
+	liveness "github.com/lf-edge/edge-home-orchestration-go/internal/db/bolt/liveness"
+	dbLivenessMocks "github.com/lf-edge/edge-home-orchestration-go/internal/db/bolt/liveness/mocks"
 	network "github.com/lf-edge/edge-home-orchestration-go/internal/db/bolt/network"
 	dbNetworkMocks "github.com/lf-edge/edge-home-orchestration-go/internal/db/bolt/network/mocks"
 	service "github.com/lf-edge/edge-home-orchestration-go/internal/db/bolt/service"
 	dbServiceMocks "github.com/lf-edge/edge-home-orchestration-go/internal/db/bolt/service/mocks"
+	snapshot "github.com/lf-edge/edge-home-orchestration-go/internal/db/bolt/snapshot"
+	dbSnapshotMocks "github.com/lf-edge/edge-home-orchestration-go/internal/db/bolt/snapshot/mocks"
 	system "github.com/lf-edge/edge-home-orchestration-go/internal/db/bolt/system"
 	dbSysMocks "github.com/lf-edge/edge-home-orchestration-go/internal/db/bolt/system/mocks"
 )
 
 var (
-	mockConf    *dbConfigurationMocks.MockDBInterface
-	mockNet     *dbNetworkMocks.MockDBInterface
-	mockService *dbServiceMocks.MockDBInterface
-	mockSys     *dbSysMocks.MockDBInterface
+	mockConf     *dbConfigurationMocks.MockDBInterface
+	mockNet      *dbNetworkMocks.MockDBInterface
+	mockService  *dbServiceMocks.MockDBInterface
+	mockSys      *dbSysMocks.MockDBInterface
+	mockLiveness *dbLivenessMocks.MockDBInterface
+	mockSnapshot *dbSnapshotMocks.MockDBInterface
 )
 
 func testInit(ctrl *gomock.Controller) func() {
@@ -45,22 +52,30 @@ func testInit(ctrl *gomock.Controller) func() {
 	originNetQuery := netQuery
 	originServiceQuery := serviceQuery
 	originSysQuery := sysQuery
+	originLivenessQuery := livenessQuery
+	originSnapshotQuery := snapshotQuery
 
 	mockConf = dbConfigurationMocks.NewMockDBInterface(ctrl)
 	mockNet = dbNetworkMocks.NewMockDBInterface(ctrl)
 	mockService = dbServiceMocks.NewMockDBInterface(ctrl)
 	mockSys = dbSysMocks.NewMockDBInterface(ctrl)
+	mockLiveness = dbLivenessMocks.NewMockDBInterface(ctrl)
+	mockSnapshot = dbSnapshotMocks.NewMockDBInterface(ctrl)
 
 	confQuery = mockConf
 	netQuery = mockNet
 	serviceQuery = mockService
 	sysQuery = mockSys
+	livenessQuery = mockLiveness
+	snapshotQuery = mockSnapshot
 
 	return func() {
 		confQuery = originConfQuery
 		netQuery = originNetQuery
 		serviceQuery = originServiceQuery
 		sysQuery = originSysQuery
+		livenessQuery = originLivenessQuery
+		snapshotQuery = originSnapshotQuery
 	}
 }
 
@@ -70,6 +85,8 @@ func TestGetDeviceInfoWithService(t *testing.T) {
 	defer ctrl.Finish()
 	defer f()
 
+	mockLiveness.EXPECT().Get(gomock.Any()).Return(liveness.Info{}, errors.New("")).AnyTimes()
+
 	t.Run("Success", func(t *testing.T) {
 		gomock.InOrder(
 			mockConf.EXPECT().GetList().Return([]configuration.Configuration{
@@ -114,6 +131,9 @@ func TestGetDeviceInfoWithService(t *testing.T) {
 			if candidate.ID != "test" {
 				t.Error("unexpected service id")
 			}
+			if candidate.Platform != "test" {
+				t.Error("unexpected platform")
+			}
 			if candidate.ExecType == "container" {
 				if candidate.Endpoint[0] != "1.1.1.1" || candidate.Endpoint[1] != "1.1.1.2" {
 					t.Error("unexpected endpoint of container")
@@ -244,6 +264,51 @@ func TestGetDeviceInfoWithService(t *testing.T) {
 	})
 }
 
+func TestGetDeviceInfoWithServiceLiveness(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	f := testInit(ctrl)
+	defer ctrl.Finish()
+	defer f()
+
+	confItems := []configuration.Configuration{
+		{
+			ID:       "online",
+			Platform: "test",
+			ExecType: "container",
+		},
+		{
+			ID:       "unreachable",
+			Platform: "test",
+			ExecType: "container",
+		},
+	}
+
+	t.Run("ExcludeUnreachable", func(t *testing.T) {
+		mockConf.EXPECT().GetList().Return(confItems, nil)
+		mockLiveness.EXPECT().Get(gomock.Eq("online")).Return(liveness.Info{ID: "online", State: liveness.StateOnline}, nil)
+		mockLiveness.EXPECT().Get(gomock.Eq("unreachable")).Return(liveness.Info{ID: "unreachable", State: liveness.StateUnreachable}, nil)
+		mockNet.EXPECT().Get(gomock.Eq("online")).Return(network.Info{ID: "online", IPv4: []string{"1.1.1.1"}}, nil)
+
+		ret, err := GetInstance().GetDeviceInfoWithService("", []string{"container"}, false)
+		if err != nil {
+			t.Error("unexpected error")
+		} else if len(ret) != 1 || ret[0].ID != "online" {
+			t.Error("unexpected candidates", ret)
+		}
+	})
+	t.Run("AllUnreachable", func(t *testing.T) {
+		mockConf.EXPECT().GetList().Return(confItems[1:], nil)
+		mockLiveness.EXPECT().Get(gomock.Eq("unreachable")).Return(liveness.Info{ID: "unreachable", State: liveness.StateUnreachable}, nil)
+
+		ret, err := GetInstance().GetDeviceInfoWithService("", []string{"container"}, false)
+		if err == nil {
+			t.Error("unexpected success")
+		} else if ret != nil {
+			t.Error("unexpected success")
+		}
+	})
+}
+
 func TestGetDeviceID(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	f := testInit(ctrl)
@@ -271,3 +336,178 @@ func TestGetDeviceID(t *testing.T) {
 		}
 	})
 }
+
+func TestGetDevicesWithLabels(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	f := testInit(ctrl)
+	defer ctrl.Finish()
+	defer f()
+
+	confItems := []configuration.Configuration{
+		{
+			ID:       "kitchen",
+			Platform: "linux",
+			ExecType: "native",
+			Labels:   map[string]string{"room": "kitchen", "power": "mains"},
+		},
+		{
+			ID:       "bedroom",
+			Platform: "linux",
+			ExecType: "native",
+			Labels:   map[string]string{"room": "bedroom"},
+		},
+		{
+			ID:       "nolabel",
+			Platform: "linux",
+			ExecType: "native",
+		},
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mockConf.EXPECT().GetList().Return(confItems, nil)
+
+		ret, err := GetInstance().GetDevicesWithLabels(map[string]string{"room": "kitchen"})
+		if err != nil {
+			t.Error("unexpected error")
+		} else if len(ret) != 1 || ret["kitchen"]["power"] != "mains" {
+			t.Error("unexpected devices", ret)
+		}
+	})
+	t.Run("EmptySelector", func(t *testing.T) {
+		mockConf.EXPECT().GetList().Return(confItems, nil)
+
+		ret, err := GetInstance().GetDevicesWithLabels(nil)
+		if err != nil {
+			t.Error("unexpected error")
+		} else if len(ret) != len(confItems) {
+			t.Error("unexpected devices", ret)
+		}
+	})
+	t.Run("Error", func(t *testing.T) {
+		mockConf.EXPECT().GetList().Return(nil, errors.New(""))
+
+		if _, err := GetInstance().GetDevicesWithLabels(nil); err == nil {
+			t.Error("unexpected success")
+		}
+	})
+}
+
+func TestGetDevices(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	f := testInit(ctrl)
+	defer ctrl.Finish()
+	defer f()
+
+	resourceTime := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	confItems := []configuration.Configuration{
+		{
+			ID:       "remote",
+			Platform: "linux",
+			ExecType: "container",
+			Labels:   map[string]string{"room": "kitchen"},
+		},
+		{
+			ID:       "local",
+			Platform: "linux",
+			ExecType: "native",
+		},
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mockConf.EXPECT().GetList().Return(confItems, nil)
+		mockSys.EXPECT().Get(gomock.Eq("id")).Return(system.Info{Name: "id", Value: "local"}, nil)
+		mockNet.EXPECT().Get(gomock.Eq("local")).Return(network.Info{ID: "local", IPv4: []string{"1.1.1.1"}}, nil)
+		mockNet.EXPECT().Get(gomock.Eq("remote")).Return(network.Info{ID: "remote", IPv4: []string{"1.1.1.2"}, RTT: 0.5}, nil)
+		mockService.EXPECT().Get(gomock.Eq("local")).Return(service.Info{}, errors.New(""))
+		mockService.EXPECT().Get(gomock.Eq("remote")).Return(service.Info{ID: "remote", Services: []string{"testService1"}}, nil)
+		mockSnapshot.EXPECT().Get(gomock.Eq("local")).Return(snapshot.Info{}, errors.New(""))
+		mockSnapshot.EXPECT().Get(gomock.Eq("remote")).Return(snapshot.Info{
+			ID:       "remote",
+			Resource: map[string]interface{}{"cpuUsage": 12.5},
+			Time:     resourceTime,
+		}, nil)
+		mockLiveness.EXPECT().Get(gomock.Eq("remote")).Return(liveness.Info{ID: "remote", State: liveness.StateDegraded}, nil)
+
+		ret, err := GetInstance().GetDevices()
+		if err != nil {
+			t.Fatal("unexpected error")
+		} else if len(ret) != 2 || ret[0].ID != "local" || ret[1].ID != "remote" {
+			t.Fatal("unexpected devices", ret)
+		}
+
+		local, remote := ret[0], ret[1]
+		if local.State != liveness.StateOnline || local.IPv4[0] != "1.1.1.1" || local.Services != nil || local.Resource != nil {
+			t.Error("unexpected local device", local)
+		}
+		if remote.State != liveness.StateDegraded || remote.RTT != 0.5 || remote.Services[0] != "testService1" ||
+			remote.Labels["room"] != "kitchen" || remote.Resource["cpuUsage"] != 12.5 || !remote.ResourceTime.Equal(resourceTime) {
+			t.Error("unexpected remote device", remote)
+		}
+	})
+	t.Run("Error", func(t *testing.T) {
+		mockConf.EXPECT().GetList().Return(nil, errors.New(""))
+
+		if _, err := GetInstance().GetDevices(); err == nil {
+			t.Error("unexpected success")
+		}
+	})
+}
+
+func TestGetDevice(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	f := testInit(ctrl)
+	defer ctrl.Finish()
+	defer f()
+
+	t.Run("Success", func(t *testing.T) {
+		mockConf.EXPECT().Get(gomock.Eq("remote")).Return(configuration.Configuration{ID: "remote", Platform: "linux", ExecType: "native"}, nil)
+		mockSys.EXPECT().Get(gomock.Eq("id")).Return(system.Info{Name: "id", Value: "local"}, nil)
+		mockNet.EXPECT().Get(gomock.Eq("remote")).Return(network.Info{}, errors.New(""))
+		mockService.EXPECT().Get(gomock.Eq("remote")).Return(service.Info{}, errors.New(""))
+		mockSnapshot.EXPECT().Get(gomock.Eq("remote")).Return(snapshot.Info{}, errors.New(""))
+		mockLiveness.EXPECT().Get(gomock.Eq("remote")).Return(liveness.Info{}, errors.New(""))
+
+		ret, err := GetInstance().GetDevice("remote")
+		if err != nil {
+			t.Error("unexpected error")
+		} else if ret.ID != "remote" || ret.Platform != "linux" || ret.State != "" || ret.IPv4 != nil {
+			t.Error("unexpected device", ret)
+		}
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		mockConf.EXPECT().Get(gomock.Eq("invalid")).Return(configuration.Configuration{}, errors.New(""))
+
+		if _, err := GetInstance().GetDevice("invalid"); err == nil {
+			t.Error("unexpected success")
+		}
+	})
+}
+
+func TestGetEndpoints(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	defer testInit(ctrl)()
+
+	t.Run("IPv6Preferred", func(t *testing.T) {
+		mockNet.EXPECT().Get(gomock.Eq("remote")).Return(network.Info{
+			ID:     "remote",
+			IPv4:   []string{"1.1.1.2"},
+			IPv6:   []string{"fd00::2"},
+			Family: network.FamilyIPv6,
+		}, nil)
+
+		endpoints, err := getEndpoints("remote")
+		if err != nil {
+			t.Fatal(err.Error())
+		} else if len(endpoints) != 2 || endpoints[0] != "fd00::2" || endpoints[1] != "1.1.1.2" {
+			t.Error("unexpected endpoints", endpoints)
+		}
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		mockNet.EXPECT().Get(gomock.Eq("invalid")).Return(network.Info{}, errors.New(""))
+
+		if _, err := getEndpoints("invalid"); err == nil {
+			t.Error("unexpected success")
+		}
+	})
+}
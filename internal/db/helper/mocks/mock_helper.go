@@ -80,3 +80,48 @@ func (mr *MockMultipleBucketQueryMockRecorder) GetDeviceInfoWithService(serviceN
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeviceInfoWithService", reflect.TypeOf((*MockMultipleBucketQuery)(nil).GetDeviceInfoWithService), serviceName, executionTypes, installed)
 }
+
+// GetDevice mocks base method.
+func (m *MockMultipleBucketQuery) GetDevice(id string) (helper.DeviceInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDevice", id)
+	ret0, _ := ret[0].(helper.DeviceInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDevice indicates an expected call of GetDevice.
+func (mr *MockMultipleBucketQueryMockRecorder) GetDevice(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDevice", reflect.TypeOf((*MockMultipleBucketQuery)(nil).GetDevice), id)
+}
+
+// GetDevices mocks base method.
+func (m *MockMultipleBucketQuery) GetDevices() ([]helper.DeviceInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDevices")
+	ret0, _ := ret[0].([]helper.DeviceInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDevices indicates an expected call of GetDevices.
+func (mr *MockMultipleBucketQueryMockRecorder) GetDevices() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDevices", reflect.TypeOf((*MockMultipleBucketQuery)(nil).GetDevices))
+}
+
+// GetDevicesWithLabels mocks base method.
+func (m *MockMultipleBucketQuery) GetDevicesWithLabels(selector map[string]string) (map[string]map[string]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDevicesWithLabels", selector)
+	ret0, _ := ret[0].(map[string]map[string]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDevicesWithLabels indicates an expected call of GetDevicesWithLabels.
+func (mr *MockMultipleBucketQueryMockRecorder) GetDevicesWithLabels(selector interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDevicesWithLabels", reflect.TypeOf((*MockMultipleBucketQuery)(nil).GetDevicesWithLabels), selector)
+}
@@ -0,0 +1,214 @@
+/*******************************************************************************
+ * Copyright 2020 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+
+package orchestrationapi
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultClientTimeout is the default time to wait for the terminal status of the service
+	DefaultClientTimeout = 24 * time.Hour
+	// DefaultMaxClients is the default maximum number of the requesters waiting for the service status
+	DefaultMaxClients = 1024
+
+	// ExecutionTimedOut is the status of the execution whose requester stopped waiting by the timeout
+	ExecutionTimedOut = "TimedOut"
+	// ExecutionEvicted is the status of the execution whose requester was removed for a newer one
+	ExecutionEvicted = "Evicted"
+
+	clientStatsInterval = 10 * time.Minute
+)
+
+// ClientStats struct holds the counters of the requesters waiting for the service status
+type ClientStats struct {
+	// Active is the number of the requesters waiting for the service status
+	Active int
+	// Registered is the number of the requesters registered since start
+	Registered uint64
+	// Notified is the number of the requesters removed by the terminal status
+	Notified uint64
+	// TimedOut is the number of the requesters removed by the timeout
+	TimedOut uint64
+	// Evicted is the number of the oldest requesters removed to register a new one when the registry is full
+	Evicted uint64
+}
+
+type orcheClient struct {
+	appName     string
+	serviceID   uint64
+	executionID string
+	notiChan    chan string
+	endSignal   chan bool
+	added       time.Time
+}
+
+type clientRegistry struct {
+	sync.Mutex
+	clients    map[uint64]*orcheClient
+	timeout    time.Duration
+	maxClients int
+	stats      ClientStats
+}
+
+var clients = newClientRegistry()
+
+func newClientRegistry() *clientRegistry {
+	return &clientRegistry{
+		clients:    make(map[uint64]*orcheClient),
+		timeout:    DefaultClientTimeout,
+		maxClients: DefaultMaxClients,
+	}
+}
+
+// SetClientTimeout sets the time to wait for the terminal status of the service,
+// the requester is removed when the timeout fires, 0 means no timeout
+func SetClientTimeout(timeout time.Duration) {
+	clients.Lock()
+	defer clients.Unlock()
+
+	clients.timeout = timeout
+}
+
+// SetMaxClients sets the maximum number of the requesters waiting for the service status,
+// the oldest requester is evicted to register a new one when the registry is full, 0 means no limit
+func SetMaxClients(maxClients int) {
+	clients.Lock()
+	defer clients.Unlock()
+
+	clients.maxClients = maxClients
+}
+
+// add registers the requester of the service and listens the status of the service
+// until the terminal status arrives or the timeout fires, the execution of the requester
+// replaced or evicted by the new one is recorded as evicted
+func (r *clientRegistry) add(serviceID uint64, appName, executionID string, notiChan chan string) *orcheClient {
+	client := &orcheClient{
+		appName:     appName,
+		serviceID:   serviceID,
+		executionID: executionID,
+		notiChan:    notiChan,
+		endSignal:   make(chan bool, 1),
+		added:       time.Now(),
+	}
+
+	r.Lock()
+	var ended *orcheClient
+	if prev, ok := r.clients[serviceID]; ok {
+		prev.endSignal <- true
+		ended = prev
+	} else if r.maxClients > 0 && len(r.clients) >= r.maxClients {
+		ended = r.evictOldest()
+	}
+	r.clients[serviceID] = client
+	r.stats.Registered++
+	timeout := r.timeout
+	r.Unlock()
+
+	if ended != nil {
+		finishExecution(ended.executionID, ExecutionEvicted)
+	}
+
+	go client.listenNotify(r, timeout)
+	return client
+}
+
+// remove removes the requester if it is still registered for the service
+func (r *clientRegistry) remove(client *orcheClient) bool {
+	r.Lock()
+	defer r.Unlock()
+
+	if r.clients[client.serviceID] != client {
+		return false
+	}
+	delete(r.clients, client.serviceID)
+	return true
+}
+
+func (r *clientRegistry) getStats() ClientStats {
+	r.Lock()
+	defer r.Unlock()
+
+	stats := r.stats
+	stats.Active = len(r.clients)
+	return stats
+}
+
+// evictOldest removes the requester registered first and returns it, the caller holds the lock
+func (r *clientRegistry) evictOldest() *orcheClient {
+	var oldest *orcheClient
+	for _, client := range r.clients {
+		if oldest == nil || client.added.Before(oldest.added) {
+			oldest = client
+		}
+	}
+	if oldest == nil {
+		return nil
+	}
+
+	log.Printf("[orchestrationapi] too many requesters, stop waiting the service status [appNames:%s][serviceID:%d]\n", oldest.appName, oldest.serviceID)
+	oldest.endSignal <- true
+	delete(r.clients, oldest.serviceID)
+	r.stats.Evicted++
+	return oldest
+}
+
+// logStats logs the counters of the requesters periodically
+func (r *clientRegistry) logStats(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		stats := r.getStats()
+		log.Printf("[orchestrationapi] requesters waiting the service status [active:%d][registered:%d][notified:%d][timedOut:%d][evicted:%d]\n",
+			stats.Active, stats.Registered, stats.Notified, stats.TimedOut, stats.Evicted)
+	}
+}
+
+func (r *clientRegistry) count(counter *uint64) {
+	r.Lock()
+	defer r.Unlock()
+
+	*counter++
+}
+
+func (client *orcheClient) listenNotify(r *clientRegistry, timeout time.Duration) {
+	var expired <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		expired = timer.C
+	}
+
+	select {
+	case str := <-client.notiChan:
+		log.Printf("[orchestrationapi] service status changed [appNames:%s][status:%s]\n", client.appName, str)
+		finishExecution(client.executionID, str)
+		if r.remove(client) {
+			r.count(&r.stats.Notified)
+		}
+	case <-expired:
+		log.Printf("[orchestrationapi] service status timed out [appNames:%s][serviceID:%d]\n", client.appName, client.serviceID)
+		if r.remove(client) {
+			finishExecution(client.executionID, ExecutionTimedOut)
+			r.count(&r.stats.TimedOut)
+		}
+	case <-client.endSignal:
+	}
+}
@@ -0,0 +1,155 @@
+/*******************************************************************************
+ * Copyright 2020 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+
+package orchestrationapi
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr"
+	execDB "github.com/lf-edge/edge-home-orchestration-go/internal/db/bolt/execution"
+	dbexecutionMocks "github.com/lf-edge/edge-home-orchestration-go/internal/db/bolt/execution/mocks"
+)
+
+func waitClients(r *clientRegistry, active int) bool {
+	for i := 0; i < 100; i++ {
+		r.Lock()
+		n := len(r.clients)
+		r.Unlock()
+		if n == active {
+			return true
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return false
+}
+
+func TestClientRegistry(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockExecDB := dbexecutionMocks.NewMockDBInterface(ctrl)
+	defaultExecDB := execDBExecutor
+	execDBExecutor = mockExecDB
+	defer func() { execDBExecutor = defaultExecDB }()
+
+	t.Run("Notified", func(t *testing.T) {
+		r := newClientRegistry()
+		notiChan := make(chan string, 1)
+
+		mockExecDB.EXPECT().Update(gomock.Any()).DoAndReturn(func(info execDB.Info) error {
+			if info.ID != "exec" || info.Status != servicemgr.ConstServiceStatusFinished {
+				t.Error("unexpected update", info)
+			}
+			return nil
+		})
+
+		r.add(1, "app", "exec", notiChan)
+		if !waitClients(r, 1) {
+			t.Fatal("client is not registered")
+		}
+		notiChan <- servicemgr.ConstServiceStatusFinished
+		if !waitClients(r, 0) {
+			t.Fatal("client is not removed")
+		}
+		if r.stats.Registered != 1 || r.stats.Notified != 1 || r.stats.TimedOut != 0 {
+			t.Error("unexpected stats", r.stats)
+		}
+	})
+	t.Run("TimedOut", func(t *testing.T) {
+		r := newClientRegistry()
+		r.timeout = 10 * time.Millisecond
+
+		mockExecDB.EXPECT().Update(gomock.Any()).DoAndReturn(func(info execDB.Info) error {
+			if info.ID != "exec" || info.Status != ExecutionTimedOut {
+				t.Error("unexpected update", info)
+			}
+			return nil
+		})
+
+		r.add(1, "app", "exec", make(chan string))
+		if !waitClients(r, 0) {
+			t.Fatal("client is not removed")
+		}
+		if r.stats.Registered != 1 || r.stats.Notified != 0 || r.stats.TimedOut != 1 {
+			t.Error("unexpected stats", r.stats)
+		}
+	})
+	t.Run("Replaced", func(t *testing.T) {
+		r := newClientRegistry()
+
+		updated := make(chan execDB.Info, 1)
+		mockExecDB.EXPECT().Update(gomock.Any()).DoAndReturn(func(info execDB.Info) error {
+			updated <- info
+			return nil
+		})
+
+		first := r.add(1, "app", "exec1", make(chan string))
+		second := r.add(1, "app", "exec2", make(chan string))
+		if !waitClients(r, 1) || r.clients[1] != second || r.remove(first) {
+			t.Error("unexpected clients", r.clients)
+		}
+		if info := <-updated; info.ID != "exec1" || info.Status != ExecutionEvicted {
+			t.Error("unexpected update", info)
+		}
+	})
+	t.Run("Evicted", func(t *testing.T) {
+		r := newClientRegistry()
+		r.maxClients = 2
+
+		updated := make(chan execDB.Info, 1)
+		mockExecDB.EXPECT().Update(gomock.Any()).DoAndReturn(func(info execDB.Info) error {
+			updated <- info
+			return nil
+		})
+
+		first := r.add(1, "app", "exec1", make(chan string))
+		r.add(2, "app", "exec2", make(chan string))
+		r.add(3, "app", "exec3", make(chan string))
+		if !waitClients(r, 2) || r.remove(first) {
+			t.Error("unexpected clients", r.clients)
+		}
+		if stats := r.getStats(); stats.Active != 2 || stats.Registered != 3 || stats.Evicted != 1 {
+			t.Error("unexpected stats", stats)
+		}
+		if info := <-updated; info.ID != "exec1" || info.Status != ExecutionEvicted {
+			t.Error("unexpected update", info)
+		}
+	})
+	t.Run("Unbounded", func(t *testing.T) {
+		r := newClientRegistry()
+		r.timeout = 10 * time.Millisecond
+
+		var finished sync.WaitGroup
+		finished.Add(2048)
+		mockExecDB.EXPECT().Update(gomock.Any()).DoAndReturn(func(info execDB.Info) error {
+			finished.Done()
+			return nil
+		}).Times(2048)
+		for i := uint64(0); i < 2048; i++ {
+			r.add(i, "app", "exec", make(chan string))
+		}
+		if !waitClients(r, 0) {
+			t.Fatal("clients are not removed")
+		}
+		finished.Wait()
+	})
+}
@@ -24,8 +24,10 @@ package mocks
 import (
 	configuremgrtypes "github.com/lf-edge/edge-home-orchestration-go/internal/common/types/configuremgrtypes"
 	verifier "github.com/lf-edge/edge-home-orchestration-go/internal/controller/securemgr/verifier"
+	servicelog "github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr/servicelog"
 	orchestrationapi "github.com/lf-edge/edge-home-orchestration-go/internal/orchestrationapi"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 )
@@ -102,6 +104,166 @@ func (mr *MockOrcheExternalAPIMockRecorder) RequestService(arg0 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestService", reflect.TypeOf((*MockOrcheExternalAPI)(nil).RequestService), arg0)
 }
 
+// StopService mocks base method.
+func (m *MockOrcheExternalAPI) StopService(arg0 uint64) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StopService", arg0)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// StopService indicates an expected call of StopService.
+func (mr *MockOrcheExternalAPIMockRecorder) StopService(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopService", reflect.TypeOf((*MockOrcheExternalAPI)(nil).StopService), arg0)
+}
+
+// GetService mocks base method.
+func (m *MockOrcheExternalAPI) GetService(arg0 uint64) orchestrationapi.ResponseServiceInfo {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetService", arg0)
+	ret0, _ := ret[0].(orchestrationapi.ResponseServiceInfo)
+	return ret0
+}
+
+// GetService indicates an expected call of GetService.
+func (mr *MockOrcheExternalAPIMockRecorder) GetService(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetService", reflect.TypeOf((*MockOrcheExternalAPI)(nil).GetService), arg0)
+}
+
+// ListServices mocks base method.
+func (m *MockOrcheExternalAPI) ListServices() orchestrationapi.ResponseServiceInfo {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListServices")
+	ret0, _ := ret[0].(orchestrationapi.ResponseServiceInfo)
+	return ret0
+}
+
+// ListServices indicates an expected call of ListServices.
+func (mr *MockOrcheExternalAPIMockRecorder) ListServices() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListServices", reflect.TypeOf((*MockOrcheExternalAPI)(nil).ListServices))
+}
+
+// GetDeviceLabels mocks base method.
+func (m *MockOrcheExternalAPI) GetDeviceLabels(arg0 map[string]string) orchestrationapi.ResponseDeviceLabels {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeviceLabels", arg0)
+	ret0, _ := ret[0].(orchestrationapi.ResponseDeviceLabels)
+	return ret0
+}
+
+// GetDeviceLabels indicates an expected call of GetDeviceLabels.
+func (mr *MockOrcheExternalAPIMockRecorder) GetDeviceLabels(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeviceLabels", reflect.TypeOf((*MockOrcheExternalAPI)(nil).GetDeviceLabels), arg0)
+}
+
+// GetDevice mocks base method.
+func (m *MockOrcheExternalAPI) GetDevice(arg0 string) orchestrationapi.ResponseDevices {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDevice", arg0)
+	ret0, _ := ret[0].(orchestrationapi.ResponseDevices)
+	return ret0
+}
+
+// GetDevice indicates an expected call of GetDevice.
+func (mr *MockOrcheExternalAPIMockRecorder) GetDevice(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDevice", reflect.TypeOf((*MockOrcheExternalAPI)(nil).GetDevice), arg0)
+}
+
+// GetDevices mocks base method.
+func (m *MockOrcheExternalAPI) GetDevices() orchestrationapi.ResponseDevices {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDevices")
+	ret0, _ := ret[0].(orchestrationapi.ResponseDevices)
+	return ret0
+}
+
+// GetDevices indicates an expected call of GetDevices.
+func (mr *MockOrcheExternalAPIMockRecorder) GetDevices() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDevices", reflect.TypeOf((*MockOrcheExternalAPI)(nil).GetDevices))
+}
+
+// GetExecutions mocks base method.
+func (m *MockOrcheExternalAPI) GetExecutions(arg0 orchestrationapi.ExecutionFilter) orchestrationapi.ResponseExecutions {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExecutions", arg0)
+	ret0, _ := ret[0].(orchestrationapi.ResponseExecutions)
+	return ret0
+}
+
+// GetExecutions indicates an expected call of GetExecutions.
+func (mr *MockOrcheExternalAPIMockRecorder) GetExecutions(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExecutions", reflect.TypeOf((*MockOrcheExternalAPI)(nil).GetExecutions), arg0)
+}
+
+// GetClientStats mocks base method.
+func (m *MockOrcheExternalAPI) GetClientStats() orchestrationapi.ResponseClientStats {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClientStats")
+	ret0, _ := ret[0].(orchestrationapi.ResponseClientStats)
+	return ret0
+}
+
+// GetClientStats indicates an expected call of GetClientStats.
+func (mr *MockOrcheExternalAPIMockRecorder) GetClientStats() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClientStats", reflect.TypeOf((*MockOrcheExternalAPI)(nil).GetClientStats))
+}
+
+// WatchDevices mocks base method.
+func (m *MockOrcheExternalAPI) WatchDevices() (<-chan map[string]interface{}, func(), string) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchDevices")
+	ret0, _ := ret[0].(<-chan map[string]interface{})
+	ret1, _ := ret[1].(func())
+	ret2, _ := ret[2].(string)
+	return ret0, ret1, ret2
+}
+
+// WatchDevices indicates an expected call of WatchDevices.
+func (mr *MockOrcheExternalAPIMockRecorder) WatchDevices() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchDevices", reflect.TypeOf((*MockOrcheExternalAPI)(nil).WatchDevices))
+}
+
+// WatchService mocks base method.
+func (m *MockOrcheExternalAPI) WatchService(arg0 uint64) (<-chan map[string]interface{}, func(), string) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchService", arg0)
+	ret0, _ := ret[0].(<-chan map[string]interface{})
+	ret1, _ := ret[1].(func())
+	ret2, _ := ret[2].(string)
+	return ret0, ret1, ret2
+}
+
+// WatchService indicates an expected call of WatchService.
+func (mr *MockOrcheExternalAPIMockRecorder) WatchService(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchService", reflect.TypeOf((*MockOrcheExternalAPI)(nil).WatchService), arg0)
+}
+
+// GetServiceLogs mocks base method.
+func (m *MockOrcheExternalAPI) GetServiceLogs(arg0 uint64, arg1 int, arg2 bool) (<-chan servicelog.Entry, func(), string) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetServiceLogs", arg0, arg1, arg2)
+	ret0, _ := ret[0].(<-chan servicelog.Entry)
+	ret1, _ := ret[1].(func())
+	ret2, _ := ret[2].(string)
+	return ret0, ret1, ret2
+}
+
+// GetServiceLogs indicates an expected call of GetServiceLogs.
+func (mr *MockOrcheExternalAPIMockRecorder) GetServiceLogs(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServiceLogs", reflect.TypeOf((*MockOrcheExternalAPI)(nil).GetServiceLogs), arg0, arg1, arg2)
+}
+
 // RequestCloudSyncPublish mocks base method
 func (m *MockOrcheExternalAPI) RequestCloudSyncPublish(arg0 string, arg1 string, arg2 string, arg3 string) string {
 	m.ctrl.T.Helper()
@@ -193,6 +355,22 @@ func (mr *MockOrcheInternalAPIMockRecorder) ExecuteAppOnLocal(arg0 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecuteAppOnLocal", reflect.TypeOf((*MockOrcheInternalAPI)(nil).ExecuteAppOnLocal), arg0)
 }
 
+// GetDeviceInfo mocks base method.
+func (m *MockOrcheInternalAPI) GetDeviceInfo() (string, map[string]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeviceInfo")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(map[string]string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetDeviceInfo indicates an expected call of GetDeviceInfo.
+func (mr *MockOrcheInternalAPIMockRecorder) GetDeviceInfo() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeviceInfo", reflect.TypeOf((*MockOrcheInternalAPI)(nil).GetDeviceInfo))
+}
+
 // GetOrchestrationInfo mocks base method.
 func (m *MockOrcheInternalAPI) GetOrchestrationInfo() (string, string, []string, error) {
 	m.ctrl.T.Helper()
@@ -267,18 +445,76 @@ func (mr *MockOrcheInternalAPIMockRecorder) HandleDeviceInfo(arg0, arg1, arg2 in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleDeviceInfo", reflect.TypeOf((*MockOrcheInternalAPI)(nil).HandleDeviceInfo), arg0, arg1, arg2)
 }
 
+// StopAppOnLocal mocks base method.
+func (m *MockOrcheInternalAPI) StopAppOnLocal(arg0 uint64, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StopAppOnLocal", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StopAppOnLocal indicates an expected call of StopAppOnLocal.
+func (mr *MockOrcheInternalAPIMockRecorder) StopAppOnLocal(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopAppOnLocal", reflect.TypeOf((*MockOrcheInternalAPI)(nil).StopAppOnLocal), arg0, arg1)
+}
+
+// ReadLogsOnLocal mocks base method.
+func (m *MockOrcheInternalAPI) ReadLogsOnLocal(arg0 uint64, arg1 string, arg2 int, arg3 time.Time) ([]servicelog.Entry, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadLogsOnLocal", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]servicelog.Entry)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ReadLogsOnLocal indicates an expected call of ReadLogsOnLocal.
+func (mr *MockOrcheInternalAPIMockRecorder) ReadLogsOnLocal(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadLogsOnLocal", reflect.TypeOf((*MockOrcheInternalAPI)(nil).ReadLogsOnLocal), arg0, arg1, arg2, arg3)
+}
+
 // HandleNotificationOnLocal mocks base method.
-func (m *MockOrcheInternalAPI) HandleNotificationOnLocal(arg0 float64, arg1 string) error {
+func (m *MockOrcheInternalAPI) HandleNotificationOnLocal(arg0 float64, arg1 string, arg2 int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "HandleNotificationOnLocal", arg0, arg1)
+	ret := m.ctrl.Call(m, "HandleNotificationOnLocal", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // HandleNotificationOnLocal indicates an expected call of HandleNotificationOnLocal.
-func (mr *MockOrcheInternalAPIMockRecorder) HandleNotificationOnLocal(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockOrcheInternalAPIMockRecorder) HandleNotificationOnLocal(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleNotificationOnLocal", reflect.TypeOf((*MockOrcheInternalAPI)(nil).HandleNotificationOnLocal), arg0, arg1, arg2)
+}
+
+// HandleProgressOnLocal mocks base method.
+func (m *MockOrcheInternalAPI) HandleProgressOnLocal(arg0 float64, arg1 map[string]interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleProgressOnLocal", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HandleProgressOnLocal indicates an expected call of HandleProgressOnLocal.
+func (mr *MockOrcheInternalAPIMockRecorder) HandleProgressOnLocal(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleProgressOnLocal", reflect.TypeOf((*MockOrcheInternalAPI)(nil).HandleProgressOnLocal), arg0, arg1)
+}
+
+// HandleSignaledOnLocal mocks base method.
+func (m *MockOrcheInternalAPI) HandleSignaledOnLocal(arg0 float64, arg1 string, arg2 int, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleSignaledOnLocal", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HandleSignaledOnLocal indicates an expected call of HandleSignaledOnLocal.
+func (mr *MockOrcheInternalAPIMockRecorder) HandleSignaledOnLocal(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleNotificationOnLocal", reflect.TypeOf((*MockOrcheInternalAPI)(nil).HandleNotificationOnLocal), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleSignaledOnLocal", reflect.TypeOf((*MockOrcheInternalAPI)(nil).HandleSignaledOnLocal), arg0, arg1, arg2, arg3)
 }
 
 // Notify mocks base method.
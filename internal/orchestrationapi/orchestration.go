@@ -37,6 +37,7 @@ import (
 	"github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr"
 	"github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr/executor"
 	"github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr/notification"
+	"github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr/servicelog"
 	"github.com/lf-edge/edge-home-orchestration-go/internal/controller/storagemgr"
 	"github.com/lf-edge/edge-home-orchestration-go/internal/restinterface/client"
 )
@@ -51,6 +52,17 @@ type Orche interface {
 // OrcheExternalAPI is the interface implemented by external REST API
 type OrcheExternalAPI interface {
 	RequestService(serviceInfo ReqeustService) ResponseService
+	StopService(serviceID uint64) string
+	GetService(serviceID uint64) ResponseServiceInfo
+	ListServices() ResponseServiceInfo
+	WatchService(serviceID uint64) (<-chan map[string]interface{}, func(), string)
+	GetServiceLogs(serviceID uint64, tail int, follow bool) (<-chan servicelog.Entry, func(), string)
+	GetDeviceLabels(selector map[string]string) ResponseDeviceLabels
+	GetDevices() ResponseDevices
+	GetDevice(deviceID string) ResponseDevices
+	WatchDevices() (<-chan map[string]interface{}, func(), string)
+	GetExecutions(filter ExecutionFilter) ResponseExecutions
+	GetClientStats() ResponseClientStats
 	verifier.Conf
 	RequestCloudSyncPublish(host string, clientID string, message string, topic string) string
 	RequestCloudSyncSubscribe(host string, appID string, topic string) string
@@ -61,9 +73,14 @@ type OrcheExternalAPI interface {
 type OrcheInternalAPI interface {
 	configuremgr.Notifier
 	ExecuteAppOnLocal(appInfo map[string]interface{})
-	HandleNotificationOnLocal(serviceID float64, status string) error
+	StopAppOnLocal(serviceID uint64, requester string) error
+	ReadLogsOnLocal(serviceID uint64, requester string, tail int, since time.Time) ([]servicelog.Entry, bool, error)
+	HandleNotificationOnLocal(serviceID float64, status string, exitCode int) error
+	HandleProgressOnLocal(serviceID float64, progress map[string]interface{}) error
+	HandleSignaledOnLocal(serviceID float64, status string, exitCode int, signal string) error
 	GetScore(target string) (scoreValue float64, err error)
 	GetOrchestrationInfo() (platform string, executionType string, serviceList []string, err error)
+	GetDeviceInfo() (deviceID string, labels map[string]string, err error)
 	HandleDeviceInfo(deviceID string, virtualAddr string, privateAddr string)
 	GetScoreWithResource(target map[string]interface{}) (scoreValue float64, err error)
 	GetResource(target string) (resourceMsg map[string]interface{}, err error)
@@ -219,6 +236,7 @@ func (o *orcheImpl) Start(deviceIDPath string, platform string, executionType st
 	cloudSyncState := os.Getenv("CLOUD_SYNC")
 	o.cloudsyncIns.InitiateCloudSync(cloudSyncState)
 	o.watcher.Watch(o)
+	go clients.logStats(clientStatsInterval)
 	o.Ready = true
 	time.Sleep(1000)
 }
@@ -243,9 +261,29 @@ func (o orcheImpl) ExecuteAppOnLocal(appInfo map[string]interface{}) {
 	o.serviceIns.ExecuteAppOnLocal(appInfo)
 }
 
+// StopAppOnLocal stops a service application executed on local device by the requester
+func (o orcheImpl) StopAppOnLocal(serviceID uint64, requester string) error {
+	return o.serviceIns.StopAppOnLocal(serviceID, requester)
+}
+
+// ReadLogsOnLocal reads the log of a service application executed on local device by the requester
+func (o orcheImpl) ReadLogsOnLocal(serviceID uint64, requester string, tail int, since time.Time) ([]servicelog.Entry, bool, error) {
+	return o.serviceIns.ReadLogsOnLocal(serviceID, requester, tail, since)
+}
+
 // HandleNotificationOnLocal handles notifications from local device after executing service application
-func (o orcheImpl) HandleNotificationOnLocal(serviceID float64, status string) error {
-	return o.notificationIns.HandleNotificationOnLocal(serviceID, status)
+func (o orcheImpl) HandleNotificationOnLocal(serviceID float64, status string, exitCode int) error {
+	return o.notificationIns.HandleNotificationOnLocal(serviceID, status, exitCode)
+}
+
+// HandleProgressOnLocal handles the image pull progress from remote device executing service application
+func (o orcheImpl) HandleProgressOnLocal(serviceID float64, progress map[string]interface{}) error {
+	return o.notificationIns.HandleProgressOnLocal(serviceID, progress)
+}
+
+// HandleSignaledOnLocal handles notifications of the service application terminated by a signal on remote device
+func (o orcheImpl) HandleSignaledOnLocal(serviceID float64, status string, exitCode int, signal string) error {
+	return o.notificationIns.HandleSignaledOnLocal(serviceID, status, exitCode, signal)
 }
 
 // GetScore gets a resource score of local device for specific app
@@ -273,6 +311,11 @@ func (o orcheImpl) GetOrchestrationInfo() (platform string, executionType string
 	return o.discoverIns.GetOrchestrationInfo()
 }
 
+// GetDeviceInfo gets the ID and the labels of the device
+func (o orcheImpl) GetDeviceInfo() (deviceID string, labels map[string]string, err error) {
+	return o.discoverIns.GetDeviceInfo()
+}
+
 // HandleDeviceInfo gets the peer's public and private Ip from relay server
 func (o orcheImpl) HandleDeviceInfo(deviceID string, virtualAddr string, privateAddr string) {
 	o.discoverIns.AddDeviceInfo(deviceID, virtualAddr, privateAddr)
@@ -34,6 +34,8 @@ import (
 	executormocks "github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr/executor/mocks"
 	servicemocks "github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr/mocks"
 	storagemocks "github.com/lf-edge/edge-home-orchestration-go/internal/controller/storagemgr/mocks"
+	dbappMocks "github.com/lf-edge/edge-home-orchestration-go/internal/db/bolt/application/mocks"
+	dbexecutionMocks "github.com/lf-edge/edge-home-orchestration-go/internal/db/bolt/execution/mocks"
 	dbsystemMocks "github.com/lf-edge/edge-home-orchestration-go/internal/db/bolt/system/mocks"
 	dbhelpermocks "github.com/lf-edge/edge-home-orchestration-go/internal/db/helper/mocks"
 	clientmocks "github.com/lf-edge/edge-home-orchestration-go/internal/restinterface/client/mocks"
@@ -60,6 +62,8 @@ var (
 	mockVerifier     *verifiermocks.MockVerifierConf
 
 	mockSystemDBExecutor *dbsystemMocks.MockDBInterface
+	mockAppDBExecutor    *dbappMocks.MockDBInterface
+	mockExecDBExecutor   *dbexecutionMocks.MockDBInterface
 )
 
 func createMockIns(ctrl *gomock.Controller) {
@@ -75,6 +79,10 @@ func createMockIns(ctrl *gomock.Controller) {
 	mockNetwork = networkmocks.NewMockNetwork(ctrl)
 	mockResourceutil = resourceutilmocks.NewMockMonitor(ctrl)
 	mockSystemDBExecutor = dbsystemMocks.NewMockDBInterface(ctrl)
+	mockAppDBExecutor = dbappMocks.NewMockDBInterface(ctrl)
+	mockExecDBExecutor = dbexecutionMocks.NewMockDBInterface(ctrl)
+	mockExecDBExecutor.EXPECT().Set(gomock.Any()).Return(nil).AnyTimes()
+	mockExecDBExecutor.EXPECT().Update(gomock.Any()).Return(nil).AnyTimes()
 	mockVerifier = verifiermocks.NewMockVerifierConf(ctrl)
 }
 
@@ -93,6 +101,8 @@ func getOcheIns(ctrl *gomock.Controller) Orche {
 
 	helper = mockDBHelper
 	sysDBExecutor = mockSystemDBExecutor
+	appDBExecutor = mockAppDBExecutor
+	execDBExecutor = mockExecDBExecutor
 
 	orche := builder.Build()
 	resourceMonitorImpl = mockResourceutil
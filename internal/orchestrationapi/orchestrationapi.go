@@ -19,12 +19,15 @@ package orchestrationapi
 
 import (
 	"errors"
+	"fmt"
 	"sort"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/lf-edge/edge-home-orchestration-go/internal/common/commandvalidator"
+	errormsg "github.com/lf-edge/edge-home-orchestration-go/internal/common/errors"
 	"github.com/lf-edge/edge-home-orchestration-go/internal/common/logmgr"
 	"github.com/lf-edge/edge-home-orchestration-go/internal/common/networkhelper"
 	"github.com/lf-edge/edge-home-orchestration-go/internal/common/requestervalidator"
@@ -34,9 +37,13 @@ import (
 	"github.com/lf-edge/edge-home-orchestration-go/internal/controller/scoringmgr"
 	"github.com/lf-edge/edge-home-orchestration-go/internal/controller/securemgr/verifier"
 	"github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr"
+	"github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr/executor"
 	"github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr/notification"
+	"github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr/servicelog"
 	"github.com/lf-edge/edge-home-orchestration-go/internal/controller/storagemgr"
+	appDB "github.com/lf-edge/edge-home-orchestration-go/internal/db/bolt/application"
 	"github.com/lf-edge/edge-home-orchestration-go/internal/db/bolt/common"
+	execDB "github.com/lf-edge/edge-home-orchestration-go/internal/db/bolt/execution"
 	sysDB "github.com/lf-edge/edge-home-orchestration-go/internal/db/bolt/system"
 	dbhelper "github.com/lf-edge/edge-home-orchestration-go/internal/db/helper"
 	"github.com/lf-edge/edge-home-orchestration-go/internal/restinterface/client"
@@ -65,13 +72,6 @@ type deviceInfo struct {
 	execType string
 }
 
-type orcheClient struct {
-	appName   string
-	args      []string
-	notiChan  chan string
-	endSignal chan bool
-}
-
 // RequestServiceInfo struct
 type RequestServiceInfo struct {
 	ExecutionType string
@@ -79,12 +79,48 @@ type RequestServiceInfo struct {
 	ExeOption     map[string]interface{}
 }
 
+// Constraints struct holds the hard requirements of the device executing the service
+type Constraints struct {
+	// MinCPUCount is the minimum number of cpu cores
+	MinCPUCount int
+	// MinFreeMemory is the minimum available memory in MiB
+	MinFreeMemory float64
+	// MaxRTT is the maximum round trip time in seconds
+	MaxRTT float64
+	// Platform is the required platform (ex. linux, docker or android)
+	Platform string
+	// Arch is the required cpu architecture with the GOARCH name (ex. amd64 or arm64)
+	Arch string
+	// Labels are the labels which the device should have with the same values
+	Labels map[string]string
+	// ExcludedDevices are the IDs of the devices never selected
+	ExcludedDevices []string
+}
+
+// RetryPolicy struct describes how to fail over to the next best device when the execution fails
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of the devices tried, 0 or 1 tries only the best device
+	MaxAttempts int
+	// Backoff is the waiting time before the second attempt, it is doubled for every next attempt
+	Backoff time.Duration
+	// FailureTimeout is the time to wait for the Failed status of the service after it is executed,
+	// 0 means the timeout set by SetFailureTimeout when MaxAttempts allows another device and a negative value never waits
+	FailureTimeout time.Duration
+}
+
 // ReqeustService struct
 type ReqeustService struct {
 	SelfSelection    bool
 	ServiceName      string
 	ServiceRequester string
 	ServiceInfo      []RequestServiceInfo
+	Constraints      Constraints
+	Retry            RetryPolicy
+	// Replicas is the number of the best devices executing the service, 0 means 1 and
+	// BroadcastReplicas means every device satisfying the request
+	Replicas int
+	// Limits are the resources which the service is allowed to use on the device executing it
+	Limits executor.ResourceLimits
 	// TODO add status callback
 }
 
@@ -94,11 +130,66 @@ type TargetInfo struct {
 	Target        string
 }
 
-// ResponseService struct
+// ExecutionAttempt struct
+type ExecutionAttempt struct {
+	Target        string
+	ExecutionType string
+	ServiceID     uint64
+	Message       string
+}
+
+// ReplicaInfo struct
+type ReplicaInfo struct {
+	ServiceID        uint64
+	RemoteTargetInfo TargetInfo
+}
+
+// ResponseService struct, ServiceID and RemoteTargetInfo are the ones of the first replica
 type ResponseService struct {
 	Message          string
 	ServiceName      string
+	ServiceID        uint64
 	RemoteTargetInfo TargetInfo
+	Attempts         []ExecutionAttempt
+	Replicas         []ReplicaInfo
+}
+
+// ResponseServiceInfo struct
+type ResponseServiceInfo struct {
+	Message  string
+	Services []map[string]interface{}
+}
+
+// ResponseDeviceLabels struct
+type ResponseDeviceLabels struct {
+	Message string
+	Devices map[string]map[string]string
+}
+
+// ResponseDevices struct
+type ResponseDevices struct {
+	Message string
+	Devices []dbhelper.DeviceInfo
+}
+
+// ExecutionFilter selects the executions in the history, the empty fields match every execution
+type ExecutionFilter struct {
+	ServiceName string
+	Target      string
+	From        time.Time
+	To          time.Time
+}
+
+// ResponseExecutions struct
+type ResponseExecutions struct {
+	Message    string
+	Executions []execDB.Info
+}
+
+// ResponseClientStats struct
+type ResponseClientStats struct {
+	Message string
+	Clients ClientStats
 }
 
 const (
@@ -108,24 +199,44 @@ const (
 	InvalidParameter = "INVALID_PARAMETER"
 	//ServiceNotFound is key for service not found
 	ServiceNotFound = "SERVICE_NOT_FOUND"
+	// DeviceNotFound is key for device not found
+	DeviceNotFound = "DEVICE_NOT_FOUND"
 	//InternalServerError is key for internal server error
 	InternalServerError = "INTERNAL_SERVER_ERROR"
 	// NotAllowedCommand is key for not allowed command
-	NotAllowedCommand  = "NOT_ALLOWED_COMMAND"
-	cloudsyncLogPrefix = "[RequestCloudSync]"
+	NotAllowedCommand = "NOT_ALLOWED_COMMAND"
+	// ServiceFailed is key for the service failed on the device
+	ServiceFailed = "SERVICE_FAILED"
+	// NoCandidateSatisfiesConstraints is key for no device satisfying the constraints
+	NoCandidateSatisfiesConstraints = "NO_CANDIDATE_SATISFIES_CONSTRAINTS"
+	cloudsyncLogPrefix              = "[RequestCloudSync]"
 )
 
-var (
-	orchClientID int32 = -1
-	orcheClients       = [1024]orcheClient{}
+// BroadcastReplicas is the Replicas value executing the service on every device satisfying the request
+const BroadcastReplicas = -1
+
+// DefaultFailureTimeout is the default time to wait for the Failed status of the service before trying the next device
+const DefaultFailureTimeout = 3 * time.Second
 
-	sysDBExecutor sysDB.DBInterface
+var defaultFailureTimeout = int64(DefaultFailureTimeout)
+
+// SetFailureTimeout sets the time to wait for the Failed status of the service when the request does not give it
+func SetFailureTimeout(timeout time.Duration) {
+	atomic.StoreInt64(&defaultFailureTimeout, int64(timeout))
+}
+
+var (
+	sysDBExecutor  sysDB.DBInterface
+	appDBExecutor  appDB.DBInterface
+	execDBExecutor execDB.DBInterface
 
 	helper dbhelper.MultipleBucketQuery
 )
 
 func init() {
 	sysDBExecutor = sysDB.Query{}
+	appDBExecutor = appDB.Query{}
+	execDBExecutor = execDB.Query{}
 
 	helper = dbhelper.GetInstance()
 }
@@ -160,25 +271,26 @@ func (orcheEngine *orcheImpl) RequestService(serviceInfo ReqeustService) Respons
 		}
 	}
 
-	atomic.AddInt32(&orchClientID, 1)
-
-	handle := int(orchClientID)
-
-	serviceClient := addServiceClient(handle, serviceInfo.ServiceName)
-	go serviceClient.listenNotify()
-
 	executionTypes := make([]string, 0)
 	var scoringType string
+	var scoringWeights interface{}
+	constraints := serviceInfo.Constraints
 	installed := true
 	for _, info := range serviceInfo.ServiceInfo {
 		executionTypes = append(executionTypes, info.ExecutionType)
-		scoringType, _ = info.ExeOption["scoringType"].(string)
+		if t, ok := info.ExeOption["scoringType"].(string); ok {
+			scoringType = t
+			scoringWeights = info.ExeOption["scoringWeights"]
+		}
+		if m, ok := info.ExeOption["minFreeMemory"].(float64); ok && m > constraints.MinFreeMemory {
+			constraints.MinFreeMemory = m
+		}
 		if len(info.ExeCmd) > 0 {
 			installed = false
 		}
 	}
 
-	candidates, err := orcheEngine.getCandidate(serviceInfo.ServiceName, executionTypes, installed)
+	candidates, err := orcheEngine.getCandidate(serviceInfo.ServiceName, executionTypes, installed, constraints)
 
 	log.Printf("[RequestService] getCandidate")
 	for index, candidate := range candidates {
@@ -204,13 +316,23 @@ func (orcheEngine *orcheImpl) RequestService(serviceInfo ReqeustService) Respons
 
 	var deviceScores []deviceInfo
 
-	if scoringType == "resource" {
+	policy := getScoringPolicy(serviceInfo.ServiceName, scoringType, scoringWeights)
+	if policy == nil && constraints.needResource() {
+		policy, _ = scoringmgr.GetPolicy(scoringmgr.DefaultPolicy)
+	}
+
+	if policy != nil {
 		deviceResources := orcheEngine.gatherDevicesResource(candidates, serviceInfo.SelfSelection)
 		if len(deviceResources) <= 0 {
 			return errorResp
 		}
+		deviceResources = filterByResource(deviceResources, constraints)
+		if len(deviceResources) <= 0 {
+			errorResp.Message = NoCandidateSatisfiesConstraints
+			return errorResp
+		}
 		for i, dev := range deviceResources {
-			deviceResources[i].score, _ = orcheEngine.GetScoreWithResource(dev.resource)
+			deviceResources[i].score, _ = policy.Score(dev.resource)
 		}
 		deviceScores = sortByScore(deviceResources)
 	} else {
@@ -223,63 +345,486 @@ func (orcheEngine *orcheImpl) RequestService(serviceInfo ReqeustService) Respons
 		return errorResp
 	}
 
-	args, err := getExecCmds(deviceScores[0].execType, serviceInfo.ServiceInfo)
+	localhosts, err := orcheEngine.networkhelper.GetIPs()
 	if err != nil {
-		log.Println(err.Error())
-		errorResp.Message = err.Error()
-		return errorResp
+		log.Println("[orchestrationapi] localhost ip gettering fail. maybe skipped localhost")
 	}
-	args = append(args, deviceScores[0].execType)
 
-	localhosts, err := orcheEngine.networkhelper.GetIPs()
+	replicas := serviceInfo.Replicas
+	if replicas == BroadcastReplicas {
+		replicas = 0
+		for _, device := range deviceScores {
+			if device.score != scoringmgr.InvalidScore {
+				replicas++
+			}
+		}
+	} else if replicas < 1 {
+		replicas = 1
+	}
+	// @Note : MaxAttempts limits the devices tried for a single replica, the replicated request tries
+	// the remaining candidates until the replicas are launched
+	maxAttempts := serviceInfo.Retry.MaxAttempts
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+	backoff := serviceInfo.Retry.Backoff
+	failureTimeout := serviceInfo.Retry.FailureTimeout
+	if failureTimeout == 0 && maxAttempts > 1 {
+		failureTimeout = time.Duration(atomic.LoadInt64(&defaultFailureTimeout))
+	}
+
+	resp := ResponseService{
+		Message:          InternalServerError,
+		ServiceName:      serviceInfo.ServiceName,
+		RemoteTargetInfo: TargetInfo{},
+	}
+	failures, lastFailed := 0, false
+	for _, device := range deviceScores {
+		if len(resp.Replicas) == replicas || device.score == scoringmgr.InvalidScore {
+			break
+		}
+		if replicas == 1 && failures >= maxAttempts {
+			break
+		}
+		if lastFailed && backoff > 0 {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+
+		attempt := ExecutionAttempt{
+			Target:        device.endpoint,
+			ExecutionType: device.execType,
+		}
+		notiChan := make(chan string, 1)
+		execution, err := orcheEngine.executeOnDevice(device, serviceInfo, localhosts, notiChan)
+		attempt.ServiceID = execution.ServiceID
+		if err != nil {
+			log.Println("[orchestrationapi] cannot execute service on", device.endpoint, ":", err.Error())
+			attempt.Message = err.Error()
+			if _, ok := err.(executionError); ok {
+				attempt.Message = InternalServerError
+			}
+			resp.Attempts = append(resp.Attempts, attempt)
+			failures, lastFailed = failures+1, true
+			continue
+		}
+
+		status, failed := waitFailure(notiChan, failureTimeout)
+		if len(status) == 0 {
+			clients.add(execution.ServiceID, serviceInfo.ServiceName, execution.ID, notiChan)
+		} else {
+			finishExecution(execution.ID, status)
+		}
+		if failed {
+			log.Println("[orchestrationapi] service", attempt.ServiceID, "failed on", device.endpoint)
+			attempt.Message = ServiceFailed
+			resp.Attempts = append(resp.Attempts, attempt)
+			failures, lastFailed = failures+1, true
+			continue
+		}
+
+		attempt.Message = ErrorNone
+		resp.Attempts = append(resp.Attempts, attempt)
+		lastFailed = false
+		resp.Replicas = append(resp.Replicas, ReplicaInfo{
+			ServiceID: attempt.ServiceID,
+			RemoteTargetInfo: TargetInfo{
+				ExecutionType: device.execType,
+				Target:        device.endpoint,
+			},
+		})
+	}
+
+	if len(resp.Replicas) > 0 {
+		resp.Message = ErrorNone
+		resp.ServiceID = resp.Replicas[0].ServiceID
+		resp.RemoteTargetInfo = resp.Replicas[0].RemoteTargetInfo
+	} else if len(resp.Attempts) > 0 {
+		last := resp.Attempts[len(resp.Attempts)-1]
+		resp.Message = last.Message
+		resp.ServiceID = last.ServiceID
+	}
+	log.Println("[orchestrationapi] ", deviceScores)
+
+	return resp
+}
+
+// executionError is the error of the service manager executing the service on the device
+type executionError struct {
+	error
+}
+
+// executeOnDevice validates the request for the device, executes the service on it
+// and records the execution in the execution history
+func (orcheEngine *orcheImpl) executeOnDevice(device deviceInfo, serviceInfo ReqeustService, localhosts []string, notiChan chan string) (execDB.Info, error) {
+	execution := execDB.Info{
+		ServiceName:   serviceInfo.ServiceName,
+		Requester:     serviceInfo.ServiceRequester,
+		Target:        device.endpoint,
+		ExecutionType: device.execType,
+		Score:         device.score,
+		Resource:      device.resource,
+	}
+
+	args, err := getExecCmds(device.execType, serviceInfo.ServiceInfo)
 	if err != nil {
-		log.Println("[orchestrationapi] localhost ip gettering fail. maybe skipped localhost")
+		return execution, err
 	}
+	args = append(args, device.execType)
 
-	if common.HasElem(localhosts, deviceScores[0].endpoint) {
+	if common.HasElem(localhosts, device.endpoint) {
 		validator := commandvalidator.CommandValidator{}
 		for _, info := range serviceInfo.ServiceInfo {
-			if info.ExecutionType == "native" || info.ExecutionType == "android" {
+			if info.ExecutionType == "native" || info.ExecutionType == "android" || info.ExecutionType == "wasm" {
 				if err := validator.CheckCommand(serviceInfo.ServiceName, info.ExeCmd); err != nil {
-					log.Println(err.Error())
-					return ResponseService{
-						Message:          err.Error(),
-						ServiceName:      serviceInfo.ServiceName,
-						RemoteTargetInfo: TargetInfo{},
-					}
+					return execution, err
 				}
 			}
 		}
 
 		vRequester := requestervalidator.RequesterValidator{}
 		if err := vRequester.CheckRequester(serviceInfo.ServiceName, serviceInfo.ServiceRequester); err != nil &&
-			(deviceScores[0].execType == "native" || deviceScores[0].execType == "android") {
-			log.Println(err.Error())
-			return ResponseService{
-				Message:          err.Error(),
-				ServiceName:      serviceInfo.ServiceName,
-				RemoteTargetInfo: TargetInfo{},
-			}
+			(device.execType == "native" || device.execType == "android" || device.execType == "wasm") {
+			return execution, err
 		}
 	}
 
-	orcheEngine.executeApp(
-		deviceScores[0].endpoint,
+	execution.Args = args
+	execution.StartTime = time.Now()
+	execution.Status = servicemgr.ConstServiceStatusStarted
+	execution.ServiceID, err = orcheEngine.executeApp(
+		device.endpoint,
 		serviceInfo.ServiceName,
 		serviceInfo.ServiceRequester,
 		args,
-		serviceClient.notiChan,
+		serviceInfo.Limits,
+		notiChan,
 	)
-	log.Println("[orchestrationapi] ", deviceScores)
+	if err != nil {
+		execution.Status = servicemgr.ConstServiceStatusFailed
+		execution.EndTime = time.Now()
+	}
+
+	execution.ID = execDB.NewID(execution.ServiceID, execution.StartTime)
+	if dbErr := execDBExecutor.Set(execution); dbErr != nil {
+		log.Println("[orchestrationapi] cannot record the execution :", dbErr.Error())
+	}
+
+	if err != nil {
+		return execution, executionError{err}
+	}
+	return execution, nil
+}
+
+// finishExecution records the terminal status of the execution
+func finishExecution(id, status string) {
+	err := execDBExecutor.Update(execDB.Info{ID: id, Status: status, EndTime: time.Now()})
+	if err != nil {
+		log.Println("[orchestrationapi] cannot record the status of the execution :", err.Error())
+	}
+}
+
+// waitFailure waits for the terminal status of the service during the timeout,
+// it returns the status received and whether the service is failed
+func waitFailure(notiChan chan string, timeout time.Duration) (string, bool) {
+	if timeout <= 0 {
+		return "", false
+	}
+
+	select {
+	case status := <-notiChan:
+		return status, status == servicemgr.ConstServiceStatusFailed
+	case <-time.After(timeout):
+		return "", false
+	}
+}
+
+// StopService stops the service executed by the request
+func (orcheEngine *orcheImpl) StopService(serviceID uint64) string {
+	log.Printf("[StopService] %d", serviceID)
+
+	if !orcheEngine.Ready {
+		return InternalServerError
+	}
+
+	if err := orcheEngine.serviceIns.Stop(serviceID); err != nil {
+		log.Println("[orchestrationapi] cannot stop service :", err.Error())
+		return getServiceErrorMessage(err)
+	}
+
+	return ErrorNone
+}
+
+// GetService returns the information of the service executed by the request
+func (orcheEngine *orcheImpl) GetService(serviceID uint64) ResponseServiceInfo {
+	if !orcheEngine.Ready {
+		return ResponseServiceInfo{Message: InternalServerError}
+	}
+
+	service, err := orcheEngine.serviceIns.Get(serviceID)
+	if err != nil {
+		return ResponseServiceInfo{Message: getServiceErrorMessage(err)}
+	}
+
+	return ResponseServiceInfo{
+		Message:  ErrorNone,
+		Services: []map[string]interface{}{service},
+	}
+}
+
+// ListServices returns the information of the running services executed by the requests
+func (orcheEngine *orcheImpl) ListServices() ResponseServiceInfo {
+	if !orcheEngine.Ready {
+		return ResponseServiceInfo{Message: InternalServerError}
+	}
+
+	return ResponseServiceInfo{
+		Message:  ErrorNone,
+		Services: orcheEngine.serviceIns.List(),
+	}
+}
+
+// GetDeviceLabels returns the labels of the devices which have every label of the selector
+func (orcheEngine *orcheImpl) GetDeviceLabels(selector map[string]string) ResponseDeviceLabels {
+	if !orcheEngine.Ready {
+		return ResponseDeviceLabels{Message: InternalServerError}
+	}
+
+	devices, err := helper.GetDevicesWithLabels(selector)
+	if err != nil {
+		log.Println("[orchestrationapi]", err.Error())
+		return ResponseDeviceLabels{Message: InternalServerError}
+	}
+
+	return ResponseDeviceLabels{
+		Message: ErrorNone,
+		Devices: devices,
+	}
+}
+
+// GetDevices returns the information of the devices known by the orchestration,
+// including the removed devices which have only their liveness state
+func (orcheEngine *orcheImpl) GetDevices() ResponseDevices {
+	if !orcheEngine.Ready {
+		return ResponseDevices{Message: InternalServerError}
+	}
+
+	devices, err := helper.GetDevices()
+	if err != nil {
+		log.Println("[orchestrationapi]", err.Error())
+		return ResponseDevices{Message: InternalServerError}
+	}
+
+	states, err := orcheEngine.discoverIns.GetDeviceStates()
+	if err != nil {
+		log.Println("[orchestrationapi]", err.Error())
+		return ResponseDevices{Message: InternalServerError}
+	}
+
+	devices = mergeDeviceStates(devices, states)
+	orcheEngine.setLocalResource(devices)
+	return ResponseDevices{
+		Message: ErrorNone,
+		Devices: devices,
+	}
+}
+
+// GetDevice returns the information of the device
+func (orcheEngine *orcheImpl) GetDevice(deviceID string) ResponseDevices {
+	if !orcheEngine.Ready {
+		return ResponseDevices{Message: InternalServerError}
+	}
+
+	device, err := helper.GetDevice(deviceID)
+	if err != nil {
+		if _, ok := err.(errormsg.NotFound); ok {
+			return orcheEngine.getRemovedDevice(deviceID)
+		}
+		log.Println("[orchestrationapi]", err.Error())
+		return ResponseDevices{Message: InternalServerError}
+	}
+
+	devices := []dbhelper.DeviceInfo{device}
+	orcheEngine.setLocalResource(devices)
+	return ResponseDevices{
+		Message: ErrorNone,
+		Devices: devices,
+	}
+}
+
+// getRemovedDevice returns the device which has only its liveness state
+func (orcheEngine *orcheImpl) getRemovedDevice(deviceID string) ResponseDevices {
+	states, err := orcheEngine.discoverIns.GetDeviceStates()
+	if err != nil {
+		log.Println("[orchestrationapi]", err.Error())
+		return ResponseDevices{Message: InternalServerError}
+	}
+
+	for _, device := range mergeDeviceStates(nil, states) {
+		if device.ID == deviceID {
+			return ResponseDevices{
+				Message: ErrorNone,
+				Devices: []dbhelper.DeviceInfo{device},
+			}
+		}
+	}
+	return ResponseDevices{Message: DeviceNotFound}
+}
+
+// setLocalResource sets the current resource values of this device,
+// the last resource values of the other devices are kept by their heartbeats
+func (orcheEngine *orcheImpl) setLocalResource(devices []dbhelper.DeviceInfo) {
+	info, err := sysDBExecutor.Get(sysDB.ID)
+	if err != nil {
+		return
+	}
+	for i := range devices {
+		if devices[i].ID != info.Value {
+			continue
+		}
+		if resource, err := orcheEngine.GetResource(info.Value); err == nil {
+			devices[i].Resource, devices[i].ResourceTime = resource, time.Now()
+		}
+	}
+}
+
+// mergeDeviceStates appends the devices which are not in the devices but have a liveness state, ordered by device ID
+func mergeDeviceStates(devices []dbhelper.DeviceInfo, states []map[string]interface{}) []dbhelper.DeviceInfo {
+	known := make(map[string]bool, len(devices))
+	for _, device := range devices {
+		known[device.ID] = true
+	}
+
+	merged := append(make([]dbhelper.DeviceInfo, 0, len(devices)+len(states)), devices...)
+	for _, state := range states {
+		id, _ := state["DeviceID"].(string)
+		if len(id) == 0 || known[id] {
+			continue
+		}
+		device := dbhelper.DeviceInfo{ID: id}
+		device.State, _ = state["State"].(string)
+		device.LastSeen, _ = state["LastSeen"].(time.Time)
+		merged = append(merged, device)
+	}
+	sort.Slice(merged, func(i, j int) bool {
+		return merged[i].ID < merged[j].ID
+	})
+	return merged
+}
+
+// GetExecutions returns the history of the service executions requested by this device
+func (orcheEngine *orcheImpl) GetExecutions(filter ExecutionFilter) ResponseExecutions {
+	if !orcheEngine.Ready {
+		return ResponseExecutions{Message: InternalServerError}
+	}
+	if !filter.To.IsZero() && filter.To.Before(filter.From) {
+		return ResponseExecutions{Message: InvalidParameter}
+	}
+
+	var executions []execDB.Info
+	var err error
+	switch {
+	case len(filter.ServiceName) > 0:
+		executions, err = execDBExecutor.GetByService(filter.ServiceName)
+	case len(filter.Target) > 0:
+		executions, err = execDBExecutor.GetByDevice(filter.Target)
+	case !filter.From.IsZero() || !filter.To.IsZero():
+		executions, err = execDBExecutor.GetByTimeRange(filter.From, filter.To)
+	default:
+		executions, err = execDBExecutor.GetList()
+	}
+	if err != nil {
+		log.Println("[orchestrationapi]", err.Error())
+		return ResponseExecutions{Message: InternalServerError}
+	}
+
+	matched := make([]execDB.Info, 0, len(executions))
+	for _, execution := range executions {
+		if filter.match(execution) {
+			matched = append(matched, execution)
+		}
+	}
+
+	return ResponseExecutions{
+		Message:    ErrorNone,
+		Executions: matched,
+	}
+}
+
+// GetClientStats returns the counters of the requesters waiting for the service status
+func (orcheEngine *orcheImpl) GetClientStats() ResponseClientStats {
+	if !orcheEngine.Ready {
+		return ResponseClientStats{Message: InternalServerError}
+	}
+
+	return ResponseClientStats{
+		Message: ErrorNone,
+		Clients: clients.getStats(),
+	}
+}
+
+func (filter ExecutionFilter) match(execution execDB.Info) bool {
+	if len(filter.ServiceName) > 0 && execution.ServiceName != filter.ServiceName {
+		return false
+	} else if len(filter.Target) > 0 && execution.Target != filter.Target {
+		return false
+	} else if execution.StartTime.Before(filter.From) {
+		return false
+	}
+	return filter.To.IsZero() || !execution.StartTime.After(filter.To)
+}
+
+// WatchDevices returns a channel delivering the liveness state of the device whenever its state changes
+func (orcheEngine *orcheImpl) WatchDevices() (<-chan map[string]interface{}, func(), string) {
+	if !orcheEngine.Ready {
+		return nil, nil, InternalServerError
+	}
+
+	watchChan, cancel := orcheEngine.discoverIns.WatchDeviceStates()
+	return watchChan, cancel, ErrorNone
+}
+
+// WatchService returns a channel delivering the information of the service whenever its status changes
+func (orcheEngine *orcheImpl) WatchService(serviceID uint64) (<-chan map[string]interface{}, func(), string) {
+	if !orcheEngine.Ready {
+		return nil, nil, InternalServerError
+	}
+
+	watchChan, cancel, err := orcheEngine.serviceIns.Watch(serviceID)
+	if err != nil {
+		return nil, nil, getServiceErrorMessage(err)
+	}
+
+	return watchChan, cancel, ErrorNone
+}
+
+// GetServiceLogs returns a channel delivering the output of the service, it keeps delivering the next lines when follow is true
+func (orcheEngine *orcheImpl) GetServiceLogs(serviceID uint64, tail int, follow bool) (<-chan servicelog.Entry, func(), string) {
+	if !orcheEngine.Ready {
+		return nil, nil, InternalServerError
+	}
+
+	logChan, cancel, err := orcheEngine.serviceIns.ReadLogs(serviceID, tail, follow)
+	if err != nil {
+		log.Println(logtag, "cannot read the log of service", serviceID, ":", err.Error())
+		return nil, nil, getServiceErrorMessage(err)
+	}
+
+	return logChan, cancel, ErrorNone
+}
 
-	return ResponseService{
-		Message:     ErrorNone,
-		ServiceName: serviceInfo.ServiceName,
-		RemoteTargetInfo: TargetInfo{
-			ExecutionType: deviceScores[0].execType,
-			Target:        deviceScores[0].endpoint,
-		},
+func getServiceErrorMessage(err error) string {
+	switch err.(type) {
+	case errormsg.NotFound:
+		return ServiceNotFound
+	case errormsg.NotSupport:
+		return NotAllowedCommand
 	}
+
+	if err == servicemgr.ErrInvalidService {
+		return ServiceNotFound
+	}
+	return InternalServerError
 }
 
 func getExecCmds(execType string, requestServiceInfos []RequestServiceInfo) ([]string, error) {
@@ -292,8 +837,124 @@ func getExecCmds(execType string, requestServiceInfos []RequestServiceInfo) ([]s
 	return nil, errors.New("not found")
 }
 
-func (orcheEngine orcheImpl) getCandidate(appName string, execType []string, installed bool) (deviceList []dbhelper.ExecutionCandidate, err error) {
-	return helper.GetDeviceInfoWithService(appName, execType, installed)
+// getScoringPolicy selects the scoring policy by the scoringType of the request or the .conf file of the service.
+// It returns nil to let each device calculate its score by itself.
+func getScoringPolicy(serviceName string, scoringType string, scoringWeights interface{}) scoringmgr.ScoringPolicy {
+	var weights map[string]float64
+	if len(scoringType) == 0 {
+		info, err := appDBExecutor.Get(serviceName)
+		if err != nil || len(info.ScoringType) == 0 {
+			return nil
+		}
+		scoringType = info.ScoringType
+		weights = info.ScoringWeights
+	} else if items, ok := scoringWeights.(map[string]interface{}); ok {
+		weights = make(map[string]float64)
+		for factor, weight := range items {
+			if w, ok := weight.(float64); ok {
+				weights[factor] = w
+			}
+		}
+	}
+
+	if scoringType == scoringmgr.WeightedLinearPolicy && len(weights) > 0 {
+		policy, err := scoringmgr.NewWeightedLinear(weights)
+		if err == nil {
+			return policy
+		}
+		log.Println("[orchestrationapi] ignore scoring weights :", err.Error())
+	}
+
+	policy, err := scoringmgr.GetPolicy(scoringType)
+	if err != nil {
+		log.Println("[orchestrationapi]", err.Error())
+		return nil
+	}
+	return policy
+}
+
+// needResource checks whether the constraints are checked with the resource values of the devices
+func (c Constraints) needResource() bool {
+	return c.MinCPUCount > 0 || c.MinFreeMemory > 0 || c.MaxRTT > 0 || len(c.Arch) > 0
+}
+
+// satisfiedByCandidate checks the constraints known before gathering the resource values
+func (c Constraints) satisfiedByCandidate(candidate dbhelper.ExecutionCandidate) error {
+	if common.HasElem(c.ExcludedDevices, candidate.ID) {
+		return errors.New("excluded device")
+	}
+	if len(c.Platform) > 0 && !strings.EqualFold(c.Platform, candidate.Platform) {
+		return fmt.Errorf("platform %s is not %s", candidate.Platform, c.Platform)
+	}
+	if !dbhelper.MatchLabels(candidate.Labels, c.Labels) {
+		return errors.New("labels are not matched")
+	}
+	return nil
+}
+
+// satisfiedByResource checks the constraints with the resource values given by GetResource.
+// The device not reporting the resource value does not satisfy its constraint.
+func (c Constraints) satisfiedByResource(resource map[string]interface{}) error {
+	if _, found := resource["error"]; found {
+		return errors.New("resource is not found")
+	}
+	if c.MinCPUCount > 0 {
+		if count, ok := resource["cpuCount"].(float64); !ok || count < float64(c.MinCPUCount) {
+			return fmt.Errorf("cpu count is less than %d", c.MinCPUCount)
+		}
+	}
+	if c.MinFreeMemory > 0 {
+		if available, ok := resource["memAvailable"].(float64); !ok || available < c.MinFreeMemory*1024 {
+			return fmt.Errorf("available memory is less than %v MiB", c.MinFreeMemory)
+		}
+	}
+	if c.MaxRTT > 0 {
+		if rtt, ok := resource["rtt"].(float64); !ok || rtt > c.MaxRTT {
+			return fmt.Errorf("round trip time is more than %v seconds", c.MaxRTT)
+		}
+	}
+	if len(c.Arch) > 0 {
+		if arch, ok := resource["arch"].(string); !ok || arch != c.Arch {
+			return fmt.Errorf("architecture is not %s", c.Arch)
+		}
+	}
+	return nil
+}
+
+// filterByResource drops the devices whose resource values do not satisfy the constraints
+func filterByResource(deviceResources []deviceInfo, constraints Constraints) []deviceInfo {
+	if !constraints.needResource() {
+		return deviceResources
+	}
+
+	filtered := make([]deviceInfo, 0, len(deviceResources))
+	for _, dev := range deviceResources {
+		if err := constraints.satisfiedByResource(dev.resource); err != nil {
+			log.Println("[orchestrationapi] drop", dev.id, ":", err.Error())
+			continue
+		}
+		filtered = append(filtered, dev)
+	}
+	return filtered
+}
+
+func (orcheEngine orcheImpl) getCandidate(appName string, execType []string, installed bool, constraints Constraints) (deviceList []dbhelper.ExecutionCandidate, err error) {
+	candidates, err := helper.GetDeviceInfoWithService(appName, execType, installed)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, candidate := range candidates {
+		if err := constraints.satisfiedByCandidate(candidate); err != nil {
+			log.Println("[orchestrationapi] drop", candidate.ID, ":", err.Error())
+			continue
+		}
+		deviceList = append(deviceList, candidate)
+	}
+	if len(deviceList) == 0 {
+		return nil, errors.New(NoCandidateSatisfiesConstraints)
+	}
+	return deviceList, nil
 }
 
 func (orcheEngine orcheImpl) gatherDevicesScore(candidates []dbhelper.ExecutionCandidate, selfSelection bool) (deviceScores []deviceInfo) {
@@ -349,10 +1010,12 @@ func (orcheEngine orcheImpl) gatherDevicesScore(candidates []dbhelper.ExecutionC
 				return
 			}
 
-			if isLocalhost(cand.Endpoint, localhosts) {
-				if !selfSelection {
-					return
-				}
+			isLocal := isLocalhost(cand.Endpoint, localhosts)
+			if isLocal && !selfSelection {
+				return
+			}
+
+			if isLocal {
 				score, err = orcheEngine.GetScore(info.Value)
 			} else {
 				score, err = orcheEngine.clientAPI.DoScoreRemoteDevice(info.Value, cand.Endpoint[0])
@@ -450,6 +1113,7 @@ func (orcheEngine orcheImpl) gatherDevicesResource(candidates []dbhelper.Executi
 			log.Printf("candidate ExecType : %v", cand.ExecType)
 			log.Printf("candidate Endpoint : %v", cand.Endpoint[0])
 			log.Printf("candidate resource : %v", resource)
+
 			resources <- deviceInfo{endpoint: cand.Endpoint[0], resource: resource, id: cand.ID, execType: cand.ExecType}
 		}(candidate)
 	}
@@ -459,20 +1123,13 @@ func (orcheEngine orcheImpl) gatherDevicesResource(candidates []dbhelper.Executi
 	return
 }
 
-func (orcheEngine orcheImpl) executeApp(endpoint, serviceName, requester string, args []string, notiChan chan string) {
+func (orcheEngine orcheImpl) executeApp(endpoint, serviceName, requester string, args []string, limits executor.ResourceLimits, notiChan chan string) (uint64, error) {
 	ifArgs := make([]interface{}, len(args))
 	for i, v := range args {
 		ifArgs[i] = v
 	}
 
-	orcheEngine.serviceIns.Execute(endpoint, serviceName, requester, ifArgs, notiChan)
-}
-
-func (client *orcheClient) listenNotify() {
-	select {
-	case str := <-client.notiChan:
-		log.Printf("[orchestrationapi] service status changed [appNames:%s][status:%s]\n", client.appName, str)
-	}
+	return orcheEngine.serviceIns.Execute(endpoint, serviceName, requester, ifArgs, limits, notiChan)
 }
 
 func isLocalhost(endpoints1, endpoints2 []string) bool {
@@ -486,15 +1143,6 @@ func isLocalhost(endpoints1, endpoints2 []string) bool {
 	return false
 }
 
-func addServiceClient(clientID int, appName string) (client *orcheClient) {
-	// orcheClients[clientID].args = args
-	orcheClients[clientID].appName = appName
-	orcheClients[clientID].notiChan = make(chan string)
-
-	client = &orcheClients[clientID]
-	return
-}
-
 func sortByScore(deviceScores []deviceInfo) []deviceInfo {
 	sort.Slice(deviceScores, func(i, j int) bool {
 		return deviceScores[i].score > deviceScores[j].score
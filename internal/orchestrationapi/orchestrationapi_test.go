@@ -28,8 +28,15 @@ import (
 	"github.com/golang/mock/gomock"
 
 	"testing"
+	"time"
 
+	errormsg "github.com/lf-edge/edge-home-orchestration-go/internal/common/errors"
 	"github.com/lf-edge/edge-home-orchestration-go/internal/common/requestervalidator"
+	"github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr"
+	"github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr/executor"
+	appDB "github.com/lf-edge/edge-home-orchestration-go/internal/db/bolt/application"
+	execDB "github.com/lf-edge/edge-home-orchestration-go/internal/db/bolt/execution"
+	dbexecutionMocks "github.com/lf-edge/edge-home-orchestration-go/internal/db/bolt/execution/mocks"
 	sysDB "github.com/lf-edge/edge-home-orchestration-go/internal/db/bolt/system"
 	dbhelper "github.com/lf-edge/edge-home-orchestration-go/internal/db/helper"
 )
@@ -84,13 +91,14 @@ func TestRequestService(t *testing.T) {
 			mockService.EXPECT().SetLocalServiceExecutor(mockExecutor),
 			mockDiscovery.EXPECT().SetRestResource(),
 			mockDBHelper.EXPECT().GetDeviceInfoWithService(gomock.Eq(appName), gomock.Any(), gomock.Any()).Return(candidateInfos, nil),
+			mockAppDBExecutor.EXPECT().Get(appName).Return(appDB.Info{}, errors.New("not found")),
 			mockSystemDBExecutor.EXPECT().Get("id").Return(sysInfo, nil),
 			mockNetwork.EXPECT().GetIPs().Return([]string{""}, nil),
 			mockClient.EXPECT().DoScoreRemoteDevice(gomock.Any(), gomock.Any()).Return(scores[0], nil),
 			mockClient.EXPECT().DoScoreRemoteDevice(gomock.Any(), gomock.Any()).Return(scores[1], nil),
 			mockClient.EXPECT().DoScoreRemoteDevice(gomock.Any(), gomock.Any()).Return(scores[2], nil),
 			mockNetwork.EXPECT().GetIPs().Return([]string{""}, nil),
-			mockService.EXPECT().Execute(gomock.Any(), appName, gomock.Any(), gomock.Any(), gomock.Any()),
+			mockService.EXPECT().Execute(gomock.Any(), appName, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(uint64(1), nil),
 		)
 
 		o := getOcheIns(ctrl)
@@ -105,9 +113,251 @@ func TestRequestService(t *testing.T) {
 		res := oche.RequestService(requestServiceInfo)
 		if res.Message != ErrorNone {
 			t.Error("unexpected handle")
+		} else if res.ServiceID != uint64(1) {
+			t.Error("unexpected service id")
 		}
 	})
 
+	t.Run("ScoringPolicy", func(t *testing.T) {
+		resources := map[string]map[string]interface{}{
+			"endpoint1": {"cpuUsage": 90.0, "cpuCount": 4.0, "cpuFreq": 1000.0, "memAvailable": 4194304.0, "netBandwidth": 100.0, "rtt": 0.1, "arch": "amd64"},
+			"endpoint2": {"cpuUsage": 10.0, "cpuCount": 4.0, "cpuFreq": 1000.0, "memAvailable": 262144.0, "netBandwidth": 100.0, "rtt": 0.1, "arch": "arm64"},
+			"endpoint3": {"error": 0.0},
+		}
+		requestWithPolicy := func(info appDB.Info, exeOption map[string]interface{}, constraints Constraints) ResponseService {
+			gomock.InOrder(
+				mockService.EXPECT().SetLocalServiceExecutor(mockExecutor),
+				mockDiscovery.EXPECT().SetRestResource(),
+				mockDBHelper.EXPECT().GetDeviceInfoWithService(gomock.Eq(appName), gomock.Any(), gomock.Any()).Return(candidateInfos, nil),
+			)
+			if _, ok := exeOption["scoringType"]; !ok {
+				mockAppDBExecutor.EXPECT().Get(appName).Return(info, nil)
+			}
+			gomock.InOrder(
+				mockSystemDBExecutor.EXPECT().Get("id").Return(sysInfo, nil),
+				mockNetwork.EXPECT().GetIPs().Return([]string{""}, nil),
+			)
+			mockClient.EXPECT().DoGetResourceRemoteDevice(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(devID string, endpoint string) (map[string]interface{}, error) {
+					return resources[endpoint], nil
+				},
+			).Times(3)
+			mockNetwork.EXPECT().GetIPs().Return([]string{""}, nil)
+			mockService.EXPECT().Execute(gomock.Any(), appName, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(uint64(1), nil)
+
+			getOcheIns(ctrl)
+			oche := getOrcheImple()
+			oche.Ready = true
+
+			request := requestServiceInfo
+			request.ServiceInfo = []RequestServiceInfo{{ExecutionType: "platform", ExeCmd: args, ExeOption: exeOption}}
+			request.Constraints = constraints
+			return oche.RequestService(request)
+		}
+
+		t.Run("Request", func(t *testing.T) {
+			res := requestWithPolicy(appDB.Info{}, map[string]interface{}{"scoringType": "cpu"}, Constraints{})
+			if res.Message != ErrorNone || res.RemoteTargetInfo.Target != "endpoint2" {
+				t.Error("unexpected target", res.RemoteTargetInfo.Target)
+			}
+		})
+		t.Run("RequestWeights", func(t *testing.T) {
+			res := requestWithPolicy(appDB.Info{}, map[string]interface{}{
+				"scoringType":    "weighted",
+				"scoringWeights": map[string]interface{}{"memory": 1.0},
+			}, Constraints{})
+			if res.Message != ErrorNone || res.RemoteTargetInfo.Target != "endpoint1" {
+				t.Error("unexpected target", res.RemoteTargetInfo.Target)
+			}
+		})
+		t.Run("ServiceConf", func(t *testing.T) {
+			res := requestWithPolicy(appDB.Info{ScoringType: "memory"}, nil, Constraints{})
+			if res.Message != ErrorNone || res.RemoteTargetInfo.Target != "endpoint1" {
+				t.Error("unexpected target", res.RemoteTargetInfo.Target)
+			}
+		})
+		t.Run("Constraints", func(t *testing.T) {
+			res := requestWithPolicy(appDB.Info{}, nil, Constraints{MinCPUCount: 2, Arch: "arm64"})
+			if res.Message != ErrorNone || res.RemoteTargetInfo.Target != "endpoint2" {
+				t.Error("unexpected target", res.RemoteTargetInfo.Target)
+			}
+		})
+		t.Run("MinFreeMemory", func(t *testing.T) {
+			res := requestWithPolicy(appDB.Info{}, map[string]interface{}{"minFreeMemory": 1024.0}, Constraints{})
+			if res.Message != ErrorNone || res.RemoteTargetInfo.Target != "endpoint1" {
+				t.Error("unexpected target", res.RemoteTargetInfo.Target)
+			}
+		})
+	})
+
+	t.Run("MultipleDevices", func(t *testing.T) {
+		resources := map[string]map[string]interface{}{
+			"endpoint1": {"cpuUsage": 90.0, "cpuCount": 4.0, "cpuFreq": 1000.0, "memAvailable": 4194304.0, "netBandwidth": 100.0, "rtt": 0.1},
+			"endpoint2": {"cpuUsage": 10.0, "cpuCount": 4.0, "cpuFreq": 1000.0, "memAvailable": 262144.0, "netBandwidth": 100.0, "rtt": 0.1},
+			"endpoint3": {"error": 0.0},
+		}
+		SetFailureTimeout(10 * time.Millisecond)
+		defer SetFailureTimeout(DefaultFailureTimeout)
+
+		requestWith := func(retry RetryPolicy, replicas int, executions ...func(notiChan chan string) (uint64, error)) ResponseService {
+			gomock.InOrder(
+				mockService.EXPECT().SetLocalServiceExecutor(mockExecutor),
+				mockDiscovery.EXPECT().SetRestResource(),
+				mockDBHelper.EXPECT().GetDeviceInfoWithService(gomock.Eq(appName), gomock.Any(), gomock.Any()).Return(candidateInfos, nil),
+				mockSystemDBExecutor.EXPECT().Get("id").Return(sysInfo, nil),
+				mockNetwork.EXPECT().GetIPs().Return([]string{""}, nil),
+			)
+			mockClient.EXPECT().DoGetResourceRemoteDevice(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(devID string, endpoint string) (map[string]interface{}, error) {
+					return resources[endpoint], nil
+				},
+			).Times(3)
+			mockNetwork.EXPECT().GetIPs().Return([]string{""}, nil)
+
+			calls := make([]*gomock.Call, 0, len(executions))
+			for _, execution := range executions {
+				execute := execution
+				calls = append(calls, mockService.EXPECT().Execute(gomock.Any(), appName, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(target, name, requester string, args []interface{}, limits executor.ResourceLimits, notiChan chan string) (uint64, error) {
+						return execute(notiChan)
+					},
+				))
+			}
+			gomock.InOrder(calls...)
+
+			getOcheIns(ctrl)
+			oche := getOrcheImple()
+			oche.Ready = true
+
+			request := requestServiceInfo
+			request.ServiceInfo = []RequestServiceInfo{{ExecutionType: "platform", ExeCmd: args, ExeOption: map[string]interface{}{"scoringType": "cpu"}}}
+			request.Retry = retry
+			request.Replicas = replicas
+			return oche.RequestService(request)
+		}
+		fail := func(notiChan chan string) (uint64, error) {
+			return 1, errors.New("")
+		}
+		failLater := func(notiChan chan string) (uint64, error) {
+			notiChan <- servicemgr.ConstServiceStatusFailed
+			return 2, nil
+		}
+		succeed := func(notiChan chan string) (uint64, error) {
+			return 3, nil
+		}
+
+		t.Run("ExecuteFail", func(t *testing.T) {
+			res := requestWith(RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond}, 0, fail, succeed)
+			if res.Message != ErrorNone || res.ServiceID != 3 || res.RemoteTargetInfo.Target != "endpoint1" {
+				t.Error("unexpected response", res)
+			}
+			if len(res.Attempts) != 2 || res.Attempts[0].Target != "endpoint2" || res.Attempts[0].Message != InternalServerError ||
+				res.Attempts[1].Target != "endpoint1" || res.Attempts[1].Message != ErrorNone {
+				t.Error("unexpected attempts", res.Attempts)
+			}
+		})
+		t.Run("ServiceFailed", func(t *testing.T) {
+			res := requestWith(RetryPolicy{MaxAttempts: 2, FailureTimeout: 10 * time.Millisecond}, 0, failLater, succeed)
+			if res.Message != ErrorNone || res.ServiceID != 3 || res.RemoteTargetInfo.Target != "endpoint1" {
+				t.Error("unexpected response", res)
+			}
+			if len(res.Attempts) != 2 || res.Attempts[0].ServiceID != 2 || res.Attempts[0].Message != ServiceFailed {
+				t.Error("unexpected attempts", res.Attempts)
+			}
+		})
+		t.Run("DefaultFailureTimeout", func(t *testing.T) {
+			res := requestWith(RetryPolicy{MaxAttempts: 2}, 0, failLater, succeed)
+			if res.Message != ErrorNone || res.ServiceID != 3 || res.RemoteTargetInfo.Target != "endpoint1" {
+				t.Error("unexpected response", res)
+			}
+			if len(res.Attempts) != 2 || res.Attempts[0].Target != "endpoint2" || res.Attempts[0].Message != ServiceFailed {
+				t.Error("unexpected attempts", res.Attempts)
+			}
+		})
+		t.Run("MaxAttempts", func(t *testing.T) {
+			res := requestWith(RetryPolicy{MaxAttempts: 1}, 0, fail)
+			if res.Message != InternalServerError || len(res.Attempts) != 1 {
+				t.Error("unexpected response", res)
+			}
+		})
+		t.Run("AllFailed", func(t *testing.T) {
+			res := requestWith(RetryPolicy{MaxAttempts: 3, FailureTimeout: 10 * time.Millisecond}, 0, fail, failLater)
+			if res.Message != ServiceFailed || res.RemoteTargetInfo.Target != "" || len(res.Attempts) != 2 {
+				t.Error("unexpected response", res)
+			}
+		})
+		t.Run("Replicas", func(t *testing.T) {
+			res := requestWith(RetryPolicy{}, 2, succeed, succeed)
+			if res.Message != ErrorNone || res.RemoteTargetInfo.Target != "endpoint2" || len(res.Replicas) != 2 ||
+				res.Replicas[0].RemoteTargetInfo.Target != "endpoint2" || res.Replicas[1].RemoteTargetInfo.Target != "endpoint1" {
+				t.Error("unexpected response", res)
+			}
+		})
+		t.Run("ReplicasFailover", func(t *testing.T) {
+			res := requestWith(RetryPolicy{MaxAttempts: 2}, 1, fail, succeed)
+			if res.Message != ErrorNone || len(res.Replicas) != 1 || res.Replicas[0].RemoteTargetInfo.Target != "endpoint1" {
+				t.Error("unexpected response", res)
+			}
+		})
+		t.Run("ExecutionHistory", func(t *testing.T) {
+			historyCtrl := gomock.NewController(t)
+			defer historyCtrl.Finish()
+
+			defaultExecDB := mockExecDBExecutor
+			mockExecDBExecutor = dbexecutionMocks.NewMockDBInterface(historyCtrl)
+			defer func() { mockExecDBExecutor = defaultExecDB }()
+			// the requesters of the previous requests are not evicted into this history
+			defaultClients := clients
+			clients = newClientRegistry()
+			defer func() { clients = defaultClients }()
+
+			var recorded []execDB.Info
+			mockExecDBExecutor.EXPECT().Set(gomock.Any()).DoAndReturn(func(info execDB.Info) error {
+				recorded = append(recorded, info)
+				return nil
+			}).Times(2)
+			mockExecDBExecutor.EXPECT().Update(gomock.Any()).DoAndReturn(func(info execDB.Info) error {
+				if info.ID != recorded[0].ID || info.Status != servicemgr.ConstServiceStatusFailed || info.EndTime.IsZero() {
+					t.Error("unexpected update", info)
+				}
+				return nil
+			})
+
+			requestWith(RetryPolicy{MaxAttempts: 2, FailureTimeout: 10 * time.Millisecond}, 0, failLater, succeed)
+			if len(recorded) != 2 || recorded[0].ServiceID != 2 || recorded[0].Target != "endpoint2" ||
+				recorded[0].ServiceName != appName || recorded[0].Resource == nil || recorded[1].Target != "endpoint1" ||
+				recorded[1].Status != servicemgr.ConstServiceStatusStarted || recorded[1].StartTime.IsZero() {
+				t.Error("unexpected executions", recorded)
+			}
+		})
+		t.Run("FirstOfThreeFails", func(t *testing.T) {
+			resources["endpoint3"] = map[string]interface{}{"cpuUsage": 50.0, "cpuCount": 4.0, "cpuFreq": 1000.0,
+				"memAvailable": 262144.0, "netBandwidth": 100.0, "rtt": 0.1}
+			defer func() { resources["endpoint3"] = map[string]interface{}{"error": 0.0} }()
+
+			t.Run("Broadcast", func(t *testing.T) {
+				res := requestWith(RetryPolicy{}, BroadcastReplicas, fail, succeed, succeed)
+				if res.Message != ErrorNone || len(res.Replicas) != 2 || len(res.Attempts) != 3 ||
+					res.Replicas[0].RemoteTargetInfo.Target != "endpoint3" || res.Replicas[1].RemoteTargetInfo.Target != "endpoint1" {
+					t.Error("unexpected response", res)
+				}
+			})
+			t.Run("Replicas", func(t *testing.T) {
+				res := requestWith(RetryPolicy{}, 2, fail, succeed, succeed)
+				if res.Message != ErrorNone || len(res.Replicas) != 2 || len(res.Attempts) != 3 {
+					t.Error("unexpected response", res)
+				}
+			})
+		})
+		t.Run("Broadcast", func(t *testing.T) {
+			res := requestWith(RetryPolicy{}, BroadcastReplicas, succeed, fail)
+			if res.Message != ErrorNone || len(res.Replicas) != 1 || len(res.Attempts) != 2 ||
+				res.Attempts[1].Target != "endpoint1" || res.Attempts[1].Message != InternalServerError {
+				t.Error("unexpected response", res)
+			}
+		})
+	})
+
 	t.Run("Error", func(t *testing.T) {
 		t.Run("NotReady", func(t *testing.T) {
 			mockService.EXPECT().SetLocalServiceExecutor(mockExecutor)
@@ -123,6 +373,49 @@ func TestRequestService(t *testing.T) {
 				t.Error("unexpected Error")
 			}
 		})
+		t.Run("ExecuteFail", func(t *testing.T) {
+			gomock.InOrder(
+				mockService.EXPECT().SetLocalServiceExecutor(mockExecutor),
+				mockDiscovery.EXPECT().SetRestResource(),
+				mockDBHelper.EXPECT().GetDeviceInfoWithService(gomock.Eq(appName), gomock.Any(), gomock.Any()).Return(candidateInfos[:1], nil),
+				mockAppDBExecutor.EXPECT().Get(appName).Return(appDB.Info{}, errors.New("not found")),
+				mockSystemDBExecutor.EXPECT().Get("id").Return(sysInfo, nil),
+				mockNetwork.EXPECT().GetIPs().Return([]string{""}, nil),
+				mockClient.EXPECT().DoScoreRemoteDevice(gomock.Any(), gomock.Any()).Return(float64(1.0), nil),
+				mockNetwork.EXPECT().GetIPs().Return([]string{""}, nil),
+				mockService.EXPECT().Execute(gomock.Any(), appName, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(uint64(2), errors.New("-1")),
+			)
+			o := getOcheIns(ctrl)
+			if o == nil {
+				t.Error("ochestration object is nil, expected is not nil")
+			}
+
+			oche := getOrcheImple()
+
+			oche.Ready = true
+
+			res := oche.RequestService(requestServiceInfo)
+			if res.Message != InternalServerError {
+				t.Error("unexpected Error")
+			}
+		})
+		t.Run("NoCandidateSatisfiesConstraints", func(t *testing.T) {
+			gomock.InOrder(
+				mockService.EXPECT().SetLocalServiceExecutor(mockExecutor),
+				mockDiscovery.EXPECT().SetRestResource(),
+				mockDBHelper.EXPECT().GetDeviceInfoWithService(gomock.Eq(appName), gomock.Any(), gomock.Any()).Return(candidateInfos, nil),
+			)
+			getOcheIns(ctrl)
+			oche := getOrcheImple()
+			oche.Ready = true
+
+			request := requestServiceInfo
+			request.Constraints = Constraints{ExcludedDevices: []string{"ID1", "ID2", "ID3"}}
+			res := oche.RequestService(request)
+			if res.Message != NoCandidateSatisfiesConstraints {
+				t.Error("unexpected message", res.Message)
+			}
+		})
 		t.Run("DiscoveryFail", func(t *testing.T) {
 			gomock.InOrder(
 				mockService.EXPECT().SetLocalServiceExecutor(mockExecutor),
@@ -145,3 +438,447 @@ func TestRequestService(t *testing.T) {
 		})
 	})
 }
+
+func TestServiceLifecycle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	createMockIns(ctrl)
+
+	serviceID := uint64(1)
+	service := map[string]interface{}{
+		servicemgr.ConstKeyServiceID:   serviceID,
+		servicemgr.ConstKeyServiceName: "MyApp",
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		gomock.InOrder(
+			mockService.EXPECT().SetLocalServiceExecutor(mockExecutor),
+			mockDiscovery.EXPECT().SetRestResource(),
+			mockService.EXPECT().List().Return([]map[string]interface{}{service}),
+			mockService.EXPECT().Get(serviceID).Return(service, nil),
+			mockService.EXPECT().Stop(serviceID).Return(nil),
+		)
+		watchChan := make(chan map[string]interface{}, 1)
+		watchChan <- service
+		mockService.EXPECT().Watch(serviceID).Return((<-chan map[string]interface{})(watchChan), func() {}, nil)
+
+		getOcheIns(ctrl)
+		oche := getOrcheImple()
+		oche.Ready = true
+
+		if res := oche.ListServices(); res.Message != ErrorNone || len(res.Services) != 1 {
+			t.Error("unexpected list result")
+		}
+		if res := oche.GetService(serviceID); res.Message != ErrorNone || len(res.Services) != 1 {
+			t.Error("unexpected get result")
+		}
+		if msg := oche.StopService(serviceID); msg != ErrorNone {
+			t.Error("unexpected stop result")
+		}
+		if events, _, msg := oche.WatchService(serviceID); msg != ErrorNone || (<-events)[servicemgr.ConstKeyServiceID] != serviceID {
+			t.Error("unexpected watch result")
+		}
+	})
+	t.Run("Error", func(t *testing.T) {
+		t.Run("NotReady", func(t *testing.T) {
+			gomock.InOrder(
+				mockService.EXPECT().SetLocalServiceExecutor(mockExecutor),
+				mockDiscovery.EXPECT().SetRestResource(),
+			)
+
+			getOcheIns(ctrl)
+			oche := getOrcheImple()
+
+			if res := oche.ListServices(); res.Message != InternalServerError {
+				t.Error("unexpected list result")
+			}
+			if msg := oche.StopService(serviceID); msg != InternalServerError {
+				t.Error("unexpected stop result")
+			}
+		})
+		t.Run("ServiceNotFound", func(t *testing.T) {
+			gomock.InOrder(
+				mockService.EXPECT().SetLocalServiceExecutor(mockExecutor),
+				mockDiscovery.EXPECT().SetRestResource(),
+				mockService.EXPECT().Get(serviceID).Return(nil, servicemgr.ErrInvalidService),
+				mockService.EXPECT().Stop(serviceID).Return(errormsg.NotFound{Message: "not running"}),
+				mockService.EXPECT().Watch(serviceID).Return(nil, nil, servicemgr.ErrInvalidService),
+			)
+
+			getOcheIns(ctrl)
+			oche := getOrcheImple()
+			oche.Ready = true
+
+			if res := oche.GetService(serviceID); res.Message != ServiceNotFound {
+				t.Error("unexpected get result")
+			}
+			if msg := oche.StopService(serviceID); msg != ServiceNotFound {
+				t.Error("unexpected stop result")
+			}
+			if _, _, msg := oche.WatchService(serviceID); msg != ServiceNotFound {
+				t.Error("unexpected watch result")
+			}
+		})
+	})
+}
+
+func TestGetDeviceLabels(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	createMockIns(ctrl)
+
+	selector := map[string]string{"room": "kitchen"}
+	devices := map[string]map[string]string{"ID1": {"room": "kitchen"}}
+
+	t.Run("Success", func(t *testing.T) {
+		gomock.InOrder(
+			mockService.EXPECT().SetLocalServiceExecutor(mockExecutor),
+			mockDiscovery.EXPECT().SetRestResource(),
+			mockDBHelper.EXPECT().GetDevicesWithLabels(gomock.Eq(selector)).Return(devices, nil),
+		)
+
+		getOcheIns(ctrl)
+		oche := getOrcheImple()
+		oche.Ready = true
+
+		if res := oche.GetDeviceLabels(selector); res.Message != ErrorNone || res.Devices["ID1"]["room"] != "kitchen" {
+			t.Error("unexpected result", res)
+		}
+	})
+	t.Run("Error", func(t *testing.T) {
+		gomock.InOrder(
+			mockService.EXPECT().SetLocalServiceExecutor(mockExecutor),
+			mockDiscovery.EXPECT().SetRestResource(),
+			mockDBHelper.EXPECT().GetDevicesWithLabels(gomock.Any()).Return(nil, errors.New("")),
+		)
+
+		getOcheIns(ctrl)
+		oche := getOrcheImple()
+
+		if res := oche.GetDeviceLabels(selector); res.Message != InternalServerError {
+			t.Error("unexpected result", res)
+		}
+		oche.Ready = true
+		if res := oche.GetDeviceLabels(selector); res.Message != InternalServerError {
+			t.Error("unexpected result", res)
+		}
+	})
+}
+
+func TestGetDevices(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	createMockIns(ctrl)
+
+	devices := []dbhelper.DeviceInfo{{ID: "ID1", Platform: "linux", State: "online"}}
+	states := []map[string]interface{}{
+		{"DeviceID": "ID1", "State": "online", "LastSeen": time.Now()},
+		{"DeviceID": "ID0", "State": "removed", "LastSeen": time.Now()},
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		gomock.InOrder(
+			mockService.EXPECT().SetLocalServiceExecutor(mockExecutor),
+			mockDiscovery.EXPECT().SetRestResource(),
+			mockDBHelper.EXPECT().GetDevices().Return(devices, nil),
+			mockDiscovery.EXPECT().GetDeviceStates().Return(states, nil),
+			mockSystemDBExecutor.EXPECT().Get(sysDB.ID).Return(sysDB.Info{Name: sysDB.ID, Value: "ID1"}, nil),
+			mockScoring.EXPECT().GetResource(gomock.Eq("ID1")).Return(map[string]interface{}{"cpuUsage": 10.0}, nil),
+		)
+
+		getOcheIns(ctrl)
+		oche := getOrcheImple()
+		oche.Ready = true
+
+		res := oche.GetDevices()
+		if res.Message != ErrorNone || len(res.Devices) != 2 {
+			t.Fatal("unexpected result", res)
+		}
+		if res.Devices[0].ID != "ID0" || res.Devices[0].State != "removed" || res.Devices[0].LastSeen.IsZero() {
+			t.Error("removed device is not merged", res.Devices[0])
+		}
+		if res.Devices[1].ID != "ID1" || res.Devices[1].Platform != "linux" || res.Devices[1].Resource["cpuUsage"] != 10.0 {
+			t.Error("unexpected device", res.Devices[1])
+		}
+	})
+	t.Run("Error", func(t *testing.T) {
+		gomock.InOrder(
+			mockService.EXPECT().SetLocalServiceExecutor(mockExecutor),
+			mockDiscovery.EXPECT().SetRestResource(),
+			mockDBHelper.EXPECT().GetDevices().Return(nil, errors.New("")),
+		)
+
+		getOcheIns(ctrl)
+		oche := getOrcheImple()
+
+		if res := oche.GetDevices(); res.Message != InternalServerError {
+			t.Error("unexpected result", res)
+		}
+		oche.Ready = true
+		if res := oche.GetDevices(); res.Message != InternalServerError {
+			t.Error("unexpected result", res)
+		}
+
+		gomock.InOrder(
+			mockDBHelper.EXPECT().GetDevices().Return(devices, nil),
+			mockDiscovery.EXPECT().GetDeviceStates().Return(nil, errors.New("")),
+		)
+		if res := oche.GetDevices(); res.Message != InternalServerError {
+			t.Error("unexpected result", res)
+		}
+	})
+}
+
+func TestGetDevice(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	createMockIns(ctrl)
+
+	t.Run("Success", func(t *testing.T) {
+		gomock.InOrder(
+			mockService.EXPECT().SetLocalServiceExecutor(mockExecutor),
+			mockDiscovery.EXPECT().SetRestResource(),
+			mockDBHelper.EXPECT().GetDevice(gomock.Eq("ID1")).Return(dbhelper.DeviceInfo{ID: "ID1", Platform: "linux"}, nil),
+			mockSystemDBExecutor.EXPECT().Get(sysDB.ID).Return(sysDB.Info{Name: sysDB.ID, Value: "ID0"}, nil),
+		)
+
+		getOcheIns(ctrl)
+		oche := getOrcheImple()
+		oche.Ready = true
+
+		if res := oche.GetDevice("ID1"); res.Message != ErrorNone || len(res.Devices) != 1 || res.Devices[0].ID != "ID1" {
+			t.Error("unexpected result", res)
+		}
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		gomock.InOrder(
+			mockService.EXPECT().SetLocalServiceExecutor(mockExecutor),
+			mockDiscovery.EXPECT().SetRestResource(),
+			mockDBHelper.EXPECT().GetDevice(gomock.Eq("ID2")).Return(dbhelper.DeviceInfo{}, errormsg.NotFound{}),
+			mockDiscovery.EXPECT().GetDeviceStates().Return([]map[string]interface{}{{"DeviceID": "ID3", "State": "removed"}}, nil),
+		)
+
+		getOcheIns(ctrl)
+		oche := getOrcheImple()
+		oche.Ready = true
+
+		if res := oche.GetDevice("ID2"); res.Message != DeviceNotFound {
+			t.Error("unexpected result", res)
+		}
+	})
+	t.Run("Removed", func(t *testing.T) {
+		gomock.InOrder(
+			mockService.EXPECT().SetLocalServiceExecutor(mockExecutor),
+			mockDiscovery.EXPECT().SetRestResource(),
+			mockDBHelper.EXPECT().GetDevice(gomock.Eq("ID3")).Return(dbhelper.DeviceInfo{}, errormsg.NotFound{}),
+			mockDiscovery.EXPECT().GetDeviceStates().Return([]map[string]interface{}{{"DeviceID": "ID3", "State": "removed"}}, nil),
+		)
+
+		getOcheIns(ctrl)
+		oche := getOrcheImple()
+		oche.Ready = true
+
+		if res := oche.GetDevice("ID3"); res.Message != ErrorNone || len(res.Devices) != 1 || res.Devices[0].State != "removed" {
+			t.Error("unexpected result", res)
+		}
+	})
+	t.Run("Error", func(t *testing.T) {
+		gomock.InOrder(
+			mockService.EXPECT().SetLocalServiceExecutor(mockExecutor),
+			mockDiscovery.EXPECT().SetRestResource(),
+			mockDBHelper.EXPECT().GetDevice(gomock.Any()).Return(dbhelper.DeviceInfo{}, errors.New("")),
+		)
+
+		getOcheIns(ctrl)
+		oche := getOrcheImple()
+
+		if res := oche.GetDevice("ID1"); res.Message != InternalServerError {
+			t.Error("unexpected result", res)
+		}
+		oche.Ready = true
+		if res := oche.GetDevice("ID1"); res.Message != InternalServerError {
+			t.Error("unexpected result", res)
+		}
+	})
+}
+
+func TestGetExecutions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	createMockIns(ctrl)
+
+	startTime := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	executions := []execDB.Info{
+		{ID: "1", ServiceName: "hello", Target: "192.168.1.2", StartTime: startTime},
+		{ID: "2", ServiceName: "hello", Target: "192.168.1.3", StartTime: startTime.Add(time.Hour)},
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		gomock.InOrder(
+			mockService.EXPECT().SetLocalServiceExecutor(mockExecutor),
+			mockDiscovery.EXPECT().SetRestResource(),
+			mockExecDBExecutor.EXPECT().GetByService("hello").Return(executions, nil),
+			mockExecDBExecutor.EXPECT().GetByTimeRange(startTime.Add(time.Minute), time.Time{}).Return(executions[1:], nil),
+		)
+
+		getOcheIns(ctrl)
+		oche := getOrcheImple()
+		oche.Ready = true
+
+		res := oche.GetExecutions(ExecutionFilter{ServiceName: "hello", Target: "192.168.1.3"})
+		if res.Message != ErrorNone || len(res.Executions) != 1 || res.Executions[0].ID != "2" {
+			t.Error("unexpected result", res)
+		}
+		res = oche.GetExecutions(ExecutionFilter{From: startTime.Add(time.Minute)})
+		if res.Message != ErrorNone || len(res.Executions) != 1 || res.Executions[0].ID != "2" {
+			t.Error("unexpected result", res)
+		}
+	})
+	t.Run("Error", func(t *testing.T) {
+		gomock.InOrder(
+			mockService.EXPECT().SetLocalServiceExecutor(mockExecutor),
+			mockDiscovery.EXPECT().SetRestResource(),
+			mockExecDBExecutor.EXPECT().GetList().Return(nil, errors.New("")),
+		)
+
+		getOcheIns(ctrl)
+		oche := getOrcheImple()
+
+		if res := oche.GetExecutions(ExecutionFilter{}); res.Message != InternalServerError {
+			t.Error("unexpected result", res)
+		}
+		oche.Ready = true
+		if res := oche.GetExecutions(ExecutionFilter{From: startTime, To: startTime.Add(-time.Hour)}); res.Message != InvalidParameter {
+			t.Error("unexpected result", res)
+		}
+		if res := oche.GetExecutions(ExecutionFilter{}); res.Message != InternalServerError {
+			t.Error("unexpected result", res)
+		}
+	})
+}
+
+func TestGetClientStats(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	createMockIns(ctrl)
+
+	gomock.InOrder(
+		mockService.EXPECT().SetLocalServiceExecutor(mockExecutor),
+		mockDiscovery.EXPECT().SetRestResource(),
+	)
+
+	getOcheIns(ctrl)
+	oche := getOrcheImple()
+
+	if res := oche.GetClientStats(); res.Message != InternalServerError {
+		t.Error("unexpected result", res)
+	}
+	oche.Ready = true
+	if res := oche.GetClientStats(); res.Message != ErrorNone || res.Clients != clients.getStats() {
+		t.Error("unexpected result", res)
+	}
+}
+
+func TestWatchDevices(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	createMockIns(ctrl)
+
+	t.Run("Success", func(t *testing.T) {
+		watchChan := make(chan map[string]interface{})
+		gomock.InOrder(
+			mockService.EXPECT().SetLocalServiceExecutor(mockExecutor),
+			mockDiscovery.EXPECT().SetRestResource(),
+			mockDiscovery.EXPECT().WatchDeviceStates().Return((<-chan map[string]interface{})(watchChan), func() {}),
+		)
+
+		getOcheIns(ctrl)
+		oche := getOrcheImple()
+		oche.Ready = true
+
+		ch, cancel, message := oche.WatchDevices()
+		if message != ErrorNone || ch == nil || cancel == nil {
+			t.Error("unexpected result", message)
+		}
+	})
+	t.Run("NotReady", func(t *testing.T) {
+		gomock.InOrder(
+			mockService.EXPECT().SetLocalServiceExecutor(mockExecutor),
+			mockDiscovery.EXPECT().SetRestResource(),
+		)
+
+		getOcheIns(ctrl)
+		oche := getOrcheImple()
+
+		if _, _, message := oche.WatchDevices(); message != InternalServerError {
+			t.Error("unexpected result", message)
+		}
+	})
+}
+
+func TestConstraints(t *testing.T) {
+	t.Run("Candidate", func(t *testing.T) {
+		candidate := dbhelper.ExecutionCandidate{
+			ID:       "ID1",
+			Platform: "linux",
+			Labels:   map[string]string{"gpu": "true", "room": "kitchen"},
+		}
+
+		satisfied := []Constraints{
+			{},
+			{Platform: "Linux"},
+			{Labels: map[string]string{"gpu": "true"}},
+			{ExcludedDevices: []string{"ID2"}},
+		}
+		for _, c := range satisfied {
+			if err := c.satisfiedByCandidate(candidate); err != nil {
+				t.Error("unexpected fail", c, err.Error())
+			}
+		}
+
+		unsatisfied := []Constraints{
+			{Platform: "android"},
+			{Labels: map[string]string{"gpu": "false"}},
+			{Labels: map[string]string{"camera": "true"}},
+			{ExcludedDevices: []string{"ID1"}},
+		}
+		for _, c := range unsatisfied {
+			if err := c.satisfiedByCandidate(candidate); err == nil {
+				t.Error("unexpected success", c)
+			}
+		}
+	})
+	t.Run("Resource", func(t *testing.T) {
+		devices := []deviceInfo{
+			{id: "small", resource: map[string]interface{}{"cpuCount": 2.0, "memAvailable": 262144.0, "rtt": 0.1, "arch": "arm"}},
+			{id: "large", resource: map[string]interface{}{"cpuCount": 8.0, "memAvailable": 4194304.0, "rtt": 0.5, "arch": "amd64"}},
+			{id: "unknown", resource: map[string]interface{}{}},
+			{id: "error", resource: map[string]interface{}{"error": 0.0}},
+		}
+
+		if filtered := filterByResource(devices, Constraints{}); len(filtered) != len(devices) {
+			t.Error("unexpected filtering without constraints")
+		}
+
+		expected := map[string]Constraints{
+			"small": {MaxRTT: 0.2},
+			"large": {MinCPUCount: 4, MinFreeMemory: 1024, Arch: "amd64"},
+		}
+		for id, c := range expected {
+			if filtered := filterByResource(devices, c); len(filtered) != 1 || filtered[0].id != id {
+				t.Error("unexpected filtered devices", filtered)
+			}
+		}
+
+		if filtered := filterByResource(devices, Constraints{MinFreeMemory: 8192}); len(filtered) != 0 {
+			t.Error("unexpected filtered devices", filtered)
+		}
+	})
+}
@@ -19,6 +19,9 @@
 package client
 
 import (
+	"time"
+
+	"github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr/servicelog"
 	"github.com/lf-edge/edge-home-orchestration-go/internal/restinterface/cipher"
 )
 
@@ -29,12 +32,15 @@ type Clienter interface {
 	// for servicemgr
 	DoExecuteRemoteDevice(appInfo map[string]interface{}, target string) (err error)
 	DoNotifyAppStatusRemoteDevice(statusNotificationInfo map[string]interface{}, appID uint64, target string) (err error)
+	DoStopRemoteDevice(appID uint64, target string) (err error)
+	DoGetLogsRemoteDevice(appID uint64, target string, tail int, since time.Time) (entries []servicelog.Entry, finished bool, err error)
 
 	// for scoringmgr
 	DoScoreRemoteDevice(devID string, endpoint string) (scoreValue float64, err error)
 	DoGetResourceRemoteDevice(devID string, endpoint string) (respMsg map[string]interface{}, err error)
 	// for discoverymgr
 	DoGetOrchestrationInfo(endpoint string) (platform string, executionType string, serviceList []string, err error)
+	DoGetDeviceInfo(endpoint string) (respMsg map[string]interface{}, err error)
 	DoNotifyMNEDCBroadcastServer(endpoint string, port int, deviceID string, privateIP string, virtualIP string) error
 }
 
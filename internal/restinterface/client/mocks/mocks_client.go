@@ -23,7 +23,9 @@ package mocks
 
 import (
 	reflect "reflect"
+	time "time"
 
+	servicelog "github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr/servicelog"
 	cipher "github.com/lf-edge/edge-home-orchestration-go/internal/restinterface/cipher"
 	client "github.com/lf-edge/edge-home-orchestration-go/internal/restinterface/client"
 
@@ -84,6 +86,21 @@ func (mr *MockClienterMockRecorder) DoGetOrchestrationInfo(arg0 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DoGetOrchestrationInfo", reflect.TypeOf((*MockClienter)(nil).DoGetOrchestrationInfo), arg0)
 }
 
+// DoGetDeviceInfo mocks base method.
+func (m *MockClienter) DoGetDeviceInfo(arg0 string) (map[string]interface{}, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DoGetDeviceInfo", arg0)
+	ret0, _ := ret[0].(map[string]interface{})
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DoGetDeviceInfo indicates an expected call of DoGetDeviceInfo.
+func (mr *MockClienterMockRecorder) DoGetDeviceInfo(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DoGetDeviceInfo", reflect.TypeOf((*MockClienter)(nil).DoGetDeviceInfo), arg0)
+}
+
 // DoGetResourceRemoteDevice mocks base method.
 func (m *MockClienter) DoGetResourceRemoteDevice(arg0, arg1 string) (map[string]interface{}, error) {
 	m.ctrl.T.Helper()
@@ -99,6 +116,36 @@ func (mr *MockClienterMockRecorder) DoGetResourceRemoteDevice(arg0, arg1 interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DoGetResourceRemoteDevice", reflect.TypeOf((*MockClienter)(nil).DoGetResourceRemoteDevice), arg0, arg1)
 }
 
+// DoStopRemoteDevice mocks base method.
+func (m *MockClienter) DoStopRemoteDevice(arg0 uint64, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DoStopRemoteDevice", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DoStopRemoteDevice indicates an expected call of DoStopRemoteDevice.
+func (mr *MockClienterMockRecorder) DoStopRemoteDevice(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DoStopRemoteDevice", reflect.TypeOf((*MockClienter)(nil).DoStopRemoteDevice), arg0, arg1)
+}
+
+// DoGetLogsRemoteDevice mocks base method.
+func (m *MockClienter) DoGetLogsRemoteDevice(arg0 uint64, arg1 string, arg2 int, arg3 time.Time) ([]servicelog.Entry, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DoGetLogsRemoteDevice", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]servicelog.Entry)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// DoGetLogsRemoteDevice indicates an expected call of DoGetLogsRemoteDevice.
+func (mr *MockClienterMockRecorder) DoGetLogsRemoteDevice(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DoGetLogsRemoteDevice", reflect.TypeOf((*MockClienter)(nil).DoGetLogsRemoteDevice), arg0, arg1, arg2, arg3)
+}
+
 // DoScoreRemoteDevice mocks base method.
 func (m *MockClienter) DoScoreRemoteDevice(arg0, arg1 string) (float64, error) {
 	m.ctrl.T.Helper()
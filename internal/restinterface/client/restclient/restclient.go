@@ -19,13 +19,18 @@
 package restclient
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/lf-edge/edge-home-orchestration-go/internal/common/logmgr"
 
+	"github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr/servicelog"
 	"github.com/lf-edge/edge-home-orchestration-go/internal/restinterface/cipher"
 	"github.com/lf-edge/edge-home-orchestration-go/internal/restinterface/client"
 	"github.com/lf-edge/edge-home-orchestration-go/internal/restinterface/resthelper"
@@ -123,6 +128,62 @@ func (c restClientImpl) DoNotifyAppStatusRemoteDevice(statusNotificationInfo map
 	return nil
 }
 
+// DoStopRemoteDevice sends request to remote orchestration (APIV1ServicemgrServicesServiceIDDelete) to stop service
+func (c restClientImpl) DoStopRemoteDevice(appID uint64, target string) error {
+	log.Printf("%s DoStopRemoteDevice : endpoint[%v]", logPrefix, logmgr.SanitizeUserInput(target)) // lgtm [go/log-injection]
+	if !c.IsSetKey {
+		return errors.New(logPrefix + " does not set key")
+	}
+
+	restapi := fmt.Sprintf("/api/v1/servicemgr/services/%d", appID)
+
+	targetURL := c.helper.MakeTargetURL(target, c.internalPort, restapi)
+
+	_, code, err := c.helper.DoDelete(targetURL)
+	if err != nil {
+		return errors.New(logPrefix + " delete return error")
+	} else if code != http.StatusOK {
+		return fmt.Errorf("%s delete return status %d", logPrefix, code)
+	}
+
+	return nil
+}
+
+// DoGetLogsRemoteDevice sends request to remote orchestration (APIV1ServicemgrServicesServiceIDLogsGet) to get the log of service
+func (c restClientImpl) DoGetLogsRemoteDevice(appID uint64, target string, tail int, since time.Time) (entries []servicelog.Entry, finished bool, err error) {
+	if !c.IsSetKey {
+		return nil, false, errors.New(logPrefix + " does not set key")
+	}
+
+	query := url.Values{}
+	query.Set("tail", strconv.Itoa(tail))
+	if !since.IsZero() {
+		query.Set("since", since.Format(time.RFC3339Nano))
+	}
+	restapi := fmt.Sprintf("/api/v1/servicemgr/services/%d/logs?%s", appID, query.Encode())
+
+	targetURL := c.helper.MakeTargetURL(target, c.internalPort, restapi)
+
+	respBytes, code, err := c.helper.DoGet(targetURL)
+	if err != nil {
+		return nil, false, errors.New(logPrefix + " get return error")
+	} else if code != http.StatusOK {
+		return nil, false, fmt.Errorf("%s get return status %d", logPrefix, code)
+	}
+
+	respMsg, err := c.Key.DecryptByteToJSON(respBytes)
+	if err != nil {
+		return nil, false, errors.New(logPrefix + " can not decryption " + err.Error())
+	}
+
+	finished, _ = respMsg["Finished"].(bool)
+	logs, err := json.Marshal(respMsg["Logs"])
+	if err == nil {
+		err = json.Unmarshal(logs, &entries)
+	}
+	return
+}
+
 // DoScoreRemoteDevice  sends request to remote orchestration (APIV1ScoringmgrScoreLibnameGet) to get score
 func (c restClientImpl) DoScoreRemoteDevice(devID string, endpoint string) (scoreValue float64, err error) {
 	log.Printf("%s DoScoreRemoteDevice : endpoint[%v]", logPrefix, endpoint)
@@ -197,31 +258,11 @@ func (c restClientImpl) DoGetResourceRemoteDevice(devID string, endpoint string)
 
 // DoGetOrchestrationInfo requests for orchestration info from endpoint
 func (c restClientImpl) DoGetOrchestrationInfo(endpoint string) (string, string, []string, error) {
-	if !c.IsSetKey {
-		return "", "", []string{}, errors.New("[" + logPrefix + "] does not set key")
-	}
-
 	log.Println(logPrefix, "DoGetOrchestrationInfo", "for", logmgr.SanitizeUserInput(endpoint)) // lgtm [go/log-injection]
 
-	restapi := "/api/v1/discoverymgr/orchestrationinfo"
-
-	targetURL := c.helper.MakeTargetURL(endpoint, c.internalPort, restapi)
-
-	info := make(map[string]interface{})
-	info["devID"] = "DevID"
-	encryptBytes, err := c.Key.EncryptJSONToByte(info)
+	respMsg, err := c.getOrchestrationInfo(endpoint)
 	if err != nil {
-		return "", "", []string{}, errors.New("[" + logPrefix + "] can not encryption " + err.Error())
-	}
-
-	respBytes, code, err := c.helper.DoGetWithBody(targetURL, encryptBytes)
-	if err != nil || code != http.StatusOK {
-		return "", "", []string{}, errors.New("[" + logPrefix + "] get return error")
-	}
-
-	respMsg, err := c.Key.DecryptByteToJSON(respBytes)
-	if err != nil {
-		return "", "", []string{}, errors.New("[" + logPrefix + "] can not decryption " + err.Error())
+		return "", "", []string{}, err
 	}
 
 	log.Println("[JSON] : ", strings.ReplaceAll(fmt.Sprintf("%#v", respMsg), "\"", "")) // lgtm [go/log-injection]
@@ -241,6 +282,42 @@ func (c restClientImpl) DoGetOrchestrationInfo(endpoint string) (string, string,
 	return platform, executionType, serviceList, nil
 }
 
+// DoGetDeviceInfo requests for orchestration info from endpoint, the response of the device which knows
+// its device ID and labels has them as DeviceID and Labels
+func (c restClientImpl) DoGetDeviceInfo(endpoint string) (respMsg map[string]interface{}, err error) {
+	log.Println(logPrefix, "DoGetDeviceInfo", "for", logmgr.SanitizeUserInput(endpoint)) // lgtm [go/log-injection]
+
+	return c.getOrchestrationInfo(endpoint)
+}
+
+func (c restClientImpl) getOrchestrationInfo(endpoint string) (respMsg map[string]interface{}, err error) {
+	if !c.IsSetKey {
+		return nil, errors.New("[" + logPrefix + "] does not set key")
+	}
+
+	restapi := "/api/v1/discoverymgr/orchestrationinfo"
+
+	targetURL := c.helper.MakeTargetURL(endpoint, c.internalPort, restapi)
+
+	info := make(map[string]interface{})
+	info["devID"] = "DevID"
+	encryptBytes, err := c.Key.EncryptJSONToByte(info)
+	if err != nil {
+		return nil, errors.New("[" + logPrefix + "] can not encryption " + err.Error())
+	}
+
+	respBytes, code, err := c.helper.DoGetWithBody(targetURL, encryptBytes)
+	if err != nil || code != http.StatusOK {
+		return nil, errors.New("[" + logPrefix + "] get return error")
+	}
+
+	respMsg, err = c.Key.DecryptByteToJSON(respBytes)
+	if err != nil {
+		return nil, errors.New("[" + logPrefix + "] can not decryption " + err.Error())
+	}
+	return respMsg, nil
+}
+
 // DoNotifyMNEDCBroadcastServer sends the device details to MNEDC server
 func (c restClientImpl) DoNotifyMNEDCBroadcastServer(endpoint string, port int, deviceID string, privateIP string, virtualIP string) error {
 	if !c.IsSetKey {
@@ -21,6 +21,7 @@ import (
 	"errors"
 	"net/http"
 	"testing"
+	"time"
 
 	ciphermock "github.com/lf-edge/edge-home-orchestration-go/internal/restinterface/cipher/mocks"
 	helpermock "github.com/lf-edge/edge-home-orchestration-go/internal/restinterface/resthelper/mocks"
@@ -239,6 +240,137 @@ func TestDoNotifyAppStatusRemoteDevice(t *testing.T) {
 	})
 }
 
+func TestDoStopRemoteDevice(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := restClient
+	if client == nil {
+		t.Error("unexpected return value")
+	}
+
+	mockCipher := ciphermock.NewMockIEdgeCipherer(ctrl)
+	mockHelper := helpermock.NewMockRestHelper(ctrl)
+
+	t.Run("Error", func(t *testing.T) {
+		t.Run("IsNotSetKey", func(t *testing.T) {
+			client.setHelper(mockHelper)
+
+			client.IsSetKey = false
+			err := client.DoStopRemoteDevice(1, "")
+			if err == nil {
+				t.Error("expect error is not nil, but nil")
+			}
+		})
+		t.Run("DoDelete", func(t *testing.T) {
+			t.Run("ReturnError", func(t *testing.T) {
+				client.SetCipher(mockCipher)
+				client.setHelper(mockHelper)
+				gomock.InOrder(
+					mockHelper.EXPECT().MakeTargetURL(gomock.Any(), gomock.Any(), "/api/v1/servicemgr/services/1").Return(""),
+					mockHelper.EXPECT().DoDelete(gomock.Any()).Return(nil, http.StatusOK, errors.New("")),
+				)
+
+				err := client.DoStopRemoteDevice(1, "")
+				if err == nil {
+					t.Error("expect error is not nil, but nil")
+				}
+			})
+			t.Run("StatusNotOk", func(t *testing.T) {
+				client.SetCipher(mockCipher)
+				client.setHelper(mockHelper)
+				gomock.InOrder(
+					mockHelper.EXPECT().MakeTargetURL(gomock.Any(), gomock.Any(), gomock.Any()).Return(""),
+					mockHelper.EXPECT().DoDelete(gomock.Any()).Return(nil, http.StatusNotFound, nil),
+				)
+
+				err := client.DoStopRemoteDevice(1, "")
+				if err == nil {
+					t.Error("expect error is not nil, but nil")
+				}
+			})
+		})
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		client.SetCipher(mockCipher)
+		client.setHelper(mockHelper)
+		gomock.InOrder(
+			mockHelper.EXPECT().MakeTargetURL(gomock.Any(), gomock.Any(), gomock.Any()).Return(""),
+			mockHelper.EXPECT().DoDelete(gomock.Any()).Return(nil, http.StatusOK, nil),
+		)
+
+		err := client.DoStopRemoteDevice(1, "")
+		if err != nil {
+			t.Error("expect error is nil, but not nil")
+		}
+	})
+}
+
+func TestDoGetLogsRemoteDevice(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := restClient
+	if client == nil {
+		t.Error("unexpected return value")
+	}
+
+	mockCipher := ciphermock.NewMockIEdgeCipherer(ctrl)
+	mockHelper := helpermock.NewMockRestHelper(ctrl)
+
+	since := time.Date(2020, 9, 1, 10, 0, 0, 0, time.UTC)
+
+	t.Run("Error", func(t *testing.T) {
+		t.Run("IsNotSetKey", func(t *testing.T) {
+			client.setHelper(mockHelper)
+
+			client.IsSetKey = false
+			_, _, err := client.DoGetLogsRemoteDevice(1, "", 0, since)
+			if err == nil {
+				t.Error("expect error is not nil, but nil")
+			}
+		})
+		t.Run("StatusNotOk", func(t *testing.T) {
+			client.SetCipher(mockCipher)
+			client.setHelper(mockHelper)
+			gomock.InOrder(
+				mockHelper.EXPECT().MakeTargetURL(gomock.Any(), gomock.Any(), gomock.Any()).Return(""),
+				mockHelper.EXPECT().DoGet(gomock.Any()).Return(nil, http.StatusNotFound, nil),
+			)
+
+			_, _, err := client.DoGetLogsRemoteDevice(1, "", 0, since)
+			if err == nil {
+				t.Error("expect error is not nil, but nil")
+			}
+		})
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		client.SetCipher(mockCipher)
+		client.setHelper(mockHelper)
+
+		decryptJSON := map[string]interface{}{
+			"Finished": true,
+			"Logs": []interface{}{
+				map[string]interface{}{"Time": "2020-09-01T10:00:01Z", "Stream": "stdout", "Message": "hello"},
+			},
+		}
+		gomock.InOrder(
+			mockHelper.EXPECT().MakeTargetURL(gomock.Any(), gomock.Any(), "/api/v1/servicemgr/services/1/logs?since=2020-09-01T10%3A00%3A00Z&tail=10").Return(""),
+			mockHelper.EXPECT().DoGet(gomock.Any()).Return(nil, http.StatusOK, nil),
+			mockCipher.EXPECT().DecryptByteToJSON(gomock.Any()).Return(decryptJSON, nil),
+		)
+
+		entries, finished, err := client.DoGetLogsRemoteDevice(1, "", 10, since)
+		if err != nil {
+			t.Error("expect error is nil, but not nil")
+		} else if !finished || len(entries) != 1 || entries[0].Message != "hello" || !entries[0].Time.After(since) {
+			t.Error("unexpected logs", entries, finished)
+		}
+	})
+}
+
 func TestDoScoreRemoteDevice(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -558,6 +690,64 @@ func TestDoGetOrchestrationInfo(t *testing.T) {
 	})
 }
 
+func TestDoGetDeviceInfo(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := restClient
+	if client == nil {
+		t.Error("unexpected return value")
+	}
+
+	mockCipher := ciphermock.NewMockIEdgeCipherer(ctrl)
+	mockHelper := helpermock.NewMockRestHelper(ctrl)
+
+	t.Run("IsNotSetKey", func(t *testing.T) {
+		client.setHelper(mockHelper)
+
+		client.IsSetKey = false
+		_, err := client.DoGetDeviceInfo("")
+		if err == nil {
+			t.Error("expect error is not nil, but nil")
+		}
+	})
+	t.Run("DoGetWithBody", func(t *testing.T) {
+		client.SetCipher(mockCipher)
+		client.setHelper(mockHelper)
+
+		gomock.InOrder(
+			mockHelper.EXPECT().MakeTargetURL(gomock.Any(), gomock.Any(), gomock.Any()).Return(""),
+			mockCipher.EXPECT().EncryptJSONToByte(gomock.Any()).Return(nil, nil),
+			mockHelper.EXPECT().DoGetWithBody(gomock.Any(), gomock.Any()).Return(nil, http.StatusServiceUnavailable, nil),
+		)
+		_, err := client.DoGetDeviceInfo("")
+		if err == nil {
+			t.Error("expect error is not nil, but nil")
+		}
+	})
+	t.Run("Success", func(t *testing.T) {
+		client.SetCipher(mockCipher)
+		client.setHelper(mockHelper)
+
+		respJSONMsg := make(map[string]interface{})
+		respJSONMsg["DeviceID"] = "edge-orchestration-peer"
+		respJSONMsg["Platform"] = "platform"
+
+		gomock.InOrder(
+			mockHelper.EXPECT().MakeTargetURL(gomock.Any(), gomock.Any(), gomock.Any()).Return(""),
+			mockCipher.EXPECT().EncryptJSONToByte(gomock.Any()).Return(nil, nil),
+			mockHelper.EXPECT().DoGetWithBody(gomock.Any(), gomock.Any()).Return(nil, http.StatusOK, nil),
+			mockCipher.EXPECT().DecryptByteToJSON(gomock.Any()).Return(respJSONMsg, nil),
+		)
+		respMsg, err := client.DoGetDeviceInfo("")
+		if err != nil {
+			t.Error("expected error is nil, but not nil")
+		} else if respMsg["DeviceID"] != "edge-orchestration-peer" {
+			t.Error("unexpected response", respMsg)
+		}
+	})
+}
+
 func TestDoNotifyMNEDCBroadcastServer(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
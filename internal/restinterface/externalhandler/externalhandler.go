@@ -19,16 +19,21 @@
 package externalhandler
 
 import (
+	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/lf-edge/edge-home-orchestration-go/internal/common/logmgr"
 	"github.com/lf-edge/edge-home-orchestration-go/internal/common/networkhelper"
 	"github.com/lf-edge/edge-home-orchestration-go/internal/controller/securemgr/verifier"
+	"github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr/executor"
+	"github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr/servicelog"
 	"github.com/lf-edge/edge-home-orchestration-go/internal/db/bolt/common"
 	"github.com/lf-edge/edge-home-orchestration-go/internal/orchestrationapi"
 	"github.com/lf-edge/edge-home-orchestration-go/internal/restinterface"
@@ -48,6 +53,8 @@ const (
 	topic             = "topic"
 	appID             = "appID"
 	host              = "host"
+	serviceID         = "serviceid"
+	deviceID          = "deviceid"
 )
 
 // Handler struct
@@ -79,6 +86,72 @@ func init() {
 			Pattern:     "/api/v1/orchestration/services",
 			HandlerFunc: handler.APIV1RequestServicePost,
 		},
+		restinterface.Route{
+			Name:        "APIV1RequestServicesGet",
+			Method:      strings.ToUpper("Get"),
+			Pattern:     "/api/v1/orchestration/services",
+			HandlerFunc: handler.APIV1RequestServicesGet,
+		},
+		restinterface.Route{
+			Name:        "APIV1RequestServiceServiceIDGet",
+			Method:      strings.ToUpper("Get"),
+			Pattern:     "/api/v1/orchestration/services/{" + serviceID + "}",
+			HandlerFunc: handler.APIV1RequestServiceServiceIDGet,
+		},
+		restinterface.Route{
+			Name:        "APIV1RequestServiceServiceIDEventsGet",
+			Method:      strings.ToUpper("Get"),
+			Pattern:     "/api/v1/orchestration/services/{" + serviceID + "}/events",
+			HandlerFunc: handler.APIV1RequestServiceServiceIDEventsGet,
+		},
+		restinterface.Route{
+			Name:        "APIV1RequestServiceServiceIDLogsGet",
+			Method:      strings.ToUpper("Get"),
+			Pattern:     "/api/v1/orchestration/services/{" + serviceID + "}/logs",
+			HandlerFunc: handler.APIV1RequestServiceServiceIDLogsGet,
+		},
+		restinterface.Route{
+			Name:        "APIV1RequestServiceServiceIDDelete",
+			Method:      strings.ToUpper("Delete"),
+			Pattern:     "/api/v1/orchestration/services/{" + serviceID + "}",
+			HandlerFunc: handler.APIV1RequestServiceServiceIDDelete,
+		},
+		restinterface.Route{
+			Name:        "APIV1RequestLabelsGet",
+			Method:      strings.ToUpper("Get"),
+			Pattern:     "/api/v1/orchestration/labels",
+			HandlerFunc: handler.APIV1RequestLabelsGet,
+		},
+		restinterface.Route{
+			Name:        "APIV1RequestDevicesGet",
+			Method:      strings.ToUpper("Get"),
+			Pattern:     "/api/v1/orchestration/devices",
+			HandlerFunc: handler.APIV1RequestDevicesGet,
+		},
+		restinterface.Route{
+			Name:        "APIV1RequestDevicesEventsGet",
+			Method:      strings.ToUpper("Get"),
+			Pattern:     "/api/v1/orchestration/devices/events",
+			HandlerFunc: handler.APIV1RequestDevicesEventsGet,
+		},
+		restinterface.Route{
+			Name:        "APIV1RequestDevicesDeviceIDGet",
+			Method:      strings.ToUpper("Get"),
+			Pattern:     "/api/v1/orchestration/devices/{" + deviceID + "}",
+			HandlerFunc: handler.APIV1RequestDevicesDeviceIDGet,
+		},
+		restinterface.Route{
+			Name:        "APIV1RequestExecutionsGet",
+			Method:      strings.ToUpper("Get"),
+			Pattern:     "/api/v1/orchestration/executions",
+			HandlerFunc: handler.APIV1RequestExecutionsGet,
+		},
+		restinterface.Route{
+			Name:        "APIV1RequestClientsGet",
+			Method:      strings.ToUpper("Get"),
+			Pattern:     "/api/v1/orchestration/clients",
+			HandlerFunc: handler.APIV1RequestClientsGet,
+		},
 		restinterface.Route{
 			Name:        "APIV1RequestSecuremgrPost",
 			Method:      strings.ToUpper("Post"),
@@ -131,34 +204,22 @@ func (h *Handler) APIV1RequestServicePost(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	reqAddr := strings.Split(r.RemoteAddr, ":")
-	var addr string
-	var portStr string
-	if strings.Contains(r.RemoteAddr, "::1") {
-		addr = "localhost"
-		portStr = reqAddr[len(reqAddr)-1]
-	} else {
-		addr = reqAddr[0]
-		portStr = reqAddr[1]
-	}
-
-	ips, err := h.netHelper.GetIPs()
-	if err != nil {
-		h.helper.Response(w, nil, http.StatusServiceUnavailable)
-		return
-	} else if addr != "localhost" && addr != "127.0.0.1" && !common.HasElem(ips, addr) {
-		h.helper.Response(w, nil, http.StatusNotAcceptable)
+	if !h.checkLocalRequester(w, r) {
 		return
 	}
+	_, portStr, _ := net.SplitHostPort(r.RemoteAddr)
 
 	var (
 		responseMsg  string
 		responseName string
+		responseID   uint64
 		resp         orchestrationapi.ResponseService
 
 		name               string
 		executeEnvs        []interface{}
 		responseTargetInfo map[string]interface{}
+		responseAttempts   []map[string]interface{}
+		responseReplicas   []map[string]interface{}
 	)
 
 	//request
@@ -247,20 +308,77 @@ func (h *Handler) APIV1RequestServicePost(w http.ResponseWriter, r *http.Request
 		}
 	}
 
+	if constraints, ok := appCommand["Constraints"].(map[string]interface{}); ok {
+		if serviceInfos.Constraints, ok = parseConstraints(constraints); !ok {
+			responseMsg = orchestrationapi.InvalidParameter
+			responseName = name
+			goto SEND_RESP
+		}
+	}
+
+	if retry, ok := appCommand["Retry"].(map[string]interface{}); ok {
+		if serviceInfos.Retry, ok = parseRetryPolicy(retry); !ok {
+			responseMsg = orchestrationapi.InvalidParameter
+			responseName = name
+			goto SEND_RESP
+		}
+	}
+
+	if limits, ok := appCommand["Limits"].(map[string]interface{}); ok {
+		if serviceInfos.Limits, ok = parseLimits(limits); !ok {
+			responseMsg = orchestrationapi.InvalidParameter
+			responseName = name
+			goto SEND_RESP
+		}
+	}
+
+	if replicas, exists := appCommand["Replicas"]; exists {
+		if serviceInfos.Replicas, ok = parseReplicas(replicas); !ok {
+			responseMsg = orchestrationapi.InvalidParameter
+			responseName = name
+			goto SEND_RESP
+		}
+	}
+
 	resp = h.api.RequestService(serviceInfos)
 
 	responseMsg = resp.Message
 	responseName = resp.ServiceName
+	responseID = resp.ServiceID
 
 	responseTargetInfo = make(map[string]interface{})
 	responseTargetInfo["ExecutionType"] = resp.RemoteTargetInfo.ExecutionType
 	responseTargetInfo["Target"] = resp.RemoteTargetInfo.Target
 
+	for _, attempt := range resp.Attempts {
+		responseAttempts = append(responseAttempts, map[string]interface{}{
+			"Target":        attempt.Target,
+			"ExecutionType": attempt.ExecutionType,
+			"ServiceID":     attempt.ServiceID,
+			"Message":       attempt.Message,
+		})
+	}
+
+	for _, replica := range resp.Replicas {
+		responseReplicas = append(responseReplicas, map[string]interface{}{
+			"ServiceID":     replica.ServiceID,
+			"ExecutionType": replica.RemoteTargetInfo.ExecutionType,
+			"Target":        replica.RemoteTargetInfo.Target,
+		})
+	}
+
 SEND_RESP:
 	respJSONMsg := make(map[string]interface{})
 	respJSONMsg["Message"] = responseMsg
 	respJSONMsg["ServiceName"] = responseName
+	respJSONMsg["ServiceID"] = responseID
 	respJSONMsg["RemoteTargetInfo"] = responseTargetInfo
+	if len(responseAttempts) > 0 {
+		respJSONMsg["Attempts"] = responseAttempts
+	}
+	if len(responseReplicas) > 0 {
+		respJSONMsg["Replicas"] = responseReplicas
+	}
 
 	respEncryptBytes, err := h.Key.EncryptJSONToByte(respJSONMsg)
 	if err != nil {
@@ -272,9 +390,9 @@ SEND_RESP:
 	h.helper.Response(w, respEncryptBytes, http.StatusOK)
 }
 
-// APIV1RequestSecuremgrPost handles securemgr request from securemgr configure application
-func (h *Handler) APIV1RequestSecuremgrPost(w http.ResponseWriter, r *http.Request) {
-	log.Info(logPrefix, "APIV1RequestSecuremgrPost")
+// APIV1RequestServicesGet handles the request listing running services from service application
+func (h *Handler) APIV1RequestServicesGet(w http.ResponseWriter, r *http.Request) {
+	log.Info(logPrefix, "APIV1RequestServicesGet")
 	if !h.isSetAPI {
 		log.Error(logPrefix, doesNotSetAPI)
 		h.helper.Response(w, nil, http.StatusServiceUnavailable)
@@ -285,20 +403,524 @@ func (h *Handler) APIV1RequestSecuremgrPost(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	reqAddr := strings.Split(r.RemoteAddr, ":")
-	var addr string
-	if strings.Contains(r.RemoteAddr, "::1") {
-		addr = "localhost"
+	if !h.checkLocalRequester(w, r) {
+		return
+	}
+
+	resp := h.api.ListServices()
+
+	respJSONMsg := make(map[string]interface{})
+	respJSONMsg["Message"] = resp.Message
+	respJSONMsg["Services"] = resp.Services
+
+	respEncryptBytes, err := h.Key.EncryptJSONToByte(respJSONMsg)
+	if err != nil {
+		log.Error(logPrefix, cannotEncryption)
+		h.helper.Response(w, nil, http.StatusServiceUnavailable)
+		return
+	}
+
+	h.helper.Response(w, respEncryptBytes, http.StatusOK)
+}
+
+// APIV1RequestLabelsGet handles the request getting the labels of the devices, the query parameters select the devices with the labels
+func (h *Handler) APIV1RequestLabelsGet(w http.ResponseWriter, r *http.Request) {
+	log.Info(logPrefix, "APIV1RequestLabelsGet")
+	if !h.isSetAPI {
+		log.Error(logPrefix, doesNotSetAPI)
+		h.helper.Response(w, nil, http.StatusServiceUnavailable)
+		return
+	} else if !h.IsSetKey {
+		log.Error(logPrefix, doesNotSetKey)
+		h.helper.Response(w, nil, http.StatusServiceUnavailable)
+		return
+	}
+
+	if !h.checkLocalRequester(w, r) {
+		return
+	}
+
+	selector := make(map[string]string)
+	for key, values := range r.URL.Query() {
+		selector[key] = values[0]
+	}
+
+	resp := h.api.GetDeviceLabels(selector)
+
+	respJSONMsg := make(map[string]interface{})
+	respJSONMsg["Message"] = resp.Message
+	respJSONMsg["Devices"] = resp.Devices
+
+	respEncryptBytes, err := h.Key.EncryptJSONToByte(respJSONMsg)
+	if err != nil {
+		log.Error(logPrefix, cannotEncryption)
+		h.helper.Response(w, nil, http.StatusServiceUnavailable)
+		return
+	}
+
+	h.helper.Response(w, respEncryptBytes, http.StatusOK)
+}
+
+// APIV1RequestDevicesGet handles the request getting the devices known by the orchestration
+func (h *Handler) APIV1RequestDevicesGet(w http.ResponseWriter, r *http.Request) {
+	log.Info(logPrefix, "APIV1RequestDevicesGet")
+	if !h.isSetAPI {
+		log.Error(logPrefix, doesNotSetAPI)
+		h.helper.Response(w, nil, http.StatusServiceUnavailable)
+		return
+	} else if !h.IsSetKey {
+		log.Error(logPrefix, doesNotSetKey)
+		h.helper.Response(w, nil, http.StatusServiceUnavailable)
+		return
+	}
+
+	if !h.checkLocalRequester(w, r) {
+		return
+	}
+
+	resp := h.api.GetDevices()
+
+	respJSONMsg := make(map[string]interface{})
+	respJSONMsg["Message"] = resp.Message
+	respJSONMsg["Devices"] = resp.Devices
+
+	respEncryptBytes, err := h.Key.EncryptJSONToByte(respJSONMsg)
+	if err != nil {
+		log.Error(logPrefix, cannotEncryption)
+		h.helper.Response(w, nil, http.StatusServiceUnavailable)
+		return
+	}
+
+	h.helper.Response(w, respEncryptBytes, http.StatusOK)
+}
+
+// APIV1RequestDevicesDeviceIDGet handles the request getting a device known by the orchestration
+func (h *Handler) APIV1RequestDevicesDeviceIDGet(w http.ResponseWriter, r *http.Request) {
+	log.Info(logPrefix, "APIV1RequestDevicesDeviceIDGet")
+	if !h.isSetAPI {
+		log.Error(logPrefix, doesNotSetAPI)
+		h.helper.Response(w, nil, http.StatusServiceUnavailable)
+		return
+	} else if !h.IsSetKey {
+		log.Error(logPrefix, doesNotSetKey)
+		h.helper.Response(w, nil, http.StatusServiceUnavailable)
+		return
+	}
+
+	if !h.checkLocalRequester(w, r) {
+		return
+	}
+
+	resp := h.api.GetDevice(mux.Vars(r)[deviceID])
+
+	respJSONMsg := make(map[string]interface{})
+	respJSONMsg["Message"] = resp.Message
+	respJSONMsg["Devices"] = resp.Devices
+
+	respEncryptBytes, err := h.Key.EncryptJSONToByte(respJSONMsg)
+	if err != nil {
+		log.Error(logPrefix, cannotEncryption)
+		h.helper.Response(w, nil, http.StatusServiceUnavailable)
+		return
+	}
+
+	h.helper.Response(w, respEncryptBytes, http.StatusOK)
+}
+
+// APIV1RequestExecutionsGet handles the request getting the history of the service executions
+func (h *Handler) APIV1RequestExecutionsGet(w http.ResponseWriter, r *http.Request) {
+	log.Info(logPrefix, "APIV1RequestExecutionsGet")
+	if !h.isSetAPI {
+		log.Error(logPrefix, doesNotSetAPI)
+		h.helper.Response(w, nil, http.StatusServiceUnavailable)
+		return
+	} else if !h.IsSetKey {
+		log.Error(logPrefix, doesNotSetKey)
+		h.helper.Response(w, nil, http.StatusServiceUnavailable)
+		return
+	}
+
+	if !h.checkLocalRequester(w, r) {
+		return
+	}
+
+	query := r.URL.Query()
+	filter := orchestrationapi.ExecutionFilter{
+		ServiceName: query.Get("service"),
+		Target:      query.Get("device"),
+	}
+	var err error
+	if value := query.Get("from"); len(value) > 0 {
+		filter.From, err = time.Parse(time.RFC3339, value)
+	}
+	if value := query.Get("to"); err == nil && len(value) > 0 {
+		filter.To, err = time.Parse(time.RFC3339, value)
+	}
+
+	respJSONMsg := make(map[string]interface{})
+	if err != nil {
+		respJSONMsg["Message"] = orchestrationapi.InvalidParameter
 	} else {
-		addr = reqAddr[0]
+		resp := h.api.GetExecutions(filter)
+		respJSONMsg["Message"] = resp.Message
+		respJSONMsg["Executions"] = resp.Executions
 	}
 
-	ips, err := h.netHelper.GetIPs()
+	respEncryptBytes, err := h.Key.EncryptJSONToByte(respJSONMsg)
 	if err != nil {
+		log.Error(logPrefix, cannotEncryption)
 		h.helper.Response(w, nil, http.StatusServiceUnavailable)
 		return
-	} else if addr != "localhost" && addr != "127.0.0.1" && !common.HasElem(ips, addr) {
-		h.helper.Response(w, nil, http.StatusNotAcceptable)
+	}
+
+	h.helper.Response(w, respEncryptBytes, http.StatusOK)
+}
+
+// APIV1RequestClientsGet handles the request getting the counters of the requesters waiting for the service status
+func (h *Handler) APIV1RequestClientsGet(w http.ResponseWriter, r *http.Request) {
+	log.Info(logPrefix, "APIV1RequestClientsGet")
+	if !h.isSetAPI {
+		log.Error(logPrefix, doesNotSetAPI)
+		h.helper.Response(w, nil, http.StatusServiceUnavailable)
+		return
+	} else if !h.IsSetKey {
+		log.Error(logPrefix, doesNotSetKey)
+		h.helper.Response(w, nil, http.StatusServiceUnavailable)
+		return
+	}
+
+	if !h.checkLocalRequester(w, r) {
+		return
+	}
+
+	resp := h.api.GetClientStats()
+	respJSONMsg := make(map[string]interface{})
+	respJSONMsg["Message"] = resp.Message
+	respJSONMsg["Clients"] = resp.Clients
+
+	respEncryptBytes, err := h.Key.EncryptJSONToByte(respJSONMsg)
+	if err != nil {
+		log.Error(logPrefix, cannotEncryption)
+		h.helper.Response(w, nil, http.StatusServiceUnavailable)
+		return
+	}
+
+	h.helper.Response(w, respEncryptBytes, http.StatusOK)
+}
+
+// APIV1RequestDevicesEventsGet streams the transitions of the device states to service application as Server-Sent Events
+func (h *Handler) APIV1RequestDevicesEventsGet(w http.ResponseWriter, r *http.Request) {
+	log.Info(logPrefix, "APIV1RequestDevicesEventsGet")
+	if !h.isSetAPI {
+		log.Error(logPrefix, doesNotSetAPI)
+		h.helper.Response(w, nil, http.StatusServiceUnavailable)
+		return
+	} else if !h.IsSetKey {
+		log.Error(logPrefix, doesNotSetKey)
+		h.helper.Response(w, nil, http.StatusServiceUnavailable)
+		return
+	}
+
+	if !h.checkLocalRequester(w, r) {
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		log.Error(logPrefix, "streaming is not supported")
+		h.helper.Response(w, nil, http.StatusInternalServerError)
+		return
+	}
+
+	events, cancel, message := h.api.WatchDevices()
+	if message != orchestrationapi.ErrorNone {
+		respEncryptBytes, err := h.Key.EncryptJSONToByte(map[string]interface{}{"Message": message})
+		if err != nil {
+			log.Error(logPrefix, cannotEncryption)
+			h.helper.Response(w, nil, http.StatusServiceUnavailable)
+			return
+		}
+		h.helper.Response(w, respEncryptBytes, http.StatusOK)
+		return
+	}
+	defer cancel()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		select {
+		case device, ok := <-events:
+			if !ok {
+				return
+			}
+
+			respEncryptBytes, err := h.Key.EncryptJSONToByte(device)
+			if err != nil {
+				log.Error(logPrefix, cannotEncryption)
+				return
+			}
+			fmt.Fprintf(w, "event: state\ndata: %s\n\n", respEncryptBytes)
+			flusher.Flush()
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+// APIV1RequestServiceServiceIDGet handles the request getting a running service from service application
+func (h *Handler) APIV1RequestServiceServiceIDGet(w http.ResponseWriter, r *http.Request) {
+	log.Info(logPrefix, "APIV1RequestServiceServiceIDGet")
+	if !h.isSetAPI {
+		log.Error(logPrefix, doesNotSetAPI)
+		h.helper.Response(w, nil, http.StatusServiceUnavailable)
+		return
+	} else if !h.IsSetKey {
+		log.Error(logPrefix, doesNotSetKey)
+		h.helper.Response(w, nil, http.StatusServiceUnavailable)
+		return
+	}
+
+	if !h.checkLocalRequester(w, r) {
+		return
+	}
+
+	respJSONMsg := make(map[string]interface{})
+
+	id, err := strconv.ParseUint(mux.Vars(r)[serviceID], 10, 64)
+	if err != nil {
+		respJSONMsg["Message"] = orchestrationapi.InvalidParameter
+	} else {
+		resp := h.api.GetService(id)
+		respJSONMsg["Message"] = resp.Message
+		respJSONMsg["Services"] = resp.Services
+	}
+
+	respEncryptBytes, err := h.Key.EncryptJSONToByte(respJSONMsg)
+	if err != nil {
+		log.Error(logPrefix, cannotEncryption)
+		h.helper.Response(w, nil, http.StatusServiceUnavailable)
+		return
+	}
+
+	h.helper.Response(w, respEncryptBytes, http.StatusOK)
+}
+
+// APIV1RequestServiceServiceIDEventsGet streams the status changes of a service to service application as Server-Sent Events
+func (h *Handler) APIV1RequestServiceServiceIDEventsGet(w http.ResponseWriter, r *http.Request) {
+	log.Info(logPrefix, "APIV1RequestServiceServiceIDEventsGet")
+	if !h.isSetAPI {
+		log.Error(logPrefix, doesNotSetAPI)
+		h.helper.Response(w, nil, http.StatusServiceUnavailable)
+		return
+	} else if !h.IsSetKey {
+		log.Error(logPrefix, doesNotSetKey)
+		h.helper.Response(w, nil, http.StatusServiceUnavailable)
+		return
+	}
+
+	if !h.checkLocalRequester(w, r) {
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		log.Error(logPrefix, "streaming is not supported")
+		h.helper.Response(w, nil, http.StatusInternalServerError)
+		return
+	}
+
+	message := orchestrationapi.InvalidParameter
+	var events <-chan map[string]interface{}
+	var cancel func()
+
+	id, err := strconv.ParseUint(mux.Vars(r)[serviceID], 10, 64)
+	if err == nil {
+		events, cancel, message = h.api.WatchService(id)
+	}
+	if message != orchestrationapi.ErrorNone {
+		respEncryptBytes, err := h.Key.EncryptJSONToByte(map[string]interface{}{"Message": message})
+		if err != nil {
+			log.Error(logPrefix, cannotEncryption)
+			h.helper.Response(w, nil, http.StatusServiceUnavailable)
+			return
+		}
+		h.helper.Response(w, respEncryptBytes, http.StatusOK)
+		return
+	}
+	defer cancel()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		select {
+		case service, ok := <-events:
+			if !ok {
+				return
+			}
+
+			respEncryptBytes, err := h.Key.EncryptJSONToByte(service)
+			if err != nil {
+				log.Error(logPrefix, cannotEncryption)
+				return
+			}
+			fmt.Fprintf(w, "event: status\ndata: %s\n\n", respEncryptBytes)
+			flusher.Flush()
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+// APIV1RequestServiceServiceIDLogsGet handles the log request of a service from service application,
+// the log is streamed as Server-Sent Events when it is followed
+func (h *Handler) APIV1RequestServiceServiceIDLogsGet(w http.ResponseWriter, r *http.Request) {
+	log.Info(logPrefix, "APIV1RequestServiceServiceIDLogsGet")
+	if !h.isSetAPI {
+		log.Error(logPrefix, doesNotSetAPI)
+		h.helper.Response(w, nil, http.StatusServiceUnavailable)
+		return
+	} else if !h.IsSetKey {
+		log.Error(logPrefix, doesNotSetKey)
+		h.helper.Response(w, nil, http.StatusServiceUnavailable)
+		return
+	}
+
+	if !h.checkLocalRequester(w, r) {
+		return
+	}
+
+	message := orchestrationapi.InvalidParameter
+	var logs <-chan servicelog.Entry
+	var cancel func()
+
+	id, err := strconv.ParseUint(mux.Vars(r)[serviceID], 10, 64)
+	tail, follow := 0, false
+	query := r.URL.Query()
+	if value := query.Get("tail"); err == nil && len(value) > 0 {
+		tail, err = strconv.Atoi(value)
+	}
+	if value := query.Get("follow"); err == nil && len(value) > 0 {
+		follow, err = strconv.ParseBool(value)
+	}
+	if err == nil {
+		logs, cancel, message = h.api.GetServiceLogs(id, tail, follow)
+	}
+
+	if message != orchestrationapi.ErrorNone || !follow {
+		respJSONMsg := map[string]interface{}{"Message": message, "ServiceID": id}
+		if message == orchestrationapi.ErrorNone {
+			entries := make([]servicelog.Entry, 0)
+			for entry := range logs {
+				entries = append(entries, entry)
+			}
+			cancel()
+			respJSONMsg["Logs"] = entries
+		}
+
+		respEncryptBytes, err := h.Key.EncryptJSONToByte(respJSONMsg)
+		if err != nil {
+			log.Error(logPrefix, cannotEncryption)
+			h.helper.Response(w, nil, http.StatusServiceUnavailable)
+			return
+		}
+		h.helper.Response(w, respEncryptBytes, http.StatusOK)
+		return
+	}
+	defer cancel()
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		log.Error(logPrefix, "streaming is not supported")
+		h.helper.Response(w, nil, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		select {
+		case entry, ok := <-logs:
+			if !ok {
+				return
+			}
+
+			respEncryptBytes, err := h.Key.EncryptJSONToByte(map[string]interface{}{
+				"Time": entry.Time, "Stream": entry.Stream, "Message": entry.Message})
+			if err != nil {
+				log.Error(logPrefix, cannotEncryption)
+				return
+			}
+			fmt.Fprintf(w, "event: log\ndata: %s\n\n", respEncryptBytes)
+			flusher.Flush()
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+// APIV1RequestServiceServiceIDDelete handles the request stopping a running service from service application
+func (h *Handler) APIV1RequestServiceServiceIDDelete(w http.ResponseWriter, r *http.Request) {
+	log.Info(logPrefix, "APIV1RequestServiceServiceIDDelete")
+	if !h.isSetAPI {
+		log.Error(logPrefix, doesNotSetAPI)
+		h.helper.Response(w, nil, http.StatusServiceUnavailable)
+		return
+	} else if !h.IsSetKey {
+		log.Error(logPrefix, doesNotSetKey)
+		h.helper.Response(w, nil, http.StatusServiceUnavailable)
+		return
+	}
+
+	if !h.checkLocalRequester(w, r) {
+		return
+	}
+
+	respJSONMsg := make(map[string]interface{})
+
+	id, err := strconv.ParseUint(mux.Vars(r)[serviceID], 10, 64)
+	if err != nil {
+		respJSONMsg["Message"] = orchestrationapi.InvalidParameter
+	} else {
+		respJSONMsg["Message"] = h.api.StopService(id)
+		respJSONMsg["ServiceID"] = id
+	}
+
+	respEncryptBytes, err := h.Key.EncryptJSONToByte(respJSONMsg)
+	if err != nil {
+		log.Error(logPrefix, cannotEncryption)
+		h.helper.Response(w, nil, http.StatusServiceUnavailable)
+		return
+	}
+
+	h.helper.Response(w, respEncryptBytes, http.StatusOK)
+}
+
+// APIV1RequestSecuremgrPost handles securemgr request from securemgr configure application
+func (h *Handler) APIV1RequestSecuremgrPost(w http.ResponseWriter, r *http.Request) {
+	log.Info(logPrefix, "APIV1RequestSecuremgrPost")
+	if !h.isSetAPI {
+		log.Error(logPrefix, doesNotSetAPI)
+		h.helper.Response(w, nil, http.StatusServiceUnavailable)
+		return
+	} else if !h.IsSetKey {
+		log.Error(logPrefix, doesNotSetKey)
+		h.helper.Response(w, nil, http.StatusServiceUnavailable)
+		return
+	}
+
+	if !h.checkLocalRequester(w, r) {
 		return
 	}
 
@@ -402,19 +1024,7 @@ func (h *Handler) APIV1RequestCloudSyncmgrPublish(w http.ResponseWriter, r *http
 		return
 	}
 
-	reqAddr := strings.Split(r.RemoteAddr, ":")
-	var addr string
-	if strings.Contains(r.RemoteAddr, "::1") {
-		addr = "localhost"
-	} else {
-		addr = reqAddr[0]
-	}
-	ips, err := h.netHelper.GetIPs()
-	if err != nil {
-		h.helper.Response(w, nil, http.StatusServiceUnavailable)
-		return
-	} else if addr != "localhost" && addr != "127.0.0.1" && !common.HasElem(ips, addr) {
-		h.helper.Response(w, nil, http.StatusNotAcceptable)
+	if !h.checkLocalRequester(w, r) {
 		return
 	}
 
@@ -489,19 +1099,7 @@ func (h *Handler) APIV1RequestCloudSyncmgrSubscribe(w http.ResponseWriter, r *ht
 		return
 	}
 
-	reqAddr := strings.Split(r.RemoteAddr, ":")
-	var addr string
-	if strings.Contains(r.RemoteAddr, "::1") {
-		addr = "localhost"
-	} else {
-		addr = reqAddr[0]
-	}
-	ips, err := h.netHelper.GetIPs()
-	if err != nil {
-		h.helper.Response(w, nil, http.StatusServiceUnavailable)
-		return
-	} else if addr != "localhost" && addr != "127.0.0.1" && !common.HasElem(ips, addr) {
-		h.helper.Response(w, nil, http.StatusNotAcceptable)
+	if !h.checkLocalRequester(w, r) {
 		return
 	}
 
@@ -552,6 +1150,200 @@ SEND_RESP:
 	h.helper.Response(w, respEncryptBytes, http.StatusOK)
 }
 
+// parseConstraints converts the Constraints of the request, it returns false when a value has wrong type
+func parseConstraints(items map[string]interface{}) (constraints orchestrationapi.Constraints, ok bool) {
+	for key, value := range items {
+		switch key {
+		case "MinCPUCount":
+			count, isNumber := value.(float64)
+			if !isNumber {
+				return constraints, false
+			}
+			constraints.MinCPUCount = int(count)
+		case "MinFreeMemory":
+			if constraints.MinFreeMemory, ok = value.(float64); !ok {
+				return constraints, false
+			}
+		case "MaxRTT":
+			if constraints.MaxRTT, ok = value.(float64); !ok {
+				return constraints, false
+			}
+		case "Platform":
+			if constraints.Platform, ok = value.(string); !ok {
+				return constraints, false
+			}
+		case "Arch":
+			if constraints.Arch, ok = value.(string); !ok {
+				return constraints, false
+			}
+		case "Labels":
+			labels, isMap := value.(map[string]interface{})
+			if !isMap {
+				return constraints, false
+			}
+			constraints.Labels = make(map[string]string)
+			for name, label := range labels {
+				if constraints.Labels[name], ok = label.(string); !ok {
+					return constraints, false
+				}
+			}
+		case "ExcludedDevices":
+			devices, isList := value.([]interface{})
+			if !isList {
+				return constraints, false
+			}
+			for _, device := range devices {
+				id, isString := device.(string)
+				if !isString {
+					return constraints, false
+				}
+				constraints.ExcludedDevices = append(constraints.ExcludedDevices, id)
+			}
+		}
+	}
+	return constraints, true
+}
+
+// parseRetryPolicy converts the Retry of the request with the durations in milliseconds,
+// it returns false when a value is not a non-negative number
+func parseRetryPolicy(items map[string]interface{}) (retry orchestrationapi.RetryPolicy, ok bool) {
+	for key, value := range items {
+		number, isNumber := value.(float64)
+		if !isNumber || number < 0 {
+			return retry, false
+		}
+		switch key {
+		case "MaxAttempts":
+			retry.MaxAttempts = int(number)
+		case "Backoff":
+			retry.Backoff = time.Duration(number) * time.Millisecond
+		case "FailureTimeout":
+			retry.FailureTimeout = time.Duration(number) * time.Millisecond
+		}
+	}
+	return retry, true
+}
+
+// parseLimits converts the Limits of the request with the memory in MiB and the timeout in milliseconds,
+// it returns false when a value has wrong type
+func parseLimits(items map[string]interface{}) (limits executor.ResourceLimits, ok bool) {
+	for key, value := range items {
+		switch key {
+		case "CPUShares":
+			shares, isNumber := value.(float64)
+			if !isNumber || shares < 0 {
+				return limits, false
+			}
+			limits.CPUShares = int64(shares)
+		case "Memory":
+			memory, isNumber := value.(float64)
+			if !isNumber || memory < 0 {
+				return limits, false
+			}
+			limits.Memory = int64(memory * 1024 * 1024)
+		case "Timeout":
+			timeout, isNumber := value.(float64)
+			if !isNumber || timeout < 0 {
+				return limits, false
+			}
+			limits.Timeout = time.Duration(timeout) * time.Millisecond
+		case "HealthCheck":
+			check, isMap := value.(map[string]interface{})
+			if !isMap {
+				return limits, false
+			}
+			if limits.HealthCheck, ok = parseHealthCheck(check); !ok {
+				return limits, false
+			}
+		}
+	}
+	return limits, true
+}
+
+// parseHealthCheck converts the HealthCheck of the request with the durations in milliseconds,
+// it returns false when the command is missing or a value has wrong type
+func parseHealthCheck(items map[string]interface{}) (*executor.HealthCheck, bool) {
+	check := &executor.HealthCheck{}
+	for key, value := range items {
+		if key == "Command" {
+			command, isList := value.([]interface{})
+			if !isList {
+				return nil, false
+			}
+			for _, arg := range command {
+				str, isString := arg.(string)
+				if !isString {
+					return nil, false
+				}
+				check.Command = append(check.Command, str)
+			}
+			continue
+		}
+
+		number, isNumber := value.(float64)
+		if !isNumber || number < 0 {
+			return nil, false
+		}
+		switch key {
+		case "Interval":
+			check.Interval = time.Duration(number) * time.Millisecond
+		case "Timeout":
+			check.Timeout = time.Duration(number) * time.Millisecond
+		case "StartPeriod":
+			check.StartPeriod = time.Duration(number) * time.Millisecond
+		case "Retries":
+			check.Retries = int(number)
+		}
+	}
+	if len(check.Command) == 0 {
+		return nil, false
+	}
+	return check, true
+}
+
+// parseReplicas converts the Replicas of the request, it is a positive number or "broadcast"
+func parseReplicas(value interface{}) (int, bool) {
+	switch replicas := value.(type) {
+	case float64:
+		if replicas < 1 || replicas != float64(int(replicas)) {
+			return 0, false
+		}
+		return int(replicas), true
+	case string:
+		if replicas == "broadcast" {
+			return orchestrationapi.BroadcastReplicas, true
+		}
+	}
+	return 0, false
+}
+
+// checkLocalRequester responds with an error if the request does not come from the local device
+func (h *Handler) checkLocalRequester(w http.ResponseWriter, r *http.Request) bool {
+	addr, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		addr = r.RemoteAddr
+	}
+
+	ips, err := h.netHelper.GetIPs()
+	if err != nil {
+		h.helper.Response(w, nil, http.StatusServiceUnavailable)
+		return false
+	} else if !isLoopback(addr) && !common.HasElem(ips, addr) {
+		h.helper.Response(w, nil, http.StatusNotAcceptable)
+		return false
+	}
+
+	return true
+}
+
+func isLoopback(addr string) bool {
+	if addr == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(addr)
+	return ip != nil && ip.IsLoopback()
+}
+
 func (h *Handler) setHelper(helper resthelper.RestHelper) {
 	h.helper = helper
 }
@@ -569,19 +1361,7 @@ func (h *Handler) APIV1RequestCloudSyncmgrGetSubscribedData(w http.ResponseWrite
 		return
 	}
 
-	reqAddr := strings.Split(r.RemoteAddr, ":")
-	var addr string
-	if strings.Contains(r.RemoteAddr, "::1") {
-		addr = "localhost"
-	} else {
-		addr = reqAddr[0]
-	}
-	ips, err := h.netHelper.GetIPs()
-	if err != nil {
-		h.helper.Response(w, nil, http.StatusServiceUnavailable)
-		return
-	} else if addr != "localhost" && addr != "127.0.0.1" && !common.HasElem(ips, addr) {
-		h.helper.Response(w, nil, http.StatusNotAcceptable)
+	if !h.checkLocalRequester(w, r) {
 		return
 	}
 
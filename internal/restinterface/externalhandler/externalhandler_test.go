@@ -21,18 +21,25 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	networkhelper "github.com/lf-edge/edge-home-orchestration-go/internal/common/networkhelper/mocks"
 	"github.com/lf-edge/edge-home-orchestration-go/internal/common/requestervalidator"
 	"github.com/lf-edge/edge-home-orchestration-go/internal/controller/securemgr/verifier"
+	"github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr/executor"
+	"github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr/servicelog"
+	execDB "github.com/lf-edge/edge-home-orchestration-go/internal/db/bolt/execution"
+	dbhelper "github.com/lf-edge/edge-home-orchestration-go/internal/db/helper"
 	orchestrationapi "github.com/lf-edge/edge-home-orchestration-go/internal/orchestrationapi"
 	orchemock "github.com/lf-edge/edge-home-orchestration-go/internal/orchestrationapi/mocks"
 	ciphermock "github.com/lf-edge/edge-home-orchestration-go/internal/restinterface/cipher/mocks"
 	helpermock "github.com/lf-edge/edge-home-orchestration-go/internal/restinterface/resthelper/mocks"
 
 	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
 )
 
 func TestGetHandler(t *testing.T) {
@@ -264,6 +271,28 @@ func TestAPIV1RequestServicePost(t *testing.T) {
 					mockHelper.EXPECT().Response(gomock.Any(), gomock.Any(), gomock.Eq(http.StatusOK)),
 				)
 
+				handler.APIV1RequestServicePost(w, r)
+			})
+			t.Run("Constraints", func(t *testing.T) {
+				handler.SetCipher(mockCipher)
+				handler.SetOrchestrationAPI(mockOrchestration)
+				handler.setHelper(mockHelper)
+				handler.netHelper = mockNetHelper
+
+				_, appCommand := getReqeustArgs()
+				appCommand["Constraints"] = map[string]interface{}{"MinCPUCount": "4"}
+
+				gomock.InOrder(
+					mockNetHelper.EXPECT().GetIPs().Return([]string{addr}, nil),
+					mockCipher.EXPECT().DecryptByteToJSON(gomock.Any()).Return(appCommand, nil),
+					mockCipher.EXPECT().EncryptJSONToByte(gomock.Any()).Do(func(resp map[string]interface{}) {
+						if resp["Message"] != orchestrationapi.InvalidParameter {
+							t.Error("unexpected response")
+						}
+					}).Return(nil, nil),
+					mockHelper.EXPECT().Response(gomock.Any(), gomock.Any(), gomock.Eq(http.StatusOK)),
+				)
+
 				handler.APIV1RequestServicePost(w, r)
 			})
 		})
@@ -288,6 +317,228 @@ func TestAPIV1RequestServicePost(t *testing.T) {
 
 		handler.APIV1RequestServicePost(w, r)
 	})
+	t.Run("SuccessWithConstraints", func(t *testing.T) {
+		handler.SetCipher(mockCipher)
+		handler.SetOrchestrationAPI(mockOrchestration)
+		handler.setHelper(mockHelper)
+		handler.netHelper = mockNetHelper
+
+		requestService, appCommand := getReqeustArgs()
+		requestService.Constraints = orchestrationapi.Constraints{
+			MinCPUCount:     4,
+			MinFreeMemory:   512,
+			MaxRTT:          0.5,
+			Platform:        "linux",
+			Arch:            "arm64",
+			Labels:          map[string]string{"gpu": "true"},
+			ExcludedDevices: []string{"edge-orchestration-1"},
+		}
+		appCommand["Constraints"] = map[string]interface{}{
+			"MinCPUCount":     4.0,
+			"MinFreeMemory":   512.0,
+			"MaxRTT":          0.5,
+			"Platform":        "linux",
+			"Arch":            "arm64",
+			"Labels":          map[string]interface{}{"gpu": "true"},
+			"ExcludedDevices": []interface{}{"edge-orchestration-1"},
+		}
+		respByte := []byte{'1'}
+
+		gomock.InOrder(
+			mockNetHelper.EXPECT().GetIPs().Return([]string{addr}, nil),
+			mockCipher.EXPECT().DecryptByteToJSON(gomock.Any()).Return(appCommand, nil),
+			mockOrchestration.EXPECT().RequestService(gomock.Eq(requestService)),
+			mockCipher.EXPECT().EncryptJSONToByte(gomock.Any()).Return(respByte, nil),
+			mockHelper.EXPECT().Response(gomock.Any(), gomock.Eq(respByte), gomock.Eq(http.StatusOK)),
+		)
+
+		handler.APIV1RequestServicePost(w, r)
+	})
+	t.Run("SuccessWithRetry", func(t *testing.T) {
+		handler.SetCipher(mockCipher)
+		handler.SetOrchestrationAPI(mockOrchestration)
+		handler.setHelper(mockHelper)
+		handler.netHelper = mockNetHelper
+
+		requestService, appCommand := getReqeustArgs()
+		requestService.Retry = orchestrationapi.RetryPolicy{
+			MaxAttempts:    3,
+			Backoff:        500 * time.Millisecond,
+			FailureTimeout: 2 * time.Second,
+		}
+		appCommand["Retry"] = map[string]interface{}{"MaxAttempts": 3.0, "Backoff": 500.0, "FailureTimeout": 2000.0}
+		resp := orchestrationapi.ResponseService{
+			Message:     orchestrationapi.ErrorNone,
+			ServiceName: "test",
+			ServiceID:   2,
+			Attempts: []orchestrationapi.ExecutionAttempt{
+				{Target: "1.1.1.1", ExecutionType: "native", ServiceID: 1, Message: orchestrationapi.InternalServerError},
+				{Target: "1.1.1.2", ExecutionType: "native", ServiceID: 2, Message: orchestrationapi.ErrorNone},
+			},
+		}
+		respByte := []byte{'1'}
+
+		gomock.InOrder(
+			mockNetHelper.EXPECT().GetIPs().Return([]string{addr}, nil),
+			mockCipher.EXPECT().DecryptByteToJSON(gomock.Any()).Return(appCommand, nil),
+			mockOrchestration.EXPECT().RequestService(gomock.Eq(requestService)).Return(resp),
+			mockCipher.EXPECT().EncryptJSONToByte(gomock.Any()).Do(func(resp map[string]interface{}) {
+				attempts, ok := resp["Attempts"].([]map[string]interface{})
+				if !ok || len(attempts) != 2 || attempts[0]["Message"] != orchestrationapi.InternalServerError {
+					t.Error("unexpected response")
+				}
+			}).Return(respByte, nil),
+			mockHelper.EXPECT().Response(gomock.Any(), gomock.Eq(respByte), gomock.Eq(http.StatusOK)),
+		)
+
+		handler.APIV1RequestServicePost(w, r)
+	})
+	t.Run("InvalidRetry", func(t *testing.T) {
+		handler.SetCipher(mockCipher)
+		handler.SetOrchestrationAPI(mockOrchestration)
+		handler.setHelper(mockHelper)
+		handler.netHelper = mockNetHelper
+
+		_, appCommand := getReqeustArgs()
+		appCommand["Retry"] = map[string]interface{}{"MaxAttempts": -1.0}
+
+		gomock.InOrder(
+			mockNetHelper.EXPECT().GetIPs().Return([]string{addr}, nil),
+			mockCipher.EXPECT().DecryptByteToJSON(gomock.Any()).Return(appCommand, nil),
+			mockCipher.EXPECT().EncryptJSONToByte(gomock.Any()).Do(func(resp map[string]interface{}) {
+				if resp["Message"] != orchestrationapi.InvalidParameter {
+					t.Error("unexpected response")
+				}
+			}).Return(nil, nil),
+			mockHelper.EXPECT().Response(gomock.Any(), gomock.Any(), gomock.Eq(http.StatusOK)),
+		)
+
+		handler.APIV1RequestServicePost(w, r)
+	})
+	t.Run("SuccessWithLimits", func(t *testing.T) {
+		handler.SetCipher(mockCipher)
+		handler.SetOrchestrationAPI(mockOrchestration)
+		handler.setHelper(mockHelper)
+		handler.netHelper = mockNetHelper
+
+		requestService, appCommand := getReqeustArgs()
+		requestService.Limits = executor.ResourceLimits{
+			CPUShares: 512,
+			Memory:    64 * 1024 * 1024,
+			HealthCheck: &executor.HealthCheck{
+				Command:  []string{"curl", "-f", "http://localhost"},
+				Interval: 30 * time.Second,
+				Retries:  3,
+			},
+			Timeout: time.Minute,
+		}
+		appCommand["Limits"] = map[string]interface{}{
+			"CPUShares": 512.0,
+			"Memory":    64.0,
+			"Timeout":   60000.0,
+			"HealthCheck": map[string]interface{}{
+				"Command":  []interface{}{"curl", "-f", "http://localhost"},
+				"Interval": 30000.0,
+				"Retries":  3.0,
+			},
+		}
+		resp := orchestrationapi.ResponseService{
+			Message:     orchestrationapi.ErrorNone,
+			ServiceName: "test",
+		}
+		respByte := []byte{'1'}
+
+		gomock.InOrder(
+			mockNetHelper.EXPECT().GetIPs().Return([]string{addr}, nil),
+			mockCipher.EXPECT().DecryptByteToJSON(gomock.Any()).Return(appCommand, nil),
+			mockOrchestration.EXPECT().RequestService(gomock.Eq(requestService)).Return(resp),
+			mockCipher.EXPECT().EncryptJSONToByte(gomock.Any()).Return(respByte, nil),
+			mockHelper.EXPECT().Response(gomock.Any(), gomock.Eq(respByte), gomock.Eq(http.StatusOK)),
+		)
+
+		handler.APIV1RequestServicePost(w, r)
+	})
+	t.Run("InvalidLimits", func(t *testing.T) {
+		handler.SetCipher(mockCipher)
+		handler.SetOrchestrationAPI(mockOrchestration)
+		handler.setHelper(mockHelper)
+		handler.netHelper = mockNetHelper
+
+		_, appCommand := getReqeustArgs()
+		appCommand["Limits"] = map[string]interface{}{"HealthCheck": map[string]interface{}{"Interval": 1000.0}}
+
+		gomock.InOrder(
+			mockNetHelper.EXPECT().GetIPs().Return([]string{addr}, nil),
+			mockCipher.EXPECT().DecryptByteToJSON(gomock.Any()).Return(appCommand, nil),
+			mockCipher.EXPECT().EncryptJSONToByte(gomock.Any()).Do(func(resp map[string]interface{}) {
+				if resp["Message"] != orchestrationapi.InvalidParameter {
+					t.Error("unexpected response")
+				}
+			}).Return(nil, nil),
+			mockHelper.EXPECT().Response(gomock.Any(), gomock.Any(), gomock.Eq(http.StatusOK)),
+		)
+
+		handler.APIV1RequestServicePost(w, r)
+	})
+	t.Run("SuccessWithReplicas", func(t *testing.T) {
+		handler.SetCipher(mockCipher)
+		handler.SetOrchestrationAPI(mockOrchestration)
+		handler.setHelper(mockHelper)
+		handler.netHelper = mockNetHelper
+
+		requestService, appCommand := getReqeustArgs()
+		requestService.Replicas = orchestrationapi.BroadcastReplicas
+		appCommand["Replicas"] = "broadcast"
+		resp := orchestrationapi.ResponseService{
+			Message:     orchestrationapi.ErrorNone,
+			ServiceName: "test",
+			ServiceID:   1,
+			Replicas: []orchestrationapi.ReplicaInfo{
+				{ServiceID: 1, RemoteTargetInfo: orchestrationapi.TargetInfo{ExecutionType: "native", Target: "1.1.1.1"}},
+				{ServiceID: 2, RemoteTargetInfo: orchestrationapi.TargetInfo{ExecutionType: "native", Target: "1.1.1.2"}},
+			},
+		}
+		respByte := []byte{'1'}
+
+		gomock.InOrder(
+			mockNetHelper.EXPECT().GetIPs().Return([]string{addr}, nil),
+			mockCipher.EXPECT().DecryptByteToJSON(gomock.Any()).Return(appCommand, nil),
+			mockOrchestration.EXPECT().RequestService(gomock.Eq(requestService)).Return(resp),
+			mockCipher.EXPECT().EncryptJSONToByte(gomock.Any()).Do(func(resp map[string]interface{}) {
+				replicas, ok := resp["Replicas"].([]map[string]interface{})
+				if !ok || len(replicas) != 2 || replicas[1]["Target"] != "1.1.1.2" {
+					t.Error("unexpected response")
+				}
+			}).Return(respByte, nil),
+			mockHelper.EXPECT().Response(gomock.Any(), gomock.Eq(respByte), gomock.Eq(http.StatusOK)),
+		)
+
+		handler.APIV1RequestServicePost(w, r)
+	})
+	t.Run("InvalidReplicas", func(t *testing.T) {
+		handler.SetCipher(mockCipher)
+		handler.SetOrchestrationAPI(mockOrchestration)
+		handler.setHelper(mockHelper)
+		handler.netHelper = mockNetHelper
+
+		for _, replicas := range []interface{}{0.0, 1.5, "all"} {
+			_, appCommand := getReqeustArgs()
+			appCommand["Replicas"] = replicas
+
+			gomock.InOrder(
+				mockNetHelper.EXPECT().GetIPs().Return([]string{addr}, nil),
+				mockCipher.EXPECT().DecryptByteToJSON(gomock.Any()).Return(appCommand, nil),
+				mockCipher.EXPECT().EncryptJSONToByte(gomock.Any()).Do(func(resp map[string]interface{}) {
+					if resp["Message"] != orchestrationapi.InvalidParameter {
+						t.Error("unexpected response")
+					}
+				}).Return(nil, nil),
+				mockHelper.EXPECT().Response(gomock.Any(), gomock.Any(), gomock.Eq(http.StatusOK)),
+			)
+
+			handler.APIV1RequestServicePost(w, r)
+		}
+	})
 }
 
 func getReqeustSecureArgs() (verifier.RequestVerifierConf, map[string]interface{}) {
@@ -715,3 +966,795 @@ func TestAPIV1RequestCloudSyncmgrGetSubscribedData(t *testing.T) {
 		})
 	})
 }
+
+func TestAPIV1RequestServicesGet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler := GetHandler()
+	if handler == nil {
+		t.Error("unexpected return value")
+	}
+
+	mockOrchestration := orchemock.NewMockOrcheExternalAPI(ctrl)
+	mockCipher := ciphermock.NewMockIEdgeCipherer(ctrl)
+	mockHelper := helpermock.NewMockRestHelper(ctrl)
+	mockNetHelper := networkhelper.NewMockNetwork(ctrl)
+
+	r := httptest.NewRequest("GET", "http://localhost:1234", nil)
+	w := httptest.NewRecorder()
+
+	addr := strings.Split(r.RemoteAddr, ":")[0]
+
+	t.Run("Error", func(t *testing.T) {
+		t.Run("IsNotSetApi", func(t *testing.T) {
+			handler.setHelper(mockHelper)
+			mockHelper.EXPECT().Response(gomock.Any(), gomock.Any(), gomock.Eq(http.StatusServiceUnavailable))
+
+			handler.isSetAPI = false
+			handler.APIV1RequestServicesGet(w, r)
+		})
+		t.Run("NotLocalRequester", func(t *testing.T) {
+			handler.SetCipher(mockCipher)
+			handler.SetOrchestrationAPI(mockOrchestration)
+			handler.setHelper(mockHelper)
+			handler.netHelper = mockNetHelper
+			gomock.InOrder(
+				mockNetHelper.EXPECT().GetIPs().Return([]string{"0.0.0.0"}, nil),
+				mockHelper.EXPECT().Response(gomock.Any(), gomock.Any(), gomock.Eq(http.StatusNotAcceptable)),
+			)
+
+			handler.APIV1RequestServicesGet(w, r)
+		})
+	})
+	t.Run("Success", func(t *testing.T) {
+		handler.SetCipher(mockCipher)
+		handler.SetOrchestrationAPI(mockOrchestration)
+		handler.setHelper(mockHelper)
+		handler.netHelper = mockNetHelper
+
+		respByte := []byte{'1'}
+		services := []map[string]interface{}{{"ServiceID": uint64(1)}}
+
+		gomock.InOrder(
+			mockNetHelper.EXPECT().GetIPs().Return([]string{addr}, nil),
+			mockOrchestration.EXPECT().ListServices().Return(orchestrationapi.ResponseServiceInfo{Message: orchestrationapi.ErrorNone, Services: services}),
+			mockCipher.EXPECT().EncryptJSONToByte(gomock.Any()).Do(func(resp map[string]interface{}) {
+				if resp["Message"] != orchestrationapi.ErrorNone || len(resp["Services"].([]map[string]interface{})) != 1 {
+					t.Error("unexpected response")
+				}
+			}).Return(respByte, nil),
+			mockHelper.EXPECT().Response(gomock.Any(), gomock.Eq(respByte), gomock.Eq(http.StatusOK)),
+		)
+
+		handler.APIV1RequestServicesGet(w, r)
+	})
+}
+
+func TestAPIV1RequestLabelsGet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler := GetHandler()
+	if handler == nil {
+		t.Error("unexpected return value")
+	}
+
+	mockOrchestration := orchemock.NewMockOrcheExternalAPI(ctrl)
+	mockCipher := ciphermock.NewMockIEdgeCipherer(ctrl)
+	mockHelper := helpermock.NewMockRestHelper(ctrl)
+	mockNetHelper := networkhelper.NewMockNetwork(ctrl)
+
+	r := httptest.NewRequest("GET", "http://localhost:1234/api/v1/orchestration/labels?room=kitchen", nil)
+	w := httptest.NewRecorder()
+
+	addr := strings.Split(r.RemoteAddr, ":")[0]
+
+	t.Run("Error", func(t *testing.T) {
+		t.Run("IsNotSetApi", func(t *testing.T) {
+			handler.setHelper(mockHelper)
+			mockHelper.EXPECT().Response(gomock.Any(), gomock.Any(), gomock.Eq(http.StatusServiceUnavailable))
+
+			handler.isSetAPI = false
+			handler.APIV1RequestLabelsGet(w, r)
+		})
+		t.Run("NotLocalRequester", func(t *testing.T) {
+			handler.SetCipher(mockCipher)
+			handler.SetOrchestrationAPI(mockOrchestration)
+			handler.setHelper(mockHelper)
+			handler.netHelper = mockNetHelper
+			gomock.InOrder(
+				mockNetHelper.EXPECT().GetIPs().Return([]string{"0.0.0.0"}, nil),
+				mockHelper.EXPECT().Response(gomock.Any(), gomock.Any(), gomock.Eq(http.StatusNotAcceptable)),
+			)
+
+			handler.APIV1RequestLabelsGet(w, r)
+		})
+	})
+	t.Run("Success", func(t *testing.T) {
+		handler.SetCipher(mockCipher)
+		handler.SetOrchestrationAPI(mockOrchestration)
+		handler.setHelper(mockHelper)
+		handler.netHelper = mockNetHelper
+
+		respByte := []byte{'1'}
+		devices := map[string]map[string]string{"edge-orchestration-1": {"room": "kitchen"}}
+
+		gomock.InOrder(
+			mockNetHelper.EXPECT().GetIPs().Return([]string{addr}, nil),
+			mockOrchestration.EXPECT().GetDeviceLabels(gomock.Eq(map[string]string{"room": "kitchen"})).Return(
+				orchestrationapi.ResponseDeviceLabels{Message: orchestrationapi.ErrorNone, Devices: devices}),
+			mockCipher.EXPECT().EncryptJSONToByte(gomock.Any()).Do(func(resp map[string]interface{}) {
+				if resp["Message"] != orchestrationapi.ErrorNone || len(resp["Devices"].(map[string]map[string]string)) != 1 {
+					t.Error("unexpected response")
+				}
+			}).Return(respByte, nil),
+			mockHelper.EXPECT().Response(gomock.Any(), gomock.Eq(respByte), gomock.Eq(http.StatusOK)),
+		)
+
+		handler.APIV1RequestLabelsGet(w, r)
+	})
+}
+
+func TestAPIV1RequestDevicesGet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler := GetHandler()
+	if handler == nil {
+		t.Error("unexpected return value")
+	}
+
+	mockOrchestration := orchemock.NewMockOrcheExternalAPI(ctrl)
+	mockCipher := ciphermock.NewMockIEdgeCipherer(ctrl)
+	mockHelper := helpermock.NewMockRestHelper(ctrl)
+	mockNetHelper := networkhelper.NewMockNetwork(ctrl)
+
+	r := httptest.NewRequest("GET", "http://localhost:1234/api/v1/orchestration/devices", nil)
+	w := httptest.NewRecorder()
+
+	addr := strings.Split(r.RemoteAddr, ":")[0]
+
+	t.Run("Error", func(t *testing.T) {
+		t.Run("IsNotSetApi", func(t *testing.T) {
+			handler.setHelper(mockHelper)
+			mockHelper.EXPECT().Response(gomock.Any(), gomock.Any(), gomock.Eq(http.StatusServiceUnavailable))
+
+			handler.isSetAPI = false
+			handler.APIV1RequestDevicesGet(w, r)
+		})
+		t.Run("NotLocalRequester", func(t *testing.T) {
+			handler.SetCipher(mockCipher)
+			handler.SetOrchestrationAPI(mockOrchestration)
+			handler.setHelper(mockHelper)
+			handler.netHelper = mockNetHelper
+			gomock.InOrder(
+				mockNetHelper.EXPECT().GetIPs().Return([]string{"0.0.0.0"}, nil),
+				mockHelper.EXPECT().Response(gomock.Any(), gomock.Any(), gomock.Eq(http.StatusNotAcceptable)),
+			)
+
+			handler.APIV1RequestDevicesGet(w, r)
+		})
+	})
+	t.Run("Success", func(t *testing.T) {
+		handler.SetCipher(mockCipher)
+		handler.SetOrchestrationAPI(mockOrchestration)
+		handler.setHelper(mockHelper)
+		handler.netHelper = mockNetHelper
+
+		respByte := []byte{'1'}
+		devices := []dbhelper.DeviceInfo{{ID: "edge-orchestration-1", State: "online"}}
+
+		gomock.InOrder(
+			mockNetHelper.EXPECT().GetIPs().Return([]string{addr}, nil),
+			mockOrchestration.EXPECT().GetDevices().Return(
+				orchestrationapi.ResponseDevices{Message: orchestrationapi.ErrorNone, Devices: devices}),
+			mockCipher.EXPECT().EncryptJSONToByte(gomock.Any()).Do(func(resp map[string]interface{}) {
+				if resp["Message"] != orchestrationapi.ErrorNone || len(resp["Devices"].([]dbhelper.DeviceInfo)) != 1 {
+					t.Error("unexpected response")
+				}
+			}).Return(respByte, nil),
+			mockHelper.EXPECT().Response(gomock.Any(), gomock.Eq(respByte), gomock.Eq(http.StatusOK)),
+		)
+
+		handler.APIV1RequestDevicesGet(w, r)
+	})
+}
+
+func TestAPIV1RequestDevicesDeviceIDGet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler := GetHandler()
+	if handler == nil {
+		t.Error("unexpected return value")
+	}
+
+	mockOrchestration := orchemock.NewMockOrcheExternalAPI(ctrl)
+	mockCipher := ciphermock.NewMockIEdgeCipherer(ctrl)
+	mockHelper := helpermock.NewMockRestHelper(ctrl)
+	mockNetHelper := networkhelper.NewMockNetwork(ctrl)
+
+	r := httptest.NewRequest("GET", "http://localhost:1234", nil)
+	w := httptest.NewRecorder()
+
+	addr := strings.Split(r.RemoteAddr, ":")[0]
+
+	handler.SetCipher(mockCipher)
+	handler.SetOrchestrationAPI(mockOrchestration)
+	handler.setHelper(mockHelper)
+	handler.netHelper = mockNetHelper
+
+	t.Run("Error", func(t *testing.T) {
+		t.Run("DeviceNotFound", func(t *testing.T) {
+			gomock.InOrder(
+				mockNetHelper.EXPECT().GetIPs().Return([]string{addr}, nil),
+				mockOrchestration.EXPECT().GetDevice("invalid").Return(orchestrationapi.ResponseDevices{Message: orchestrationapi.DeviceNotFound}),
+				mockCipher.EXPECT().EncryptJSONToByte(gomock.Any()).Do(func(resp map[string]interface{}) {
+					if resp["Message"] != orchestrationapi.DeviceNotFound {
+						t.Error("unexpected response")
+					}
+				}).Return(nil, nil),
+				mockHelper.EXPECT().Response(gomock.Any(), gomock.Any(), gomock.Eq(http.StatusOK)),
+			)
+
+			handler.APIV1RequestDevicesDeviceIDGet(w, mux.SetURLVars(r, map[string]string{"deviceid": "invalid"}))
+		})
+	})
+	t.Run("Success", func(t *testing.T) {
+		respByte := []byte{'1'}
+		devices := []dbhelper.DeviceInfo{{ID: "edge-orchestration-1", Platform: "linux"}}
+
+		gomock.InOrder(
+			mockNetHelper.EXPECT().GetIPs().Return([]string{addr}, nil),
+			mockOrchestration.EXPECT().GetDevice("edge-orchestration-1").Return(
+				orchestrationapi.ResponseDevices{Message: orchestrationapi.ErrorNone, Devices: devices}),
+			mockCipher.EXPECT().EncryptJSONToByte(gomock.Any()).Do(func(resp map[string]interface{}) {
+				if resp["Message"] != orchestrationapi.ErrorNone || resp["Devices"].([]dbhelper.DeviceInfo)[0].Platform != "linux" {
+					t.Error("unexpected response")
+				}
+			}).Return(respByte, nil),
+			mockHelper.EXPECT().Response(gomock.Any(), gomock.Eq(respByte), gomock.Eq(http.StatusOK)),
+		)
+
+		handler.APIV1RequestDevicesDeviceIDGet(w, mux.SetURLVars(r, map[string]string{"deviceid": "edge-orchestration-1"}))
+	})
+}
+
+func TestAPIV1RequestExecutionsGet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler := GetHandler()
+	if handler == nil {
+		t.Error("unexpected return value")
+	}
+
+	mockOrchestration := orchemock.NewMockOrcheExternalAPI(ctrl)
+	mockCipher := ciphermock.NewMockIEdgeCipherer(ctrl)
+	mockHelper := helpermock.NewMockRestHelper(ctrl)
+	mockNetHelper := networkhelper.NewMockNetwork(ctrl)
+
+	r := httptest.NewRequest("GET", "http://localhost:1234/api/v1/orchestration/executions?service=hello&from=2020-06-01T12:00:00Z", nil)
+	w := httptest.NewRecorder()
+
+	addr := strings.Split(r.RemoteAddr, ":")[0]
+
+	t.Run("Error", func(t *testing.T) {
+		t.Run("IsNotSetApi", func(t *testing.T) {
+			handler.setHelper(mockHelper)
+			mockHelper.EXPECT().Response(gomock.Any(), gomock.Any(), gomock.Eq(http.StatusServiceUnavailable))
+
+			handler.isSetAPI = false
+			handler.APIV1RequestExecutionsGet(w, r)
+		})
+		t.Run("InvalidTime", func(t *testing.T) {
+			handler.SetCipher(mockCipher)
+			handler.SetOrchestrationAPI(mockOrchestration)
+			handler.setHelper(mockHelper)
+			handler.netHelper = mockNetHelper
+
+			respByte := []byte{'1'}
+			invalid := httptest.NewRequest("GET", "http://localhost:1234/api/v1/orchestration/executions?to=yesterday", nil)
+			gomock.InOrder(
+				mockNetHelper.EXPECT().GetIPs().Return([]string{addr}, nil),
+				mockCipher.EXPECT().EncryptJSONToByte(gomock.Any()).Do(func(resp map[string]interface{}) {
+					if resp["Message"] != orchestrationapi.InvalidParameter {
+						t.Error("unexpected response")
+					}
+				}).Return(respByte, nil),
+				mockHelper.EXPECT().Response(gomock.Any(), gomock.Eq(respByte), gomock.Eq(http.StatusOK)),
+			)
+
+			handler.APIV1RequestExecutionsGet(w, invalid)
+		})
+	})
+	t.Run("Success", func(t *testing.T) {
+		handler.SetCipher(mockCipher)
+		handler.SetOrchestrationAPI(mockOrchestration)
+		handler.setHelper(mockHelper)
+		handler.netHelper = mockNetHelper
+
+		respByte := []byte{'1'}
+		filter := orchestrationapi.ExecutionFilter{
+			ServiceName: "hello",
+			From:        time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC),
+		}
+		executions := []execDB.Info{{ID: "1", ServiceName: "hello", Status: "Finished"}}
+
+		gomock.InOrder(
+			mockNetHelper.EXPECT().GetIPs().Return([]string{addr}, nil),
+			mockOrchestration.EXPECT().GetExecutions(gomock.Eq(filter)).Return(
+				orchestrationapi.ResponseExecutions{Message: orchestrationapi.ErrorNone, Executions: executions}),
+			mockCipher.EXPECT().EncryptJSONToByte(gomock.Any()).Do(func(resp map[string]interface{}) {
+				if resp["Message"] != orchestrationapi.ErrorNone || len(resp["Executions"].([]execDB.Info)) != 1 {
+					t.Error("unexpected response")
+				}
+			}).Return(respByte, nil),
+			mockHelper.EXPECT().Response(gomock.Any(), gomock.Eq(respByte), gomock.Eq(http.StatusOK)),
+		)
+
+		handler.APIV1RequestExecutionsGet(w, r)
+	})
+}
+
+func TestAPIV1RequestClientsGet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler := GetHandler()
+	if handler == nil {
+		t.Error("unexpected return value")
+	}
+
+	mockOrchestration := orchemock.NewMockOrcheExternalAPI(ctrl)
+	mockCipher := ciphermock.NewMockIEdgeCipherer(ctrl)
+	mockHelper := helpermock.NewMockRestHelper(ctrl)
+	mockNetHelper := networkhelper.NewMockNetwork(ctrl)
+
+	r := httptest.NewRequest("GET", "http://localhost:1234/api/v1/orchestration/clients", nil)
+	w := httptest.NewRecorder()
+
+	addr := strings.Split(r.RemoteAddr, ":")[0]
+
+	t.Run("Error", func(t *testing.T) {
+		t.Run("IsNotSetApi", func(t *testing.T) {
+			handler.setHelper(mockHelper)
+			mockHelper.EXPECT().Response(gomock.Any(), gomock.Any(), gomock.Eq(http.StatusServiceUnavailable))
+
+			handler.isSetAPI = false
+			handler.APIV1RequestClientsGet(w, r)
+		})
+	})
+	t.Run("Success", func(t *testing.T) {
+		handler.SetCipher(mockCipher)
+		handler.SetOrchestrationAPI(mockOrchestration)
+		handler.setHelper(mockHelper)
+		handler.netHelper = mockNetHelper
+
+		respByte := []byte{'1'}
+		stats := orchestrationapi.ClientStats{Active: 1, Registered: 3, Notified: 1, Evicted: 1}
+		gomock.InOrder(
+			mockNetHelper.EXPECT().GetIPs().Return([]string{addr}, nil),
+			mockOrchestration.EXPECT().GetClientStats().Return(
+				orchestrationapi.ResponseClientStats{Message: orchestrationapi.ErrorNone, Clients: stats}),
+			mockCipher.EXPECT().EncryptJSONToByte(gomock.Any()).Do(func(resp map[string]interface{}) {
+				if resp["Message"] != orchestrationapi.ErrorNone || resp["Clients"] != stats {
+					t.Error("unexpected response")
+				}
+			}).Return(respByte, nil),
+			mockHelper.EXPECT().Response(gomock.Any(), gomock.Eq(respByte), gomock.Eq(http.StatusOK)),
+		)
+
+		handler.APIV1RequestClientsGet(w, r)
+	})
+}
+
+func TestAPIV1RequestDevicesEventsGet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler := GetHandler()
+	if handler == nil {
+		t.Error("unexpected return value")
+	}
+
+	mockOrchestration := orchemock.NewMockOrcheExternalAPI(ctrl)
+	mockCipher := ciphermock.NewMockIEdgeCipherer(ctrl)
+	mockHelper := helpermock.NewMockRestHelper(ctrl)
+	mockNetHelper := networkhelper.NewMockNetwork(ctrl)
+
+	r := httptest.NewRequest("GET", "http://localhost:1234/api/v1/orchestration/devices/events", nil)
+
+	addr := strings.Split(r.RemoteAddr, ":")[0]
+
+	handler.SetCipher(mockCipher)
+	handler.SetOrchestrationAPI(mockOrchestration)
+	handler.setHelper(mockHelper)
+	handler.netHelper = mockNetHelper
+
+	t.Run("Error", func(t *testing.T) {
+		t.Run("NotReady", func(t *testing.T) {
+			w := httptest.NewRecorder()
+			gomock.InOrder(
+				mockNetHelper.EXPECT().GetIPs().Return([]string{addr}, nil),
+				mockOrchestration.EXPECT().WatchDevices().Return(nil, nil, orchestrationapi.InternalServerError),
+				mockCipher.EXPECT().EncryptJSONToByte(gomock.Any()).Do(func(resp map[string]interface{}) {
+					if resp["Message"] != orchestrationapi.InternalServerError {
+						t.Error("unexpected response")
+					}
+				}).Return(nil, nil),
+				mockHelper.EXPECT().Response(gomock.Any(), gomock.Any(), gomock.Eq(http.StatusOK)),
+			)
+
+			handler.APIV1RequestDevicesEventsGet(w, r)
+		})
+	})
+	t.Run("Success", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		events := make(chan map[string]interface{}, 2)
+		events <- map[string]interface{}{"State": "degraded"}
+		events <- map[string]interface{}{"State": "unreachable"}
+		close(events)
+
+		canceled := false
+		gomock.InOrder(
+			mockNetHelper.EXPECT().GetIPs().Return([]string{addr}, nil),
+			mockOrchestration.EXPECT().WatchDevices().Return((<-chan map[string]interface{})(events), func() { canceled = true }, orchestrationapi.ErrorNone),
+			mockCipher.EXPECT().EncryptJSONToByte(gomock.Any()).Return([]byte("degraded"), nil),
+			mockCipher.EXPECT().EncryptJSONToByte(gomock.Any()).Return([]byte("unreachable"), nil),
+		)
+
+		handler.APIV1RequestDevicesEventsGet(w, r)
+
+		if w.Header().Get("Content-Type") != "text/event-stream" {
+			t.Error("unexpected content type :", w.Header().Get("Content-Type"))
+		}
+		expected := "event: state\ndata: degraded\n\nevent: state\ndata: unreachable\n\n"
+		if w.Body.String() != expected {
+			t.Error("unexpected stream :", w.Body.String())
+		}
+		if !canceled {
+			t.Error("watching is not canceled")
+		}
+	})
+}
+
+func TestAPIV1RequestServiceServiceIDGet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler := GetHandler()
+	if handler == nil {
+		t.Error("unexpected return value")
+	}
+
+	mockOrchestration := orchemock.NewMockOrcheExternalAPI(ctrl)
+	mockCipher := ciphermock.NewMockIEdgeCipherer(ctrl)
+	mockHelper := helpermock.NewMockRestHelper(ctrl)
+	mockNetHelper := networkhelper.NewMockNetwork(ctrl)
+
+	r := httptest.NewRequest("GET", "http://localhost:1234", nil)
+	w := httptest.NewRecorder()
+
+	addr := strings.Split(r.RemoteAddr, ":")[0]
+
+	handler.SetCipher(mockCipher)
+	handler.SetOrchestrationAPI(mockOrchestration)
+	handler.setHelper(mockHelper)
+	handler.netHelper = mockNetHelper
+
+	t.Run("Error", func(t *testing.T) {
+		t.Run("InvalidServiceID", func(t *testing.T) {
+			gomock.InOrder(
+				mockNetHelper.EXPECT().GetIPs().Return([]string{addr}, nil),
+				mockCipher.EXPECT().EncryptJSONToByte(gomock.Any()).Do(func(resp map[string]interface{}) {
+					if resp["Message"] != orchestrationapi.InvalidParameter {
+						t.Error("unexpected response")
+					}
+				}).Return(nil, nil),
+				mockHelper.EXPECT().Response(gomock.Any(), gomock.Any(), gomock.Eq(http.StatusOK)),
+			)
+
+			handler.APIV1RequestServiceServiceIDGet(w, mux.SetURLVars(r, map[string]string{"serviceid": "invalid"}))
+		})
+	})
+	t.Run("Success", func(t *testing.T) {
+		respByte := []byte{'1'}
+
+		gomock.InOrder(
+			mockNetHelper.EXPECT().GetIPs().Return([]string{addr}, nil),
+			mockOrchestration.EXPECT().GetService(uint64(1)).Return(orchestrationapi.ResponseServiceInfo{Message: orchestrationapi.ErrorNone}),
+			mockCipher.EXPECT().EncryptJSONToByte(gomock.Any()).Return(respByte, nil),
+			mockHelper.EXPECT().Response(gomock.Any(), gomock.Eq(respByte), gomock.Eq(http.StatusOK)),
+		)
+
+		handler.APIV1RequestServiceServiceIDGet(w, mux.SetURLVars(r, map[string]string{"serviceid": "1"}))
+	})
+}
+
+func TestAPIV1RequestServiceServiceIDEventsGet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler := GetHandler()
+	if handler == nil {
+		t.Error("unexpected return value")
+	}
+
+	mockOrchestration := orchemock.NewMockOrcheExternalAPI(ctrl)
+	mockCipher := ciphermock.NewMockIEdgeCipherer(ctrl)
+	mockHelper := helpermock.NewMockRestHelper(ctrl)
+	mockNetHelper := networkhelper.NewMockNetwork(ctrl)
+
+	r := httptest.NewRequest("GET", "http://localhost:1234", nil)
+
+	addr := strings.Split(r.RemoteAddr, ":")[0]
+
+	handler.SetCipher(mockCipher)
+	handler.SetOrchestrationAPI(mockOrchestration)
+	handler.setHelper(mockHelper)
+	handler.netHelper = mockNetHelper
+
+	t.Run("Error", func(t *testing.T) {
+		t.Run("ServiceNotFound", func(t *testing.T) {
+			w := httptest.NewRecorder()
+			gomock.InOrder(
+				mockNetHelper.EXPECT().GetIPs().Return([]string{addr}, nil),
+				mockOrchestration.EXPECT().WatchService(uint64(1)).Return(nil, nil, orchestrationapi.ServiceNotFound),
+				mockCipher.EXPECT().EncryptJSONToByte(gomock.Any()).Do(func(resp map[string]interface{}) {
+					if resp["Message"] != orchestrationapi.ServiceNotFound {
+						t.Error("unexpected response")
+					}
+				}).Return(nil, nil),
+				mockHelper.EXPECT().Response(gomock.Any(), gomock.Any(), gomock.Eq(http.StatusOK)),
+			)
+
+			handler.APIV1RequestServiceServiceIDEventsGet(w, mux.SetURLVars(r, map[string]string{"serviceid": "1"}))
+		})
+	})
+	t.Run("Success", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		events := make(chan map[string]interface{}, 2)
+		events <- map[string]interface{}{"Status": "Started"}
+		events <- map[string]interface{}{"Status": "Finished"}
+		close(events)
+
+		canceled := false
+		gomock.InOrder(
+			mockNetHelper.EXPECT().GetIPs().Return([]string{addr}, nil),
+			mockOrchestration.EXPECT().WatchService(uint64(1)).Return((<-chan map[string]interface{})(events), func() { canceled = true }, orchestrationapi.ErrorNone),
+			mockCipher.EXPECT().EncryptJSONToByte(gomock.Any()).Return([]byte("started"), nil),
+			mockCipher.EXPECT().EncryptJSONToByte(gomock.Any()).Return([]byte("finished"), nil),
+		)
+
+		handler.APIV1RequestServiceServiceIDEventsGet(w, mux.SetURLVars(r, map[string]string{"serviceid": "1"}))
+
+		if w.Header().Get("Content-Type") != "text/event-stream" {
+			t.Error("unexpected content type :", w.Header().Get("Content-Type"))
+		}
+		expected := "event: status\ndata: started\n\nevent: status\ndata: finished\n\n"
+		if w.Body.String() != expected {
+			t.Error("unexpected stream :", w.Body.String())
+		}
+		if !canceled {
+			t.Error("watching is not canceled")
+		}
+	})
+}
+
+func TestAPIV1RequestServiceServiceIDLogsGet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler := GetHandler()
+	if handler == nil {
+		t.Error("unexpected return value")
+	}
+
+	mockOrchestration := orchemock.NewMockOrcheExternalAPI(ctrl)
+	mockCipher := ciphermock.NewMockIEdgeCipherer(ctrl)
+	mockHelper := helpermock.NewMockRestHelper(ctrl)
+	mockNetHelper := networkhelper.NewMockNetwork(ctrl)
+
+	handler.SetCipher(mockCipher)
+	handler.SetOrchestrationAPI(mockOrchestration)
+	handler.setHelper(mockHelper)
+	handler.netHelper = mockNetHelper
+
+	entries := []servicelog.Entry{
+		{Stream: servicelog.StreamStdout, Message: "hello"},
+		{Stream: servicelog.StreamStderr, Message: "world"},
+	}
+	newLogs := func() <-chan servicelog.Entry {
+		logs := make(chan servicelog.Entry, len(entries))
+		for _, entry := range entries {
+			logs <- entry
+		}
+		close(logs)
+		return logs
+	}
+
+	t.Run("Error", func(t *testing.T) {
+		t.Run("InvalidTail", func(t *testing.T) {
+			r := httptest.NewRequest("GET", "http://localhost:1234/api/v1/orchestration/services/1/logs?tail=invalid", nil)
+			addr := strings.Split(r.RemoteAddr, ":")[0]
+			gomock.InOrder(
+				mockNetHelper.EXPECT().GetIPs().Return([]string{addr}, nil),
+				mockCipher.EXPECT().EncryptJSONToByte(gomock.Any()).Do(func(resp map[string]interface{}) {
+					if resp["Message"] != orchestrationapi.InvalidParameter {
+						t.Error("unexpected response")
+					}
+				}).Return(nil, nil),
+				mockHelper.EXPECT().Response(gomock.Any(), gomock.Any(), gomock.Eq(http.StatusOK)),
+			)
+
+			handler.APIV1RequestServiceServiceIDLogsGet(httptest.NewRecorder(), mux.SetURLVars(r, map[string]string{"serviceid": "1"}))
+		})
+		t.Run("ServiceNotFound", func(t *testing.T) {
+			r := httptest.NewRequest("GET", "http://localhost:1234/api/v1/orchestration/services/1/logs", nil)
+			addr := strings.Split(r.RemoteAddr, ":")[0]
+			gomock.InOrder(
+				mockNetHelper.EXPECT().GetIPs().Return([]string{addr}, nil),
+				mockOrchestration.EXPECT().GetServiceLogs(uint64(1), 0, false).Return(nil, nil, orchestrationapi.ServiceNotFound),
+				mockCipher.EXPECT().EncryptJSONToByte(gomock.Any()).Do(func(resp map[string]interface{}) {
+					if resp["Message"] != orchestrationapi.ServiceNotFound {
+						t.Error("unexpected response")
+					}
+				}).Return(nil, nil),
+				mockHelper.EXPECT().Response(gomock.Any(), gomock.Any(), gomock.Eq(http.StatusOK)),
+			)
+
+			handler.APIV1RequestServiceServiceIDLogsGet(httptest.NewRecorder(), mux.SetURLVars(r, map[string]string{"serviceid": "1"}))
+		})
+	})
+	t.Run("Success", func(t *testing.T) {
+		t.Run("Tail", func(t *testing.T) {
+			r := httptest.NewRequest("GET", "http://localhost:1234/api/v1/orchestration/services/1/logs?tail=2", nil)
+			addr := strings.Split(r.RemoteAddr, ":")[0]
+			gomock.InOrder(
+				mockNetHelper.EXPECT().GetIPs().Return([]string{addr}, nil),
+				mockOrchestration.EXPECT().GetServiceLogs(uint64(1), 2, false).Return(newLogs(), func() {}, orchestrationapi.ErrorNone),
+				mockCipher.EXPECT().EncryptJSONToByte(gomock.Any()).Do(func(resp map[string]interface{}) {
+					if resp["Message"] != orchestrationapi.ErrorNone || !reflect.DeepEqual(resp["Logs"], entries) {
+						t.Error("unexpected response", resp)
+					}
+				}).Return(nil, nil),
+				mockHelper.EXPECT().Response(gomock.Any(), gomock.Any(), gomock.Eq(http.StatusOK)),
+			)
+
+			handler.APIV1RequestServiceServiceIDLogsGet(httptest.NewRecorder(), mux.SetURLVars(r, map[string]string{"serviceid": "1"}))
+		})
+		t.Run("Follow", func(t *testing.T) {
+			r := httptest.NewRequest("GET", "http://localhost:1234/api/v1/orchestration/services/1/logs?follow=true", nil)
+			addr := strings.Split(r.RemoteAddr, ":")[0]
+			w := httptest.NewRecorder()
+
+			canceled := false
+			gomock.InOrder(
+				mockNetHelper.EXPECT().GetIPs().Return([]string{addr}, nil),
+				mockOrchestration.EXPECT().GetServiceLogs(uint64(1), 0, true).Return(newLogs(), func() { canceled = true }, orchestrationapi.ErrorNone),
+				mockCipher.EXPECT().EncryptJSONToByte(gomock.Any()).Return([]byte("hello"), nil),
+				mockCipher.EXPECT().EncryptJSONToByte(gomock.Any()).Return([]byte("world"), nil),
+			)
+
+			handler.APIV1RequestServiceServiceIDLogsGet(w, mux.SetURLVars(r, map[string]string{"serviceid": "1"}))
+
+			if w.Header().Get("Content-Type") != "text/event-stream" {
+				t.Error("unexpected content type :", w.Header().Get("Content-Type"))
+			}
+			expected := "event: log\ndata: hello\n\nevent: log\ndata: world\n\n"
+			if w.Body.String() != expected {
+				t.Error("unexpected stream :", w.Body.String())
+			}
+			if !canceled {
+				t.Error("following is not canceled")
+			}
+		})
+	})
+}
+
+func TestAPIV1RequestServiceServiceIDDelete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler := GetHandler()
+	if handler == nil {
+		t.Error("unexpected return value")
+	}
+
+	mockOrchestration := orchemock.NewMockOrcheExternalAPI(ctrl)
+	mockCipher := ciphermock.NewMockIEdgeCipherer(ctrl)
+	mockHelper := helpermock.NewMockRestHelper(ctrl)
+	mockNetHelper := networkhelper.NewMockNetwork(ctrl)
+
+	r := httptest.NewRequest("DELETE", "http://localhost:1234", nil)
+	w := httptest.NewRecorder()
+
+	addr := strings.Split(r.RemoteAddr, ":")[0]
+
+	t.Run("Error", func(t *testing.T) {
+		t.Run("IsNotSetKey", func(t *testing.T) {
+			handler.SetOrchestrationAPI(mockOrchestration)
+			handler.setHelper(mockHelper)
+			mockHelper.EXPECT().Response(gomock.Any(), gomock.Any(), gomock.Eq(http.StatusServiceUnavailable))
+
+			handler.IsSetKey = false
+			handler.APIV1RequestServiceServiceIDDelete(w, r)
+		})
+		t.Run("EncryptionFail", func(t *testing.T) {
+			handler.SetCipher(mockCipher)
+			handler.SetOrchestrationAPI(mockOrchestration)
+			handler.setHelper(mockHelper)
+			handler.netHelper = mockNetHelper
+			gomock.InOrder(
+				mockNetHelper.EXPECT().GetIPs().Return([]string{addr}, nil),
+				mockOrchestration.EXPECT().StopService(uint64(1)).Return(orchestrationapi.ServiceNotFound),
+				mockCipher.EXPECT().EncryptJSONToByte(gomock.Any()).Return(nil, errors.New("")),
+				mockHelper.EXPECT().Response(gomock.Any(), gomock.Any(), gomock.Eq(http.StatusServiceUnavailable)),
+			)
+
+			handler.APIV1RequestServiceServiceIDDelete(w, mux.SetURLVars(r, map[string]string{"serviceid": "1"}))
+		})
+	})
+	t.Run("Success", func(t *testing.T) {
+		handler.SetCipher(mockCipher)
+		handler.SetOrchestrationAPI(mockOrchestration)
+		handler.setHelper(mockHelper)
+		handler.netHelper = mockNetHelper
+
+		respByte := []byte{'1'}
+
+		gomock.InOrder(
+			mockNetHelper.EXPECT().GetIPs().Return([]string{addr}, nil),
+			mockOrchestration.EXPECT().StopService(uint64(1)).Return(orchestrationapi.ErrorNone),
+			mockCipher.EXPECT().EncryptJSONToByte(gomock.Any()).Do(func(resp map[string]interface{}) {
+				if resp["Message"] != orchestrationapi.ErrorNone {
+					t.Error("unexpected response")
+				}
+			}).Return(respByte, nil),
+			mockHelper.EXPECT().Response(gomock.Any(), gomock.Eq(respByte), gomock.Eq(http.StatusOK)),
+		)
+
+		handler.APIV1RequestServiceServiceIDDelete(w, mux.SetURLVars(r, map[string]string{"serviceid": "1"}))
+	})
+}
+
+func TestCheckLocalRequester(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler := GetHandler()
+	mockHelper := helpermock.NewMockRestHelper(ctrl)
+	mockNetHelper := networkhelper.NewMockNetwork(ctrl)
+	handler.setHelper(mockHelper)
+	handler.netHelper = mockNetHelper
+
+	mockNetHelper.EXPECT().GetIPs().Return([]string{"192.168.1.2", "fd00::2"}, nil).AnyTimes()
+
+	tests := []struct {
+		remoteAddr string
+		expected   bool
+	}{
+		{"127.0.0.1:1234", true},
+		{"[::1]:1234", true},
+		{"192.168.1.2:1234", true},
+		{"[fd00::2]:1234", true},
+		{"192.168.1.3:1234", false},
+		{"[fd00::3]:1234", false},
+	}
+	for _, test := range tests {
+		t.Run(test.remoteAddr, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "http://localhost:1234/api/v1/orchestration/devices", nil)
+			r.RemoteAddr = test.remoteAddr
+			w := httptest.NewRecorder()
+			if !test.expected {
+				mockHelper.EXPECT().Response(gomock.Any(), gomock.Any(), gomock.Eq(http.StatusNotAcceptable))
+			}
+
+			if handler.checkLocalRequester(w, r) != test.expected {
+				t.Error("unexpected result")
+			}
+		})
+	}
+}
@@ -23,9 +23,13 @@ import (
 	"io"
 	"net"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/gorilla/mux"
 	"github.com/lf-edge/edge-home-orchestration-go/internal/common/commandvalidator"
+	"github.com/lf-edge/edge-home-orchestration-go/internal/common/errors"
 	"github.com/lf-edge/edge-home-orchestration-go/internal/common/logmgr"
 	"github.com/lf-edge/edge-home-orchestration-go/internal/common/requestervalidator"
 	"github.com/lf-edge/edge-home-orchestration-go/internal/common/types/servicemgrtypes"
@@ -78,6 +82,20 @@ func init() {
 			HandlerFunc: handler.APIV1ServicemgrServicesPost,
 		},
 
+		restinterface.Route{
+			Name:        "APIV1ServicemgrServicesServiceIDDelete",
+			Method:      strings.ToUpper("Delete"),
+			Pattern:     "/api/v1/servicemgr/services/{serviceid}",
+			HandlerFunc: handler.APIV1ServicemgrServicesServiceIDDelete,
+		},
+
+		restinterface.Route{
+			Name:        "APIV1ServicemgrServicesServiceIDLogsGet",
+			Method:      strings.ToUpper("Get"),
+			Pattern:     "/api/v1/servicemgr/services/{serviceid}/logs",
+			HandlerFunc: handler.APIV1ServicemgrServicesServiceIDLogsGet,
+		},
+
 		restinterface.Route{
 			Name:        "APIV1ServicemgrServicesNotificationServiceIDPost",
 			Method:      strings.ToUpper("Post"),
@@ -210,6 +228,107 @@ func (h *Handler) APIV1ServicemgrServicesPost(w http.ResponseWriter, r *http.Req
 	h.helper.Response(w, respEncryptBytes, http.StatusOK)
 }
 
+// APIV1ServicemgrServicesServiceIDDelete handles service stop request from remote orchestration
+func (h *Handler) APIV1ServicemgrServicesServiceIDDelete(w http.ResponseWriter, r *http.Request) {
+	log.Info(logPrefix, " APIV1ServicemgrServicesServiceIDDelete")
+	if !h.isSetAPI {
+		log.Error(logPrefix, doesNotSetAPI)
+		h.helper.Response(w, nil, http.StatusServiceUnavailable)
+		return
+	} else if !h.IsSetKey {
+		log.Error(logPrefix, doesNotSetKey)
+		h.helper.Response(w, nil, http.StatusServiceUnavailable)
+		return
+	}
+
+	remoteAddr, _, _ := net.SplitHostPort(r.RemoteAddr)
+
+	serviceID, err := strconv.ParseUint(mux.Vars(r)["serviceid"], 10, 64)
+	if err != nil {
+		log.Error(logPrefix, " invalid service id")
+		h.helper.Response(w, nil, http.StatusBadRequest)
+		return
+	}
+
+	err = h.api.StopAppOnLocal(serviceID, remoteAddr)
+	if err != nil {
+		log.Error(logPrefix, " StopAppOnLocal fail : ", err.Error())
+		switch err.(type) {
+		case errors.NotFound:
+			h.helper.Response(w, nil, http.StatusNotFound)
+		case errors.NotSupport:
+			h.helper.Response(w, nil, http.StatusNotImplemented)
+		default:
+			h.helper.Response(w, nil, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	h.helper.Response(w, nil, http.StatusOK)
+}
+
+// APIV1ServicemgrServicesServiceIDLogsGet handles the log request of the service from remote orchestration
+func (h *Handler) APIV1ServicemgrServicesServiceIDLogsGet(w http.ResponseWriter, r *http.Request) {
+	log.Info(logPrefix, " APIV1ServicemgrServicesServiceIDLogsGet")
+	if !h.isSetAPI {
+		log.Error(logPrefix, doesNotSetAPI)
+		h.helper.Response(w, nil, http.StatusServiceUnavailable)
+		return
+	} else if !h.IsSetKey {
+		log.Error(logPrefix, doesNotSetKey)
+		h.helper.Response(w, nil, http.StatusServiceUnavailable)
+		return
+	}
+
+	remoteAddr, _, _ := net.SplitHostPort(r.RemoteAddr)
+
+	serviceID, err := strconv.ParseUint(mux.Vars(r)["serviceid"], 10, 64)
+	if err != nil {
+		log.Error(logPrefix, " invalid service id")
+		h.helper.Response(w, nil, http.StatusBadRequest)
+		return
+	}
+
+	query := r.URL.Query()
+	tail := 0
+	if value := query.Get("tail"); len(value) > 0 {
+		if tail, err = strconv.Atoi(value); err != nil {
+			log.Error(logPrefix, " invalid tail")
+			h.helper.Response(w, nil, http.StatusBadRequest)
+			return
+		}
+	}
+	var since time.Time
+	if value := query.Get("since"); len(value) > 0 {
+		if since, err = time.Parse(time.RFC3339Nano, value); err != nil {
+			log.Error(logPrefix, " invalid since")
+			h.helper.Response(w, nil, http.StatusBadRequest)
+			return
+		}
+	}
+
+	entries, finished, err := h.api.ReadLogsOnLocal(serviceID, remoteAddr, tail, since)
+	if err != nil {
+		log.Error(logPrefix, " ReadLogsOnLocal fail : ", err.Error())
+		switch err.(type) {
+		case errors.NotFound:
+			h.helper.Response(w, nil, http.StatusNotFound)
+		default:
+			h.helper.Response(w, nil, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	respEncryptBytes, err := h.Key.EncryptJSONToByte(map[string]interface{}{"Logs": entries, "Finished": finished})
+	if err != nil {
+		log.Error(logPrefix, cannotEncryption, err.Error())
+		h.helper.Response(w, nil, http.StatusServiceUnavailable)
+		return
+	}
+
+	h.helper.Response(w, respEncryptBytes, http.StatusOK)
+}
+
 // APIV1ServicemgrServicesNotificationServiceIDPost handles service notification request from remote orchestration
 func (h *Handler) APIV1ServicemgrServicesNotificationServiceIDPost(w http.ResponseWriter, r *http.Request) {
 	log.Info(logPrefix, " APIV1ServicemgrServicesNotificationServiceIDPost")
@@ -235,7 +354,26 @@ func (h *Handler) APIV1ServicemgrServicesNotificationServiceIDPost(w http.Respon
 	serviceID := statusNotification["ServiceID"].(float64)
 	status := statusNotification["Status"].(string)
 
-	err = h.api.HandleNotificationOnLocal(serviceID, status)
+	if progress, ok := statusNotification["Progress"].(map[string]interface{}); ok {
+		if err = h.api.HandleProgressOnLocal(serviceID, progress); err != nil {
+			h.helper.Response(w, nil, http.StatusInternalServerError)
+			return
+		}
+		handler.helper.Response(w, nil, http.StatusOK)
+		return
+	}
+
+	// ExitCode is not sent by the devices of the previous version
+	exitCode := 0
+	if code, ok := statusNotification["ExitCode"].(float64); ok {
+		exitCode = int(code)
+	}
+
+	if signal, ok := statusNotification["Signal"].(string); ok && len(signal) > 0 {
+		err = h.api.HandleSignaledOnLocal(serviceID, status, exitCode, signal)
+	} else {
+		err = h.api.HandleNotificationOnLocal(serviceID, status, exitCode)
+	}
 	if err != nil {
 		h.helper.Response(w, nil, http.StatusInternalServerError)
 		return
@@ -388,6 +526,12 @@ func (h *Handler) APIV1DiscoverymgrOrchestrationInfoGet(w http.ResponseWriter, r
 	respJSONMsg["ExecutionType"] = execution
 	respJSONMsg["ServiceList"] = serviceList
 
+	// @Note : the device discovered without mDNS is identified by them
+	if deviceID, labels, err := h.api.GetDeviceInfo(); err == nil {
+		respJSONMsg["DeviceID"] = deviceID
+		respJSONMsg["Labels"] = labels
+	}
+
 	respEncryptBytes, err := h.Key.EncryptJSONToByte(respJSONMsg)
 	if err != nil {
 		log.Error(logPrefix, cannotEncryption, err.Error())
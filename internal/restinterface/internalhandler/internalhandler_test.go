@@ -22,17 +22,21 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"time"
 
 	"testing"
 
 	"github.com/lf-edge/edge-home-orchestration-go/internal/common/commandvalidator"
+	errormsg "github.com/lf-edge/edge-home-orchestration-go/internal/common/errors"
 	"github.com/lf-edge/edge-home-orchestration-go/internal/common/requestervalidator"
 	"github.com/lf-edge/edge-home-orchestration-go/internal/common/types/configuremgrtypes"
+	"github.com/lf-edge/edge-home-orchestration-go/internal/controller/servicemgr/servicelog"
 	orchemock "github.com/lf-edge/edge-home-orchestration-go/internal/orchestrationapi/mocks"
 	ciphermock "github.com/lf-edge/edge-home-orchestration-go/internal/restinterface/cipher/mocks"
 	helpermock "github.com/lf-edge/edge-home-orchestration-go/internal/restinterface/resthelper/mocks"
 
 	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
 )
 
 func TestGetHandler(t *testing.T) {
@@ -184,9 +188,11 @@ func TestAPIV1ServicemgrServicesNotificationServiceIDPost(t *testing.T) {
 
 	serviceID := float64(1.0)
 	status := "Testing"
+	exitCode := 3
 	notification := make(map[string]interface{})
 	notification["ServiceID"] = serviceID
 	notification["Status"] = status
+	notification["ExitCode"] = float64(exitCode)
 
 	r := httptest.NewRequest("POST", "http://test.test", nil)
 	w := httptest.NewRecorder()
@@ -224,7 +230,7 @@ func TestAPIV1ServicemgrServicesNotificationServiceIDPost(t *testing.T) {
 			handler.setHelper(mockHelper)
 			gomock.InOrder(
 				mockCipher.EXPECT().DecryptByteToJSON(gomock.Any()).Return(notification, nil),
-				mockOrchestration.EXPECT().HandleNotificationOnLocal(gomock.Eq(serviceID), gomock.Eq(status)).Return(errors.New("")),
+				mockOrchestration.EXPECT().HandleNotificationOnLocal(gomock.Eq(serviceID), gomock.Eq(status), gomock.Eq(exitCode)).Return(errors.New("")),
 				mockHelper.EXPECT().Response(gomock.Any(), gomock.Any(), gomock.Eq(http.StatusInternalServerError)),
 			)
 
@@ -238,12 +244,166 @@ func TestAPIV1ServicemgrServicesNotificationServiceIDPost(t *testing.T) {
 		handler.setHelper(mockHelper)
 		gomock.InOrder(
 			mockCipher.EXPECT().DecryptByteToJSON(gomock.Any()).Return(notification, nil),
-			mockOrchestration.EXPECT().HandleNotificationOnLocal(gomock.Eq(serviceID), gomock.Eq(status)).Return(nil),
+			mockOrchestration.EXPECT().HandleNotificationOnLocal(gomock.Eq(serviceID), gomock.Eq(status), gomock.Eq(exitCode)).Return(nil),
 			mockHelper.EXPECT().Response(gomock.Any(), gomock.Any(), gomock.Eq(http.StatusOK)),
 		)
 
 		handler.APIV1ServicemgrServicesNotificationServiceIDPost(w, r)
 	})
+	t.Run("Progress", func(t *testing.T) {
+		progress := map[string]interface{}{"Image": "alpine", "Current": float64(1), "Total": float64(2)}
+		handler.SetCipher(mockCipher)
+		handler.SetOrchestrationAPI(mockOrchestration)
+		handler.setHelper(mockHelper)
+		gomock.InOrder(
+			mockCipher.EXPECT().DecryptByteToJSON(gomock.Any()).Return(map[string]interface{}{
+				"ServiceID": serviceID, "Status": "Pulling", "Progress": progress,
+			}, nil),
+			mockOrchestration.EXPECT().HandleProgressOnLocal(gomock.Eq(serviceID), gomock.Eq(progress)).Return(nil),
+			mockHelper.EXPECT().Response(gomock.Any(), gomock.Any(), gomock.Eq(http.StatusOK)),
+		)
+
+		handler.APIV1ServicemgrServicesNotificationServiceIDPost(w, r)
+	})
+	t.Run("Signaled", func(t *testing.T) {
+		handler.SetCipher(mockCipher)
+		handler.SetOrchestrationAPI(mockOrchestration)
+		handler.setHelper(mockHelper)
+		gomock.InOrder(
+			mockCipher.EXPECT().DecryptByteToJSON(gomock.Any()).Return(map[string]interface{}{
+				"ServiceID": serviceID, "Status": "Failed", "ExitCode": float64(137), "Signal": "SIGKILL",
+			}, nil),
+			mockOrchestration.EXPECT().HandleSignaledOnLocal(gomock.Eq(serviceID), gomock.Eq("Failed"), gomock.Eq(137), gomock.Eq("SIGKILL")).Return(nil),
+			mockHelper.EXPECT().Response(gomock.Any(), gomock.Any(), gomock.Eq(http.StatusOK)),
+		)
+
+		handler.APIV1ServicemgrServicesNotificationServiceIDPost(w, r)
+	})
+}
+
+func TestAPIV1ServicemgrServicesServiceIDDelete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler := GetHandler()
+	if handler == nil {
+		t.Error("unexpected return value")
+	}
+
+	mockOrchestration := orchemock.NewMockOrcheInternalAPI(ctrl)
+	mockCipher := ciphermock.NewMockIEdgeCipherer(ctrl)
+	mockHelper := helpermock.NewMockRestHelper(ctrl)
+
+	r := httptest.NewRequest("DELETE", "http://test.test", nil)
+	w := httptest.NewRecorder()
+
+	remoteAddr := strings.Split(r.RemoteAddr, ":")[0]
+
+	t.Run("Error", func(t *testing.T) {
+		t.Run("IsNotSetApi", func(t *testing.T) {
+			handler.setHelper(mockHelper)
+			mockHelper.EXPECT().Response(gomock.Any(), gomock.Any(), gomock.Eq(http.StatusServiceUnavailable))
+
+			handler.isSetAPI = false
+			handler.APIV1ServicemgrServicesServiceIDDelete(w, r)
+		})
+		t.Run("InvalidServiceID", func(t *testing.T) {
+			handler.SetCipher(mockCipher)
+			handler.SetOrchestrationAPI(mockOrchestration)
+			handler.setHelper(mockHelper)
+			mockHelper.EXPECT().Response(gomock.Any(), gomock.Any(), gomock.Eq(http.StatusBadRequest))
+
+			handler.APIV1ServicemgrServicesServiceIDDelete(w, mux.SetURLVars(r, map[string]string{"serviceid": "invalid"}))
+		})
+		t.Run("NotRunning", func(t *testing.T) {
+			handler.SetCipher(mockCipher)
+			handler.SetOrchestrationAPI(mockOrchestration)
+			handler.setHelper(mockHelper)
+			gomock.InOrder(
+				mockOrchestration.EXPECT().StopAppOnLocal(uint64(1), remoteAddr).Return(errormsg.NotFound{}),
+				mockHelper.EXPECT().Response(gomock.Any(), gomock.Any(), gomock.Eq(http.StatusNotFound)),
+			)
+
+			handler.APIV1ServicemgrServicesServiceIDDelete(w, mux.SetURLVars(r, map[string]string{"serviceid": "1"}))
+		})
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		handler.SetCipher(mockCipher)
+		handler.SetOrchestrationAPI(mockOrchestration)
+		handler.setHelper(mockHelper)
+		gomock.InOrder(
+			mockOrchestration.EXPECT().StopAppOnLocal(uint64(1), remoteAddr).Return(nil),
+			mockHelper.EXPECT().Response(gomock.Any(), gomock.Any(), gomock.Eq(http.StatusOK)),
+		)
+
+		handler.APIV1ServicemgrServicesServiceIDDelete(w, mux.SetURLVars(r, map[string]string{"serviceid": "1"}))
+	})
+}
+
+func TestAPIV1ServicemgrServicesServiceIDLogsGet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler := GetHandler()
+	if handler == nil {
+		t.Error("unexpected return value")
+	}
+
+	mockOrchestration := orchemock.NewMockOrcheInternalAPI(ctrl)
+	mockCipher := ciphermock.NewMockIEdgeCipherer(ctrl)
+	mockHelper := helpermock.NewMockRestHelper(ctrl)
+
+	since := time.Date(2020, 9, 1, 10, 0, 0, 0, time.UTC)
+	r := httptest.NewRequest("GET", "http://test.test/api/v1/servicemgr/services/1/logs?tail=10&since=2020-09-01T10:00:00Z", nil)
+	w := httptest.NewRecorder()
+
+	remoteAddr := strings.Split(r.RemoteAddr, ":")[0]
+
+	t.Run("Error", func(t *testing.T) {
+		t.Run("IsNotSetApi", func(t *testing.T) {
+			handler.setHelper(mockHelper)
+			mockHelper.EXPECT().Response(gomock.Any(), gomock.Any(), gomock.Eq(http.StatusServiceUnavailable))
+
+			handler.isSetAPI = false
+			handler.APIV1ServicemgrServicesServiceIDLogsGet(w, r)
+		})
+		t.Run("InvalidTail", func(t *testing.T) {
+			handler.SetCipher(mockCipher)
+			handler.SetOrchestrationAPI(mockOrchestration)
+			handler.setHelper(mockHelper)
+			mockHelper.EXPECT().Response(gomock.Any(), gomock.Any(), gomock.Eq(http.StatusBadRequest))
+
+			invalid := httptest.NewRequest("GET", "http://test.test/api/v1/servicemgr/services/1/logs?tail=invalid", nil)
+			handler.APIV1ServicemgrServicesServiceIDLogsGet(w, mux.SetURLVars(invalid, map[string]string{"serviceid": "1"}))
+		})
+		t.Run("NoLog", func(t *testing.T) {
+			handler.SetCipher(mockCipher)
+			handler.SetOrchestrationAPI(mockOrchestration)
+			handler.setHelper(mockHelper)
+			gomock.InOrder(
+				mockOrchestration.EXPECT().ReadLogsOnLocal(uint64(1), remoteAddr, 10, since).Return(nil, false, errormsg.NotFound{}),
+				mockHelper.EXPECT().Response(gomock.Any(), gomock.Any(), gomock.Eq(http.StatusNotFound)),
+			)
+
+			handler.APIV1ServicemgrServicesServiceIDLogsGet(w, mux.SetURLVars(r, map[string]string{"serviceid": "1"}))
+		})
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		handler.SetCipher(mockCipher)
+		handler.SetOrchestrationAPI(mockOrchestration)
+		handler.setHelper(mockHelper)
+
+		entries := []servicelog.Entry{{Time: since.Add(time.Second), Stream: servicelog.StreamStdout, Message: "hello"}}
+		gomock.InOrder(
+			mockOrchestration.EXPECT().ReadLogsOnLocal(uint64(1), remoteAddr, 10, since).Return(entries, true, nil),
+			mockCipher.EXPECT().EncryptJSONToByte(map[string]interface{}{"Logs": entries, "Finished": true}).Return([]byte{}, nil),
+			mockHelper.EXPECT().Response(gomock.Any(), gomock.Any(), gomock.Eq(http.StatusOK)),
+		)
+
+		handler.APIV1ServicemgrServicesServiceIDLogsGet(w, mux.SetURLVars(r, map[string]string{"serviceid": "1"}))
+	})
 }
 
 func TestAPIV1ScoringmgrScoreLibnameGet(t *testing.T) {
@@ -558,6 +718,7 @@ func TestAPIV1DiscoverymgrOrchInfoGet(t *testing.T) {
 		handler.setHelper(mockHelper)
 		gomock.InOrder(
 			mockOrchestration.EXPECT().GetOrchestrationInfo().Return("", "", []string{""}, nil),
+			mockOrchestration.EXPECT().GetDeviceInfo().Return("", nil, errors.New("")),
 			mockCipher.EXPECT().EncryptJSONToByte(gomock.Any()).Return(nil, errors.New("")),
 			mockHelper.EXPECT().Response(gomock.Any(), gomock.Any(), gomock.Eq(http.StatusServiceUnavailable)),
 		)
@@ -570,7 +731,15 @@ func TestAPIV1DiscoverymgrOrchInfoGet(t *testing.T) {
 		handler.setHelper(mockHelper)
 		gomock.InOrder(
 			mockOrchestration.EXPECT().GetOrchestrationInfo().Return("", "", []string{""}, nil),
-			mockCipher.EXPECT().EncryptJSONToByte(gomock.Any()).Return(nil, nil),
+			mockOrchestration.EXPECT().GetDeviceInfo().Return("edge-orchestration-test", map[string]string{"room": "kitchen"}, nil),
+			mockCipher.EXPECT().EncryptJSONToByte(gomock.Any()).DoAndReturn(
+				func(respJSONMsg map[string]interface{}) ([]byte, error) {
+					if respJSONMsg["DeviceID"] != "edge-orchestration-test" {
+						t.Error("unexpected device ID", respJSONMsg["DeviceID"])
+					}
+					return nil, nil
+				},
+			),
 			mockHelper.EXPECT().Response(gomock.Any(), gomock.Any(), gomock.Eq(http.StatusOK)),
 		)
 
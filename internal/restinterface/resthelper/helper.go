@@ -22,7 +22,9 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
+	"strconv"
 
 	"github.com/lf-edge/edge-home-orchestration-go/internal/common/logmgr"
 
@@ -206,7 +208,7 @@ func (h helperImpl) DoDelete(targetURL string) (respBytes []byte, statusCode int
 	return
 }
 
-// MakeTargetURL function
+// MakeTargetURL function, the IPv6 address of the target is enclosed in brackets
 func (h helperImpl) MakeTargetURL(target string, port int, restapi string) string {
 	var protocol string
 	if h.IsSetCert {
@@ -214,7 +216,7 @@ func (h helperImpl) MakeTargetURL(target string, port int, restapi string) strin
 	} else {
 		protocol = "http"
 	}
-	return fmt.Sprintf("%s://%s:%d%s", protocol, target, port, restapi)
+	return fmt.Sprintf("%s://%s%s", protocol, net.JoinHostPort(target, strconv.Itoa(port)), restapi)
 }
 
 // Response function
@@ -162,6 +162,17 @@ func TestMakeTargetURL(t *testing.T) {
 				t.Error("expect same, but not same")
 			}
 		})
+		t.Run("IPv6-MakeTargetURL", func(t *testing.T) {
+			target := "fd00::1"
+			port := 1234
+			restapi := "/api/v1/test"
+			expected := "http://[" + target + "]:" + strconv.Itoa(port) + restapi
+
+			fullURL := GetHelper().MakeTargetURL(target, port, restapi)
+			if expected != fullURL {
+				t.Error("expect same, but not same", fullURL)
+			}
+		})
 		t.Run("HTTPS-MakeTargetURL", func(t *testing.T) {
 			target := "testserver.test"
 			port := 1234
@@ -47,8 +47,16 @@ func (s *Server) EdgeGetText() []string {
 
 //EdgeResetServer react to interface change.
 //should be called when interface changed.
-func (s *Server) EdgeResetServer(newipv4s []net.IP) {
-	s.service.AddrIPv4 = newipv4s
+func (s *Server) EdgeResetServer(newips []net.IP) {
+	s.service.AddrIPv4 = nil
+	s.service.AddrIPv6 = nil
+	for _, ip := range newips {
+		if ip.To4() != nil {
+			s.service.AddrIPv4 = append(s.service.AddrIPv4, ip)
+		} else {
+			s.service.AddrIPv6 = append(s.service.AddrIPv6, ip)
+		}
+	}
 	select {
 	case EdgeExportedServiceEntry <- nil:
 	default:
@@ -60,8 +68,8 @@ func (s *Server) EdgeResetServer(newipv4s []net.IP) {
 
 // EdgeHandleQuery is used to handle an incoming query
 func (s *Server) edgeHandleQuery(msg *dns.Msg, ifIndex int, from net.Addr) error {
-	//IsFromIPv4?
-	_, err := s.edgeParseIPv4(from)
+	//IsFromIP?
+	_, err := s.edgeParseIP(from)
 	if err != nil {
 		return err
 	}
@@ -83,14 +91,12 @@ func (s *Server) edgeHandleQuery(msg *dns.Msg, ifIndex int, from net.Addr) error
 	return err
 }
 
-//EdgeParseIPv4 Palse ipv4 from net.Addr
-func (Server) edgeParseIPv4(from net.Addr) (string, error) {
-	deviceIPPORT := strings.Split(from.String(), ":")
-	srcIP := deviceIPPORT[0]
-
-	isV4 := net.ParseIP(srcIP)
-	if isV4.To4() == nil {
-		return "", errors.New("Do Not Handle IPv6")
+//EdgeParseIP Parse ipv4 or ipv6 from net.Addr
+func (Server) edgeParseIP(from net.Addr) (string, error) {
+	//link local ipv6 keeps the zone, e.g. fe80::1%eth0
+	srcIP, _, err := net.SplitHostPort(from.String())
+	if err != nil {
+		return "", err
 	}
 	return srcIP, nil
 }